@@ -45,64 +45,94 @@ func main() {
 	logger.Info().Msg("Connected to the SQL DB")
 
 	var (
-		//AI
-		ai = ai.NewAI()
 		//Storage
 		postgressStore = postgresstore.NewPostgresStorage(dbpool)
 		sqlStore       = sqlstore.NewSqlStorage(db)
 		store          = storage.Store{
-			User:              postgressStore.User,
-			Role:              postgressStore.Role,
-			Domain:            postgressStore.Domain,
-			Category:          postgressStore.Category,
-			Author:            postgressStore.Author,
-			Article:           postgressStore.Article,
-			CategoriesDomains: postgressStore.CategoriesDomains,
-			Image:             postgressStore.Image,
-			ImageCategory:     postgressStore.ImageCategory,
-			BasicPage:         postgressStore.BasicPage,
-			Scrapper:          sqlStore.Scrapper,
+			User:               postgressStore.User,
+			Role:               postgressStore.Role,
+			Domain:             postgressStore.Domain,
+			Category:           postgressStore.Category,
+			Author:             postgressStore.Author,
+			Article:            postgressStore.Article,
+			CategoriesDomains:  postgressStore.CategoriesDomains,
+			Image:              postgressStore.Image,
+			ImageCategory:      postgressStore.ImageCategory,
+			BasicPage:          postgressStore.BasicPage,
+			PromptTemplate:     postgressStore.PromptTemplate,
+			Usage:              postgressStore.Usage,
+			LlmCache:           postgressStore.LlmCache,
+			ArticleGeneration:  postgressStore.ArticleGeneration,
+			ArticleLink:        postgressStore.ArticleLink,
+			ArticleFaq:         postgressStore.ArticleFaq,
+			ArticleStatus:      postgressStore.ArticleStatus,
+			ArticleTranslation: postgressStore.ArticleTranslation,
+			ArticleRevision:    postgressStore.ArticleRevision,
+			CategoryEmbedding:  postgressStore.CategoryEmbedding,
+			CategoryAssignment: postgressStore.CategoryAssignment,
+			QuestionDuplicate:  postgressStore.QuestionDuplicate,
+			Scrapper:           sqlStore.Scrapper,
+		}
+		//AI
+		ai            = ai.NewAI(store.PromptTemplate, store.LlmCache)
+		articleStores = services.ArticleStores{
+			Article:            store.Article,
+			ArticleGeneration:  store.ArticleGeneration,
+			ArticleLink:        store.ArticleLink,
+			ArticleFaq:         store.ArticleFaq,
+			ArticleStatus:      store.ArticleStatus,
+			ArticleTranslation: store.ArticleTranslation,
+			ArticleRevision:    store.ArticleRevision,
+			QuestionDuplicate:  store.QuestionDuplicate,
+			Domain:             store.Domain,
+			PromptTemplate:     store.PromptTemplate,
 		}
 		//Validator
 		validator = validator.NewValidator()
 		//Services
-		authService      = services.NewAuthService(store.User)
-		articleService   = services.NewArticleService(store.Article, validator.Article, ai)
-		domainService    = services.NewDomainService(store.Domain, validator.Domain)
-		categoryService  = services.NewCategoryService(store.Category, store.CategoriesDomains, validator.Category)
-		scrapperService  = services.NewScrapperService(store.Scrapper, validator.Scrapper)
-		fileService      = services.NewFileService(store.Article, store.Domain, store.Category, store.Image)
-		basicPageService = services.NewBasicPageService(store.BasicPage, validator.BasicPage)
-		imageService     = services.NewImageService(store.Image, store.ImageCategory, validator.ImageCategory)
-		emailService     = services.NewEmailService()
-		authorService    = services.NewAuthorService(store.Author, validator.Author)
+		authService           = services.NewAuthService(store.User)
+		articleService        = services.NewArticleService(articleStores, validator.Article, ai)
+		domainService         = services.NewDomainService(store.Domain, validator.Domain)
+		categoryService       = services.NewCategoryService(store.Category, store.CategoriesDomains, store.CategoryEmbedding, store.CategoryAssignment, validator.Category, ai)
+		scrapperService       = services.NewScrapperService(store.Scrapper, validator.Scrapper)
+		fileService           = services.NewFileService(store.Article, store.Domain, store.Category, store.Image)
+		basicPageService      = services.NewBasicPageService(store.BasicPage, store.Domain, validator.BasicPage)
+		imageService          = services.NewImageService(store.Image, store.ImageCategory, validator.ImageCategory)
+		emailService          = services.NewEmailService()
+		authorService         = services.NewAuthorService(store.Author, validator.Author)
+		promptTemplateService = services.NewPromptTemplateService(store.PromptTemplate, store.Domain, validator.PromptTemplate)
+		usageService          = services.NewUsageService(store.Usage, validator.LlmModelPrice)
 		//Tasks
-		tasks         = ts.NewTasks(articleService, domainService, scrapperService, categoryService, imageService, ai)
+		tasks         = ts.NewTasks(articleService, domainService, scrapperService, categoryService, imageService, usageService, ai)
 		taskInspector = ts.NewTaskInspector()
 		//Controllers
-		authController      = controllers.NewAuthController(authService)
-		articleController   = controllers.NewArticleController(articleService, tasks.Article)
-		taskController      = controllers.NewTaskController(taskInspector)
-		domainController    = controllers.NewDomainController(domainService)
-		categoryController  = controllers.NewCategoryController(categoryService)
-		fileController      = controllers.NewFileController(fileService)
-		imageController     = controllers.NewImageController(imageService)
-		basicPageController = controllers.NewBasicPageController(basicPageService)
-		emailController     = controllers.NewEmailController(emailService)
-		authorController    = controllers.NewAuthorController(authorService)
-		scrapperController  = controllers.NewScrapperController(scrapperService)
-		apiControllers      = routes.ApiControllers{
-			Auth:      authController,
-			Article:   articleController,
-			Task:      taskController,
-			Domain:    domainController,
-			Category:  categoryController,
-			File:      fileController,
-			Image:     imageController,
-			BasicPage: basicPageController,
-			Email:     emailController,
-			Author:    authorController,
-			Scrapper:  scrapperController,
+		authController           = controllers.NewAuthController(authService)
+		articleController        = controllers.NewArticleController(articleService, authService, tasks.Article)
+		taskController           = controllers.NewTaskController(taskInspector)
+		domainController         = controllers.NewDomainController(domainService)
+		categoryController       = controllers.NewCategoryController(categoryService)
+		fileController           = controllers.NewFileController(fileService)
+		imageController          = controllers.NewImageController(imageService)
+		basicPageController      = controllers.NewBasicPageController(basicPageService)
+		emailController          = controllers.NewEmailController(emailService)
+		authorController         = controllers.NewAuthorController(authorService)
+		scrapperController       = controllers.NewScrapperController(scrapperService)
+		promptTemplateController = controllers.NewPromptTemplateController(promptTemplateService)
+		usageController          = controllers.NewUsageController(usageService)
+		apiControllers           = routes.ApiControllers{
+			Auth:           authController,
+			Article:        articleController,
+			Task:           taskController,
+			Domain:         domainController,
+			Category:       categoryController,
+			File:           fileController,
+			Image:          imageController,
+			BasicPage:      basicPageController,
+			Email:          emailController,
+			Author:         authorController,
+			Scrapper:       scrapperController,
+			PromptTemplate: promptTemplateController,
+			Usage:          usageController,
 		}
 		apiServices = routes.ApiServices{
 			Auth: authService,
@@ -43,39 +43,94 @@ func main() {
 
 	var (
 		validator      = validator.NewValidator()
-		ai             = ai.NewAI()
 		postgressStore = postgresstore.NewPostgresStorage(dbpool)
 		sqlStore       = sqlstore.NewSqlStorage(db)
 		store          = storage.Store{
-			User:              postgressStore.User,
-			Role:              postgressStore.Role,
-			Domain:            postgressStore.Domain,
-			Category:          postgressStore.Category,
-			Author:            postgressStore.Author,
-			Article:           postgressStore.Article,
-			CategoriesDomains: postgressStore.CategoriesDomains,
-			Image:             postgressStore.Image,
-			ImageCategory:     postgressStore.ImageCategory,
-			Scrapper:          sqlStore.Scrapper,
+			User:               postgressStore.User,
+			Role:               postgressStore.Role,
+			Domain:             postgressStore.Domain,
+			Category:           postgressStore.Category,
+			Author:             postgressStore.Author,
+			Article:            postgressStore.Article,
+			CategoriesDomains:  postgressStore.CategoriesDomains,
+			Image:              postgressStore.Image,
+			ImageCategory:      postgressStore.ImageCategory,
+			PromptTemplate:     postgressStore.PromptTemplate,
+			Usage:              postgressStore.Usage,
+			LlmCache:           postgressStore.LlmCache,
+			ArticleGeneration:  postgressStore.ArticleGeneration,
+			ArticleLink:        postgressStore.ArticleLink,
+			ArticleFaq:         postgressStore.ArticleFaq,
+			ArticleStatus:      postgressStore.ArticleStatus,
+			ArticleTranslation: postgressStore.ArticleTranslation,
+			ArticleRevision:    postgressStore.ArticleRevision,
+			CategoryEmbedding:  postgressStore.CategoryEmbedding,
+			CategoryAssignment: postgressStore.CategoryAssignment,
+			QuestionDuplicate:  postgressStore.QuestionDuplicate,
+			Scrapper:           sqlStore.Scrapper,
 		}
-		articleService  = services.NewArticleService(store.Article, validator.Article, ai)
+		ai            = ai.NewAI(store.PromptTemplate, store.LlmCache)
+		articleStores = services.ArticleStores{
+			Article:            store.Article,
+			ArticleGeneration:  store.ArticleGeneration,
+			ArticleLink:        store.ArticleLink,
+			ArticleFaq:         store.ArticleFaq,
+			ArticleStatus:      store.ArticleStatus,
+			ArticleTranslation: store.ArticleTranslation,
+			ArticleRevision:    store.ArticleRevision,
+			QuestionDuplicate:  store.QuestionDuplicate,
+			Domain:             store.Domain,
+			PromptTemplate:     store.PromptTemplate,
+		}
+		articleService  = services.NewArticleService(articleStores, validator.Article, ai)
 		domainService   = services.NewDomainService(store.Domain, validator.Domain)
-		categoryService = services.NewCategoryService(store.Category, store.CategoriesDomains, validator.Category)
+		categoryService = services.NewCategoryService(store.Category, store.CategoriesDomains, store.CategoryEmbedding, store.CategoryAssignment, validator.Category, ai)
 		scrapperService = services.NewScrapperService(store.Scrapper, validator.Scrapper)
 		imageService    = services.NewImageService(store.Image, store.ImageCategory, validator.ImageCategory)
-		tasks           = ts.NewTasks(articleService, domainService, scrapperService, categoryService, imageService, ai)
+		usageService    = services.NewUsageService(store.Usage, validator.LlmModelPrice)
+		tasks           = ts.NewTasks(articleService, domainService, scrapperService, categoryService, imageService, usageService, ai)
 	)
 
+	// Pause the queue while the AI provider is down instead of failing every task
+	queuePauser := ts.NewQueuePauser("default")
+	queuePauser.Resume()
+	ai.Breaker.OnStateChange(queuePauser.OnBreakerStateChange)
+
+	redisOpt := asynq.RedisClientOpt{Addr: os.Getenv("REDIS_ADDR"), Password: os.Getenv("REDIS_PASSWORD")}
+
+	scheduler := asynq.NewScheduler(redisOpt, nil)
+	if _, err := scheduler.Register(ts.RefreshSchedule(), asynq.NewTask(ts.TypeArticleRefreshStale, nil), asynq.MaxRetry(0)); err != nil {
+		logger.Fatal().Msgf("could not schedule article refresh: %v", err)
+	}
+	if _, err := scheduler.Register(ts.PublishScheduledSchedule, asynq.NewTask(ts.TypeArticlePublishScheduled, nil), asynq.MaxRetry(0)); err != nil {
+		logger.Fatal().Msgf("could not schedule publishing of articles: %v", err)
+	}
+	if _, err := scheduler.Register(ts.PurgeTrashSchedule, asynq.NewTask(ts.TypeArticlePurgeTrash, nil), asynq.MaxRetry(0)); err != nil {
+		logger.Fatal().Msgf("could not schedule purging of the trash: %v", err)
+	}
+	if err := scheduler.Start(); err != nil {
+		logger.Fatal().Msgf("could not run scheduler: %v", err)
+	}
+	defer scheduler.Shutdown()
+
 	srv := asynq.NewServer(
-		asynq.RedisClientOpt{Addr: os.Getenv("REDIS_ADDR"), Password: os.Getenv("REDIS_PASSWORD")},
+		redisOpt,
 		asynq.Config{
-			Concurrency: 1,
+			Concurrency:    1,
+			IsFailure:      ts.IsFailure,
+			RetryDelayFunc: ts.RetryDelay,
 		},
 	)
 
 	mux := asynq.NewServeMux()
 	mux.HandleFunc(ts.TypeArticleGenerateDescription, tasks.Article.HandleGenerateDescription)
 	mux.HandleFunc(ts.TypeArticleGenerateArticles, tasks.Article.HandleGenerateArticles)
+	mux.HandleFunc(ts.TypeArticleGenerateThumbnail, tasks.Article.HandleGenerateThumbnail)
+	mux.HandleFunc(ts.TypeArticleGenerateSeo, tasks.Article.HandleGenerateSeo)
+	mux.HandleFunc(ts.TypeArticleRefreshStale, tasks.Article.HandleRefreshStaleArticles)
+	mux.HandleFunc(ts.TypeArticlePublishScheduled, tasks.Article.HandlePublishScheduledArticles)
+	mux.HandleFunc(ts.TypeArticleTranslate, tasks.Article.HandleTranslateArticle)
+	mux.HandleFunc(ts.TypeArticlePurgeTrash, tasks.Article.HandlePurgeTrash)
 	mux.HandleFunc(ts.TypeScrapperUpdateQuestion, tasks.Scrapper.HandleUpdateQuestionTask)
 	if err := srv.Run(mux); err != nil {
 		logger.Fatal().Msgf("could not run server: %v", err)
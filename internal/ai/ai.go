@@ -1,25 +1,105 @@
 package ai
 
 import (
+	"context"
+	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog"
 	chatgpt "github.com/rustoma/octo-pulse/internal/ai/chatGPT"
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+	"github.com/rustoma/octo-pulse/internal/ai/prompts"
 	lr "github.com/rustoma/octo-pulse/internal/logger"
+	"github.com/rustoma/octo-pulse/internal/storage"
 )
 
 var logger *zerolog.Logger
 
 type AI struct {
-	ChatGPT chatgpt.ChatGPTer
+	Provider llm.Provider
+	Prompts  prompts.Registry
+	ChatGPT  chatgpt.ChatGPTer
+	// Breaker is open while the provider is failing. It is nil when the AI
+	// was built with NewAIWithProvider.
+	Breaker *llm.Breaker
 }
 
-func NewAI() *AI {
+// NewAI builds the AI layer on top of the provider selected with AI_PROVIDER
+// (openai, local or fake). Prompts come from the database, PROMPTS_DIR and
+// the templates embedded in the binary, in that order.
+//
+// Failed calls are retried with exponential backoff (AI_RETRY_ATTEMPTS,
+// AI_RETRY_BASE_DELAY, AI_RETRY_MAX_DELAY) and the provider is not called for
+// AI_BREAKER_COOLDOWN after AI_BREAKER_THRESHOLD consecutive outage errors.
+//
+// AI_CACHE (file or postgres) enables the completion cache. Entries live for
+// AI_CACHE_TTL; the file cache keeps them in AI_CACHE_DIR.
+func NewAI(promptTemplateStore storage.PromptTemplateStore, llmCacheStore storage.LlmCacheStore) *AI {
+	provider, err := llm.NewProvider(os.Getenv("AI_PROVIDER"))
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Cannot create AI provider")
+	}
+
+	policy := llm.RetryPolicy{
+		MaxAttempts: getEnvInt("AI_RETRY_ATTEMPTS", llm.DefaultRetryPolicy.MaxAttempts),
+		BaseDelay:   getEnvDuration("AI_RETRY_BASE_DELAY", llm.DefaultRetryPolicy.BaseDelay),
+		MaxDelay:    getEnvDuration("AI_RETRY_MAX_DELAY", llm.DefaultRetryPolicy.MaxDelay),
+	}
+	breaker := llm.NewBreaker(getEnvInt("AI_BREAKER_THRESHOLD", 5), getEnvDuration("AI_BREAKER_COOLDOWN", 2*time.Minute))
+	provider = llm.WithRetry(provider, policy, breaker)
+
+	registry, err := prompts.NewRegistry(promptTemplateStore, os.Getenv("PROMPTS_DIR"))
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Cannot load prompt templates")
+	}
+
+	cache, err := llm.NewCache(os.Getenv("AI_CACHE"), getEnvDuration("AI_CACHE_TTL", 7*24*time.Hour), os.Getenv("AI_CACHE_DIR"), llmCacheStore)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Cannot create AI cache")
+	}
+
+	if cache != nil {
+		go func() {
+			if err := cache.Purge(context.Background()); err != nil {
+				logger.Err(err).Msg("Cannot purge AI cache")
+			}
+		}()
+	}
+
+	ai := NewAIWithProvider(provider, registry, cache)
+	ai.Breaker = breaker
+
+	return ai
+}
+
+func NewAIWithProvider(provider llm.Provider, registry prompts.Registry, cache llm.Cache) *AI {
 	return &AI{
-		ChatGPT: chatgpt.NewChatGPT(),
+		Provider: provider,
+		Prompts:  registry,
+		ChatGPT:  chatgpt.NewChatGPT(provider, registry, cache),
+	}
+}
+
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// getEnvDuration reads durations like 2s or 1m30s.
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
 	}
+	return value
 }
 
 func init() {
@@ -34,7 +114,8 @@ func init() {
 		logger.Fatal().Msg("Error loading .env file")
 	}
 
-	if err := godotenv.Load(filepath.Join(dir, ".env")); err != nil {
+	// Without a .env file the settings come from the environment alone
+	if err := godotenv.Load(filepath.Join(dir, ".env")); err != nil && !errors.Is(err, fs.ErrNotExist) {
 		logger.Fatal().Msg("Error loading .env file")
 	}
 }
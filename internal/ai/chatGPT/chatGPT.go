@@ -3,15 +3,19 @@ package chatgpt
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/rs/zerolog"
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+	"github.com/rustoma/octo-pulse/internal/ai/prompts"
+	"github.com/rustoma/octo-pulse/internal/language"
 	lr "github.com/rustoma/octo-pulse/internal/logger"
 	"github.com/rustoma/octo-pulse/internal/models"
 	"github.com/sashabaranov/go-openai"
@@ -19,57 +23,261 @@ import (
 
 var logger *zerolog.Logger
 
+const rejectMarker = "---reject---"
+
 type ChatGPTer interface {
-	GenerateArticleDescription(question *models.Question) (string, error)
-	AssignToCategory(categories []*models.Category, question *models.Question) (int, error)
-	CheckIfPageContentIsValid(text string) (bool, error)
+	GenerateArticleDescription(ctx context.Context, question *models.Question, domain *models.Domain, steps Steps) (*ArticleDescription, error)
+	AssignToCategory(ctx context.Context, categories []*models.Category, question *models.Question, domain *models.Domain) (int, error)
 	CheckIfResponseContainRejected(response string) bool
-	GenerateImage() (openai.ImageResponse, error)
+	GenerateThumbnail(ctx context.Context, title string, headings []string, domain *models.Domain) (*Thumbnail, error)
+	GenerateSeoMetadata(ctx context.Context, title string, body string, domain *models.Domain) (*SeoMetadata, error)
+	GenerateFaq(ctx context.Context, body string, questions []string, domain *models.Domain) ([]FaqItem, error)
+	TranslateArticle(ctx context.Context, article *models.Article, from *models.Domain, to *models.Domain) (*ArticleTranslation, error)
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+	EmbeddingModel() string
+	WithMeter(meter *llm.Meter) ChatGPTer
+	WithoutCache() ChatGPTer
 }
 
 type chatGPT struct {
-	retriesLimit int
-	Client       *openai.Client
-	Usage        *openai.Usage
+	repairLimit int
+	jsonMode    bool
+	provider    llm.Provider
+	prompts     prompts.Registry
+	model       string
+	lightModel  string
+	imageModel  string
+	// embeddingModel must be one of the models enumerated by the openai
+	// client when the openai provider is used.
+	embeddingModel string
+	meter          *llm.Meter
+	cache          llm.Cache
+	// skipCacheRead makes every call reach the provider. Replies are still
+	// written to the cache.
+	skipCacheRead bool
 }
 
-func NewChatGPT() ChatGPTer {
-	client := openai.NewClient(os.Getenv("AI_KEY"))
-
+// NewChatGPT returns a client for provider. cache is optional; when given,
+// completions are served from it whenever an identical request was made before.
+func NewChatGPT(provider llm.Provider, registry prompts.Registry, cache llm.Cache) ChatGPTer {
 	return &chatGPT{
-		retriesLimit: 1,
-		Client:       client,
-		Usage:        &openai.Usage{PromptTokens: 0, CompletionTokens: 0, TotalTokens: 0},
+		repairLimit:    getEnvInt("AI_REPAIR_ATTEMPTS", 2),
+		jsonMode:       getEnv("AI_JSON_MODE", "true") != "false",
+		provider:       provider,
+		prompts:        registry,
+		model:          getEnv("AI_MODEL", openai.GPT4TurboPreview),
+		lightModel:     getEnv("AI_LIGHT_MODEL", openai.GPT3Dot5Turbo16K),
+		imageModel:     getEnv("AI_IMAGE_MODEL", openai.CreateImageModelDallE3),
+		embeddingModel: getEnv("AI_EMBEDDING_MODEL", openai.AdaEmbeddingV2.String()),
+		cache:          cache,
+	}
+}
+
+// WithMeter returns a copy of the client that records the token usage of every
+// completion in meter. The copy shares the provider and prompts, so it is cheap
+// to create one per task.
+func (c *chatGPT) WithMeter(meter *llm.Meter) ChatGPTer {
+	metered := *c
+	metered.meter = meter
+
+	return &metered
+}
+
+// WithoutCache returns a copy of the client that always asks the provider, for
+// when a fresh generation is wanted. The new replies replace the cached ones.
+func (c *chatGPT) WithoutCache() ChatGPTer {
+	fresh := *c
+	fresh.skipCacheRead = true
+
+	return &fresh
+}
+
+func getEnv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
 	}
+	return value
 }
 
-func (c *chatGPT) GenerateImage() (openai.ImageResponse, error) {
+// seoSourceLimit is the number of characters of the article text sent to the
+// model. The beginning of the article is enough to describe it.
+const seoSourceLimit = 6000
 
-	resp, err := c.Client.CreateImage(context.Background(), openai.ImageRequest{
-		Model:          openai.CreateImageModelDallE3,
-		Prompt:         "Generate a person applying eye drops in the bathroom in a pleasant atmosphere. The person should have his eyes open and hit the eye drops.",
-		Size:           "1024x1024",
-		Quality:        "standard",
-		N:              1,
-		ResponseFormat: openai.CreateImageResponseFormatB64JSON,
+// faqSourceLimit is the number of characters of the article text the FAQ
+// answers are based on.
+const faqSourceLimit = 12000
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// plainText strips the HTML tags of body and cuts it to limit characters.
+func (c *chatGPT) plainText(body string, limit int) string {
+	text := []rune(strings.TrimSpace(c.RemoveMultipleSpaces(htmlTag.ReplaceAllString(body, " "))))
+	if len(text) > limit {
+		text = text[:limit]
+	}
+
+	return string(text)
+}
+
+// GenerateSeoMetadata writes the meta title, meta description, focus keyword and
+// excerpt of the article with the given HTML body.
+func (c *chatGPT) GenerateSeoMetadata(ctx context.Context, title string, body string, domain *models.Domain) (*SeoMetadata, error) {
+	prompt, err := c.render(ctx, prompts.SeoMetadata, domain.ID, language.Get(domain.Language), map[string]interface{}{
+		"Title": title,
+		"Text":  c.plainText(body, seoSourceLimit),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []llm.Message{
+		{
+			Role:    llm.RoleUser,
+			Content: prompt,
+		},
+	}
+
+	var metadata SeoMetadata
+	err = c.askStructured(ctx, messages, &metadata, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &metadata, nil
+}
+
+// GenerateFaq answers questions related to the article, based on its HTML body.
+// Questions the article does not answer are left out, so fewer items than
+// questions may be returned.
+func (c *chatGPT) GenerateFaq(ctx context.Context, body string, questions []string, domain *models.Domain) ([]FaqItem, error) {
+	if len(questions) == 0 {
+		return nil, nil
+	}
+
+	prompt, err := c.render(ctx, prompts.Faq, domain.ID, language.Get(domain.Language), map[string]interface{}{
+		"Text":      c.plainText(body, faqSourceLimit),
+		"Questions": "- " + strings.Join(questions, "\n- "),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []llm.Message{
+		{
+			Role:    llm.RoleUser,
+			Content: prompt,
+		},
+	}
+
+	var faq Faq
+	err = c.askStructured(ctx, messages, &faq, func() error {
+		if len(faq.Items) > len(questions) {
+			return fmt.Errorf("expected at most %d items, one for each question", len(questions))
+		}
+
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	return resp, err
+	return faq.Items, nil
 }
 
-func (c *chatGPT) CorrectGrammar(text string) (string, error) {
+// GenerateThumbnail asks the model to describe a photo matching the article and
+// generates it with the image model. headings are the headings of the article
+// agenda.
+func (c *chatGPT) GenerateThumbnail(ctx context.Context, title string, headings []string, domain *models.Domain) (*Thumbnail, error) {
+	prompt, err := c.render(ctx, prompts.Thumbnail, domain.ID, language.Get(domain.Language), map[string]interface{}{
+		"Title":    title,
+		"Headings": strings.Join(headings, ", "),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
 
-	messages := []openai.ChatCompletionMessage{
+	messages := []llm.Message{
 		{
-			Role: openai.ChatMessageRoleUser,
-			Content: "Zwróć bezpośrednio poprawiony tekst bez żadnego dodatkowego opisu. \n" +
-				"Popraw gramatykę, błędy stylystyczne, składniowe oraz składnię HTML. Nie zmieniaj tekstu, ani struktury HTML, jedynie popraw błędy. \n" +
-				"Tekst do poprawy: \n\n" +
-				text,
+			Role:    llm.RoleUser,
+			Content: prompt,
+		},
+	}
+
+	var description ThumbnailDescription
+	err = c.askStructured(ctx, messages, &description, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info().Msgf("Generating thumbnail: %s", description.Prompt)
+
+	request := llm.ImageRequest{
+		Model:   c.imageModel,
+		Prompt:  description.Prompt,
+		Size:    "1024x1024",
+		Quality: "standard",
+	}
+
+	resp, err := c.provider.CreateImage(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	c.meter.AddImage(request.Model, request.Size)
+
+	image, err := base64.StdEncoding.DecodeString(resp.B64JSON)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode generated image: %w", err)
+	}
+
+	return &Thumbnail{Image: image, Alt: description.Alt, Prompt: description.Prompt}, nil
+}
+
+// render renders the prompt in lang, exposing the English name of the language
+// to the template as Language.
+func (c *chatGPT) render(ctx context.Context, name string, domainId int, lang language.Language, data map[string]interface{}, used map[string]prompts.Version) (string, error) {
+	data["Language"] = lang.Name
+
+	prompt, version, err := c.prompts.Render(ctx, name, domainId, lang.Code, data)
+	if err != nil {
+		logger.Err(err).Msgf("Cannot render prompt %s", name)
+		return "", err
+	}
+
+	if used != nil {
+		used[name] = version
+	}
+
+	return prompt, nil
+}
+
+func (c *chatGPT) CorrectGrammar(ctx context.Context, text string, lang string) (string, error) {
+	return c.correctGrammar(ctx, text, 0, language.Get(lang), nil)
+}
+
+func (c *chatGPT) correctGrammar(ctx context.Context, text string, domainId int, lang language.Language, used map[string]prompts.Version) (string, error) {
+
+	prompt, err := c.render(ctx, prompts.CorrectGrammar, domainId, lang, map[string]interface{}{"Text": text}, used)
+	if err != nil {
+		return "", err
+	}
+
+	messages := []llm.Message{
+		{
+			Role:    llm.RoleUser,
+			Content: prompt,
 		},
 	}
 	logger.Info().Msg("Starting to correct the text...")
-	correctedText, err := c.ask(messages, openai.GPT3Dot5Turbo16K)
+	correctedText, err := c.ask(ctx, messages, c.lightModel)
 	if err != nil {
 		logger.Error().Msgf("The text could not be corrected: %s \n\n Error: %v\n", text, err)
 		return "", err
@@ -78,60 +286,106 @@ func (c *chatGPT) CorrectGrammar(text string) (string, error) {
 	return correctedText, err
 }
 
-func (c *chatGPT) AssignToCategory(categories []*models.Category, question *models.Question) (int, error) {
+func (c *chatGPT) AssignToCategory(ctx context.Context, categories []*models.Category, question *models.Question, domain *models.Domain) (int, error) {
 
 	categoriesJSON, err := json.Marshal(categories)
 	if err != nil {
 		return 0, err
 	}
 
-	messages := []openai.ChatCompletionMessage{
+	prompt, err := c.render(ctx, prompts.AssignCategory, domain.ID, language.Get(domain.Language), map[string]interface{}{
+		"Question":   question.Question,
+		"Answer":     question.Answer,
+		"Categories": string(categoriesJSON),
+	}, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	messages := []llm.Message{
 		{
-			Role: openai.ChatMessageRoleUser,
-			Content: "Tytuł artykułu to: " + question.Question + "\n" +
-				"Opis artykułu: " + question.Answer + "\n\n" +
-				"Dostępne kategorie: " + string(categoriesJSON) + "\n\n" +
-				"Przypasuj tytuł artykułu do jednej z podanych kategorii. Zwróć jedynie id kategorii. \n\n" +
-				"Odpowiedź według zaleceń: \n\n" +
-				"- zwróć jedynie id kategorii do której pasuje tytuł \n" +
-				"- jeżeli tytuł nie pasuje do żadnej kategorii zwróć 0 \n" +
-				"- id kategorii zwróć pomiędzy trzema myślnikami \n\n" +
-				"Przykład poprawnej odpowiedzi: ---133---",
+			Role:    llm.RoleUser,
+			Content: prompt,
 		},
 	}
 
-	resp, err := c.ask(messages)
+	var assignment CategoryAssignment
+	err = c.askStructured(ctx, messages, &assignment, func() error {
+		if assignment.CategoryId == 0 {
+			return nil
+		}
+
+		for _, category := range categories {
+			if category.ID == assignment.CategoryId {
+				return nil
+			}
+		}
 
+		return fmt.Errorf("$.categoryId: %d is not one of the available category ids", assignment.CategoryId)
+	})
 	if err != nil {
 		return 0, err
 	}
 
-	re := regexp.MustCompile(`---(\d+)---`)
-	match := re.FindStringSubmatch(resp)
-	if match == nil {
-		return 0, errors.New("No integer found in the respond from AssignToCategory")
-	}
+	return assignment.CategoryId, nil
+}
 
-	categoryId, err := strconv.Atoi(match[1])
-	if err != nil {
-		return 0, err
-	}
-	return categoryId, nil
+type CategoryAssignment struct {
+	CategoryId int `json:"categoryId" jsonschema:"description=Id of the matching category or 0 when none fits,minimum=0"`
+}
+
+// SeoMetadata holds the search engine fields of an article. The limits match
+// the article validator.
+type SeoMetadata struct {
+	MetaTitle       string `json:"metaTitle" jsonschema:"minLength=1,maxLength=60"`
+	MetaDescription string `json:"metaDescription" jsonschema:"minLength=1,maxLength=160"`
+	FocusKeyword    string `json:"focusKeyword" jsonschema:"minLength=1,maxLength=60"`
+	Excerpt         string `json:"excerpt" jsonschema:"minLength=1,maxLength=300"`
+}
+
+type FaqItem struct {
+	Question string `json:"question" jsonschema:"minLength=1"`
+	Answer   string `json:"answer" jsonschema:"minLength=1,maxLength=500"`
+}
+
+type Faq struct {
+	Items []FaqItem `json:"items"`
+}
+
+type ThumbnailDescription struct {
+	Prompt string `json:"prompt" jsonschema:"description=English description of the photo for the image model,minLength=1"`
+	Alt    string `json:"alt" jsonschema:"description=Alt text of the photo in the language of the article,minLength=1"`
+}
+
+// Thumbnail is a generated article thumbnail. Image holds the encoded image as
+// returned by the image model.
+type Thumbnail struct {
+	Image  []byte
+	Alt    string
+	Prompt string
 }
 
 type Subtitle struct {
-	Title     string   `json:"title"`
-	Subtitles []string `json:"subtitles"`
+	Title     string   `json:"title" jsonschema:"minLength=1"`
+	Subtitles []string `json:"subtitles" jsonschema:"maxItems=3"`
 }
 
 type ArticleAgenda struct {
-	MainTitle string     `json:"mainTitle"`
-	Subtitles []Subtitle `json:"subtitles"`
+	MainTitle string     `json:"mainTitle" jsonschema:"minLength=1"`
+	Subtitles []Subtitle `json:"subtitles" jsonschema:"minItems=1,maxItems=4"`
+}
+
+// ArticleDescription is a generated article body together with the agenda it
+// follows and the prompt template versions that produced it.
+type ArticleDescription struct {
+	Body           string
+	Agenda         ArticleAgenda
+	PromptVersions []prompts.Version
 }
 
 func (c *chatGPT) CheckIfResponseContainRejected(response string) bool {
 	// Regular expression pattern
-	pattern := "---reject---"
+	pattern := rejectMarker
 
 	// Compile the regular expression
 	re := regexp.MustCompile(pattern)
@@ -144,28 +398,6 @@ func (c *chatGPT) CheckIfResponseContainRejected(response string) bool {
 	return false
 }
 
-func (c *chatGPT) CheckIfPageContentIsValid(text string) (bool, error) {
-	messages := []openai.ChatCompletionMessage{
-		{
-			Role: openai.ChatMessageRoleSystem,
-			Content: "Przeanalizuj poniższy tekst i zwróć pomiędzy trzema myślnikami ---reject--- jeżeli: \n\n" +
-				"1. Jeżeli tekst jest w języku angielskim. \n" +
-				"2. Jeżeli 1 punkt nie pasuje to zwróć pomiędzy trzema myślnikami ---approved---",
-		},
-	}
-
-	resp, err := c.ask(messages)
-	if err != nil {
-		return false, err
-	}
-
-	if c.CheckIfResponseContainRejected(resp) {
-		return false, nil
-	}
-
-	return true, nil
-}
-
 func (c *chatGPT) RemoveMultipleSpaces(text string) string {
 	trimmedText := strings.TrimSpace(text)
 
@@ -179,12 +411,21 @@ func (c *chatGPT) RemoveMultipleSpaces(text string) string {
 	return reg.ReplaceAllString(trimmedText, " ") + "\n\n"
 }
 
-func (c *chatGPT) GenerateArticleDescription(question *models.Question) (string, error) {
+// GenerateArticleDescription writes the article step by step: the agenda, a
+// summary of every source, the introduction and the sections. Every step is
+// recorded in steps as soon as it is completed and steps completed before are
+// not generated again. steps may be nil.
+func (c *chatGPT) GenerateArticleDescription(ctx context.Context, question *models.Question, domain *models.Domain, steps Steps) (*ArticleDescription, error) {
 
 	var articleDescription bytes.Buffer
 
+	domainId := domain.ID
+	lang := language.Get(domain.Language)
+
 	var sourceText string
 
+	usedPrompts := make(map[string]prompts.Version)
+
 	for _, pageContent := range question.PageContents {
 		text := c.RemoveMultipleSpaces(pageContent.PageContentProcessed)
 		sourceText = fmt.Sprintf("%s \n\n %s", sourceText, text)
@@ -192,57 +433,53 @@ func (c *chatGPT) GenerateArticleDescription(question *models.Question) (string,
 
 	if len(sourceText) < 2000 {
 		logger.Info().Msgf("There is no enough page content for the question. Question id: %d", question.Id)
-		return "", nil
+		return &ArticleDescription{}, nil
 	}
 
-	var messages []openai.ChatCompletionMessage
+	var messages []llm.Message
 
-	messages = append(messages, openai.ChatCompletionMessage{
-		Role:    openai.ChatMessageRoleSystem,
+	messages = append(messages, llm.Message{
+		Role:    llm.RoleSystem,
 		Content: sourceText,
 	})
 
+	agendaPrompt, err := c.render(ctx, prompts.Agenda, domainId, lang, map[string]interface{}{"Question": question.Question}, usedPrompts)
+	if err != nil {
+		return nil, err
+	}
+
 	messages = append(messages,
-		openai.ChatCompletionMessage{
-			Role: openai.ChatMessageRoleUser,
-			Content: "Głównym nagłówkiem będzie: " + question.Question + "\n Na podstawie tekstu który podałeś zwróć obiekt z nagłówkami i podrzędnymi nagłówkami, które mogą posłużyć do napisania takiego artykułu. \n" +
-				"Nie uwzględniaj treści związanych z informacjami na temat firmy, polityki prywatności lub ciasteczek cookies. \n" +
-				"Nie uwzględniaj tytułów takich jak 'o nas', 'informacje kontaktowe', 'o firmie' i wszystkich innych powiązanych z konkretną firmą. Nie dodawaj tytułów związanych z newsletter, biuletynem itp. \n" +
-				"Nie dodawaj podtytułów 'podsumowanie', 'kontynuacja tematu' \n" +
-				"Nie numeruj tytułów. \n" +
-				"Nagłówki i podrzędnye nagłówki powinny koncentrować się na kluczowych punktach, podtematach lub sekcjach związanych z głównym tytułem. \n" +
-				"Obiekt powinien być zwięzły, a nagłówki mieścić się w zakresie głównego tytułu i otaczającego go kontekstu. \n" +
-				"Nie odbiegaj od głównego tematu. \n" +
-				"Ogranicz wygenerowany obiekt do kilku najważniejszych nagłówków i podrzędnych nagłówków. \n" +
-				"Maksymalnie zastosuj cztery nagłówki. \n" +
-				"Maksymalnie zastosuj dla każdego nagłówka 3 podrzędne nagłówki. \n" +
-				"Nie pisz nic o tym gdzie kupić towar. \n" +
-				"Nie pisz nic o umowach. \n" +
-				"Nagłówki i podnagłówki powinny być w języku polskim. \n" +
-				"Zwróć poprawny json string na wzór: \n\n" +
-				"{\"mainTitle:\"" + question.Question + ", \"subtitles\": [{\"title\": \"Subtitle1\", \"subtitles\": [\"Subtitle1\", \"Subtitle2\"]},{\"title\": \"Subtitle2\", \"subtitles\": [\"Subtitle1\", \"Subtitle2\"]}]}" + "\n\n" +
-				"Zwróć wyłącznie obiekt, gotowy do serializacji. \n" +
-				"Nie dodawaj na początku ```json\\n. Twoja odpowiedź powinna zacząć się od { \n" +
-				"Nie dodawaj znaczników '\n'. Wszystko zwróć w jedej linii",
+		llm.Message{
+			Role:    llm.RoleUser,
+			Content: agendaPrompt,
 		})
 
-	agenda, err := c.ask(messages)
+	agenda, err := c.step(ctx, steps, StepAgenda, func() (string, error) {
+		var articleAgenda ArticleAgenda
+		err := c.askStructured(ctx, messages, &articleAgenda, nil)
+		if err != nil {
+			logger.Err(err).Msg("Incorrect agenda format")
+			return "", err
+		}
+
+		agendaJSON, err := json.Marshal(articleAgenda)
+		if err != nil {
+			return "", err
+		}
+
+		return string(agendaJSON), nil
+	})
 	if err != nil {
-		logger.Info().Interface("Agenda: ", agenda).Send()
-		logger.Err(err).Msg("Incorrect agenda format")
-		return "", err
+		return nil, err
 	}
 
 	var articleAgenda ArticleAgenda
-	err = json.Unmarshal([]byte(agenda), &articleAgenda)
-	if err != nil {
-		logger.Info().Interface("Agenda: ", agenda).Send()
-		logger.Err(err).Msg("Cannot Unmarshal agenda")
-		return "", err
+	if err := json.Unmarshal([]byte(agenda), &articleAgenda); err != nil {
+		return nil, err
 	}
 
-	messages = append(messages, openai.ChatCompletionMessage{
-		Role:    openai.ChatMessageRoleSystem,
+	messages = append(messages, llm.Message{
+		Role:    llm.RoleSystem,
 		Content: agenda,
 	})
 
@@ -256,20 +493,26 @@ func (c *chatGPT) GenerateArticleDescription(question *models.Question) (string,
 			continue
 		}
 
-		summaryPromp := []openai.ChatCompletionMessage{{
-			Role:    openai.ChatMessageRoleSystem,
+		summaryPrompt, err := c.render(ctx, prompts.Summary, domainId, lang, map[string]interface{}{"Agenda": agenda}, usedPrompts)
+		if err != nil {
+			return nil, err
+		}
+
+		summaryMessages := []llm.Message{{
+			Role:    llm.RoleSystem,
 			Content: pageContent.PageContentProcessed,
 		},
 			{
-				Role: openai.ChatMessageRoleUser,
-				Content: "Wyobraź sobie, że jesteś doświadczonym copywriterem z perfekcyjną znajomością języka polskiego. Podsumowanie, które zwrócisz powinno być w języku polskim." +
-					"Twoim celem jest stworzenie na podstawie tekstu, który podałeś podsumowania z najważniejszymi treścami pisanego jakby był to nowy artykuł, który będzie wykrozystany jako kontekst przy pisaniu artykułu do którego spis treści wygląda następująco: " + agenda,
+				Role:    llm.RoleUser,
+				Content: summaryPrompt,
 			},
 		}
 
-		summaryResponse, err := c.ask(summaryPromp)
+		summaryResponse, err := c.step(ctx, steps, SummaryStep(index), func() (string, error) {
+			return c.ask(ctx, summaryMessages)
+		})
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		if index == 0 {
@@ -280,280 +523,271 @@ func (c *chatGPT) GenerateArticleDescription(question *models.Question) (string,
 	}
 	logger.Info().Msg("Generating summary COMPLETED!")
 
-	messages[0] = openai.ChatCompletionMessage{
-		Role:    openai.ChatMessageRoleSystem,
-		Content: "Streszczenie: " + summary,
-	}
-
-	messages = append(messages, openai.ChatCompletionMessage{
-		Role: openai.ChatMessageRoleUser,
-		Content: "Wyobraź sobie, że jesteś doświadczonym copywriterem z perfekcyjną znajomością języka polskiego. " +
-			"Twoim celem jest stworzyć 100% oryginalny, zoptymalizowany pod względem SEO artykuł, który czyta się jak napisany przez człowieka. " +
-			"Styl odpowiedzi powinien być profesjonalny. Będzie to artykuł gdzie odbiorca będzie mógł zaczerpnąć informacji. \n\n" +
-			"Artykuł powinien być w języku polskim. \n\n" +
-			"Na podstawie zadanego tytułu zwróć krótki wstęp do artykułu. \n\n" +
-			"Podtytułami dla tego artykułu będą podtytuły jak w poniższej tablicy: \n\n" +
-			fmt.Sprintf("%+v", articleAgenda.Subtitles) + "\n\n" +
-			"Tytuł artykułu to: " + question.Question + "\n\n" +
-			"Stosuj się do poniższych wymagań: \n\n" +
-			"- Napisz tylko wstęp dla tego tytułu nie odpowiadaj na żadne podtytuły. \n" +
-			"- Nie powtarzaj się. \n" +
-			"- Nie pisz nic na temat SEO \n" +
-			"- Długość wstępu powinina mieć minimum 1000 liter. \n" +
-			"- Możesz zdefiniować kilka paragrafów, aby osiągnąć wymaganą długość wstępu. \n" +
-			"- Długość wstępu jest wymagana! Powinna być bezwględnie przestrzegana! \n" +
-			"- Tekst zwróć jako HTML. Tytuł artykułu powinien być w tagu <h1> \n" +
-			"- Zwróć jedynie HTML z tekstem tak, aby dało się go dołączyć do już isntniejącego HTML. \n" +
-			"- Odpowiedz jedynie HTML, tak abym całą odpowiedź mógł to skopiować i wkleić. \n" +
-			"- Dozwolone tagi HTML to : <p>, <ul>, <li>, <ol>, <strong>, <h1> \n" +
-			"- Nie dodawaj żadnych instrukcji od siebie.",
-	})
+	messages[0] = llm.Message{
+		Role:    llm.RoleSystem,
+		Content: lang.SummaryLabel + summary,
+	}
 
-	entryText, err := c.ask(messages)
+	introductionPrompt, err := c.render(ctx, prompts.Introduction, domainId, lang, map[string]interface{}{
+		"Question":  question.Question,
+		"Subtitles": fmt.Sprintf("%+v", articleAgenda.Subtitles),
+	}, usedPrompts)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	correctedEntryText, err := c.CorrectGrammar(entryText)
+	messages = append(messages, llm.Message{
+		Role:    llm.RoleUser,
+		Content: introductionPrompt,
+	})
+
+	correctedEntryText, err := c.step(ctx, steps, StepIntroduction, func() (string, error) {
+		entryText, err := c.ask(ctx, messages)
+		if err != nil {
+			return "", err
+		}
+
+		return c.correctGrammar(ctx, entryText, domainId, lang, usedPrompts)
+	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	articleDescription.WriteString(correctedEntryText)
 
-	for _, subtitle := range articleAgenda.Subtitles {
-		messagesLvl2 := []openai.ChatCompletionMessage{
+	for section, subtitle := range articleAgenda.Subtitles {
+		sectionPrompt, err := c.render(ctx, prompts.SectionH2, domainId, lang, map[string]interface{}{
+			"Title":        subtitle.Title,
+			"Subtitles":    fmt.Sprintf("%+v", subtitle.Subtitles),
+			"RejectMarker": rejectMarker,
+		}, usedPrompts)
+		if err != nil {
+			return nil, err
+		}
+
+		messagesLvl2 := []llm.Message{
 			messages[0],
 			{
-				Role:    openai.ChatMessageRoleSystem,
+				Role:    llm.RoleSystem,
 				Content: correctedEntryText,
 			},
 			{
-				Role: openai.ChatMessageRoleUser,
-				Content: "Wyobraź sobie, że jesteś doświadczonym copywriterem z perfekcyjną znajomością języka polskiego. " +
-					"Twoim celem jest stworzyć 100% oryginalny, zoptymalizowany pod względem SEO artykuł, który czyta się jak napisany przez człowieka. " +
-					"Styl odpowiedzi powinien być profesjonalny. Będzie to artykuł gdzie odbiorca będzie mógł zaczerpnąć informacji. \n\n" +
-					"Artykuł powinien być w języku polskim. \n\n" +
-					"Rozwiń zadany podtytuł. \n\n" +
-					"Podtytułami dla zadanego podtytułu będą podtytuły jak w poniższej tablicy: \n\n" +
-					fmt.Sprintf("%+v", subtitle.Subtitles) + "\n\n" +
-					"Zadany podtytuł to: " + subtitle.Title + "\n\n" +
-					"Stosuj się do poniższych wymagań: \n\n" +
-					"- Tekst zwróć jako HTML. Zadany podtytuł powinien być w tagu <h2> \n" +
-					"- Zwróć jedynie HTML z tekstem tak, aby dało się go dołączyć do już isntniejącego HTML. \n" +
-					"- Odpowiedz jedynie HTML, tak abym całą odpowiedź mógł to skopiować i wkleić. \n" +
-					"- Odpowiedz jedynie za pomocą HTML. Nie pisz mi nic co mam z nim zrobić, ani że jest to odpowiedź." +
-					"- W tekście nie odpowiadaj na żadne podtytuły. \n" +
-					"- Dozwolone tagi HTML to : <p>, <ul>, <li>, <ol>, <strong>, <h2> \n" +
-					"- Tekst powinien być powiązany kontekstem z głównym tytułem artykułu. \n" +
-					"- Nie używaj w odpowiedzi tytułu nadrzędnego lub podtytułów dla zadanego podtytułu \n" +
-					"- Tekst powinien być powiązany kontekstem z poprzednimi odpowiedziami. \n" +
-					"- Nie używaj w odpowiedzi tytułu nadrzędnego \n" +
-					"- Nie powtarzaj się \n" +
-					"- Nie pisz nic na temat SEO \n" +
-					"- Możesz bazować na informacjach zawartych w streszczeniu. \n" +
-					"- Jeżeli nie możesz udzielić lub kontynuować odpowiedzi zwróć pomiędzy trzema myślnikami ---reject--- \nn" +
-					"Przykład poprawnej struktury odpowiedzi: \n\n" +
-					"<h2>" + subtitle.Title + "</h2>" + "<p>...</p>",
+				Role:    llm.RoleUser,
+				Content: sectionPrompt,
 			},
 		}
 
-		respLvl2, err := c.ask(messagesLvl2)
+		correctedRespLvl2, err := c.step(ctx, steps, SectionStep(section), func() (string, error) {
+			respLvl2, err := c.ask(ctx, messagesLvl2)
+			if err != nil {
+				return "", err
+			}
+
+			if c.CheckIfResponseContainRejected(respLvl2) {
+				logger.Info().Msgf("Text cannot be proccess %s", subtitle.Title)
+				return "", nil
+			}
+
+			return c.correctGrammar(ctx, respLvl2, domainId, lang, usedPrompts)
+		})
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
-		if c.CheckIfResponseContainRejected(respLvl2) {
-			logger.Info().Msgf("Text cannot be proccess %s", subtitle.Title)
+		if correctedRespLvl2 == "" {
 			continue
 		}
 
-		correctedRespLvl2, err := c.CorrectGrammar(respLvl2)
-		if err != nil {
-			return "", err
-		}
-
 		articleDescription.WriteString(correctedRespLvl2)
 
 		logger.Info().Interface("subtitle LVL2: ", subtitle.Title).Send()
 
-		var allMessagesLvl3 []openai.ChatCompletionMessage
+		var allMessagesLvl3 []llm.Message
 		for index, subtitle3lvl := range subtitle.Subtitles {
+			subsectionPrompt, err := c.render(ctx, prompts.SectionH3, domainId, lang, map[string]interface{}{
+				"ParentTitle":  subtitle.Title,
+				"Title":        subtitle3lvl,
+				"RejectMarker": rejectMarker,
+			}, usedPrompts)
+			if err != nil {
+				return nil, err
+			}
+
 			if index == 1 {
-				messagesLvl3 := []openai.ChatCompletionMessage{
+				messagesLvl3 := []llm.Message{
 					messagesLvl2[1],
 					{
-						Role:    openai.ChatMessageRoleSystem,
+						Role:    llm.RoleSystem,
 						Content: correctedRespLvl2,
 					},
 					{
-						Role: openai.ChatMessageRoleUser,
-						Content: "Wyobraź sobie, że jesteś doświadczonym copywriterem z perfekcyjną znajomością języka polskiego. " +
-							"Twoim celem jest stworzyć 100% oryginalny, zoptymalizowany pod względem SEO artykuł, który czyta się jak napisany przez człowieka. " +
-							"Styl odpowiedzi powinien być profesjonalny. Będzie to artykuł gdzie odbiorca będzie mógł zaczerpnąć informacji. \n\n" +
-							"Artykuł powinien być w języku polskim. \n\n" +
-							"Rozwiń zadany podtytuł. \n\n" +
-							"Zadany tytuł jest to podtytuł tytułu nadrzędnego jak poniżej: \n\n" +
-							subtitle.Title + "\n\n" +
-							"Zadany podtytuł to: " + subtitle3lvl + "\n\n" +
-							"Stosuj się do poniższych wymagań: \n\n" +
-							"- Tekst zwróć jako HTML. Zadany podtytuł powinien być w tagu <h3> \n" +
-							"- Zwróć jedynie HTML z tekstem tak, aby dało się go dołączyć do już isntniejącego HTML. \n" +
-							"- Odpowiedz jedynie HTML, tak abym całą odpowiedź mógł to skopiować i wkleić. \n" +
-							"- Odpowiedz jedynie za pomocą HTML. Nie pisz mi nic co mam z nim zrobić, ani że jest to odpowiedź." +
-							"- Dozwolone tagi HTML to : <p>, <ul>, <li>, <ol>, <strong>, <h3> \n" +
-							"- Tekst powinien być powiązany kontekstem z poprzednimi odpowiedziami. \n" +
-							"- Nie używaj w odpowiedzi tytułu nadrzędnego \n" +
-							"- Nie powtarzaj się \n" +
-							"- Nie pisz nic na temat SEO \n" +
-							"- Możesz bazować na informacjach zawartych w streszczeniu. \n" +
-							"- Jeżeli nie możesz udzielić lub kontynuować odpowiedzi zwróć pomiędzy trzema myślnikami ---reject--- \nn" +
-							"Przykład poprawnej struktury odpowiedzi: \n\n" +
-							"<h3>" + subtitle3lvl + "</h3>" + "<p>...</p>",
+						Role:    llm.RoleUser,
+						Content: subsectionPrompt,
 					},
 				}
 				allMessagesLvl3 = messagesLvl3
 			} else {
-				messagesLvl3 := []openai.ChatCompletionMessage{
+				messagesLvl3 := []llm.Message{
 					{
-						Role: openai.ChatMessageRoleUser,
-						Content: "Wyobraź sobie, że jesteś doświadczonym copywriterem z perfekcyjną znajomością języka polskiego. " +
-							"Twoim celem jest stworzyć 100% oryginalny, zoptymalizowany pod względem SEO artykuł, który czyta się jak napisany przez człowieka. " +
-							"Styl odpowiedzi powinien być profesjonalny. Będzie to artykuł gdzie odbiorca będzie mógł zaczerpnąć informacji. \n\n" +
-							"Artykuł powinien być w języku polskim. \n\n" +
-							"Rozwiń zadany podtytuł. \n\n" +
-							"Zadany tytuł jest to podtytuł tytułu nadrzędnego jak poniżej: \n\n" +
-							subtitle.Title + "\n\n" +
-							"Zadany podtytuł to: " + subtitle3lvl + "\n\n" +
-							"Stosuj się do poniższych wymagań: \n\n" +
-							"- Tekst zwróć jako HTML. Zadany podtytuł powinien być w tagu <h3> \n" +
-							"- Zwróć jedynie HTML z tekstem tak, aby dało się go dołączyć do już isntniejącego HTML. \n" +
-							"- Odpowiedz jedynie HTML, tak abym całą odpowiedź mógł to skopiować i wkleić. \n" +
-							"- Odpowiedz jedynie za pomocą HTML. Nie pisz mi nic co mam z nim zrobić, ani że jest to odpowiedź." +
-							"- Dozwolone tagi HTML to : <p>, <ul>, <li>, <ol>, <strong>, <h3> \n" +
-							"- Tekst powinien być powiązany kontekstem z poprzednimi odpowiedziami. \n" +
-							"- Nie używaj w odpowiedzi tytułu nadrzędnego \n" +
-							"- Nie powtarzaj się \n" +
-							"- Nie pisz nic na temat SEO \n" +
-							"- Możesz bazować na informacjach zawartych w streszczeniu. \n" +
-							"- Jeżeli nie możesz udzielić lub kontynuować odpowiedzi zwróć pomiędzy trzema myślnikami ---reject--- \nn" +
-							"Przykład poprawnej struktury odpowiedzi: \n\n" +
-							"<h3>" + subtitle3lvl + "</h3>" + "<p>...</p>",
+						Role:    llm.RoleUser,
+						Content: subsectionPrompt,
 					},
 				}
 				allMessagesLvl3 = append(allMessagesLvl3, messagesLvl3...)
 			}
 
-			respLvl3, err := c.ask(allMessagesLvl3)
+			correctedRespLvl3, err := c.step(ctx, steps, SubsectionStep(section, index), func() (string, error) {
+				respLvl3, err := c.ask(ctx, allMessagesLvl3)
+				if err != nil {
+					return "", err
+				}
+
+				if c.CheckIfResponseContainRejected(respLvl3) {
+					logger.Info().Msgf("Text cannot be proccess %s", subtitle3lvl)
+					return "", nil
+				}
+
+				return c.correctGrammar(ctx, respLvl3, domainId, lang, usedPrompts)
+			})
 			if err != nil {
-				return "", err
+				return nil, err
 			}
 
-			if c.CheckIfResponseContainRejected(respLvl3) {
-				logger.Info().Msgf("Text cannot be proccess %s", subtitle3lvl)
+			if correctedRespLvl3 == "" {
 				continue
 			}
 
-			correctedRespLvl3, err := c.CorrectGrammar(respLvl3)
-			if err != nil {
-				return "", err
-			}
-
 			articleDescription.WriteString(correctedRespLvl3)
 
 			logger.Info().Interface("subtitle 3lvl: ", subtitle3lvl).Send()
 		}
 	}
 
-	//promptTokenCost := float64(c.Usage.PromptTokens) / 1000 * 0.01
-	//completionTokensCost := float64(c.Usage.CompletionTokens) / 1000 * 0.03
-	//totalTokenCost := promptTokenCost + completionTokensCost
-	//
-	//promptTokenCostInDolars := fmt.Sprintf("%f $", promptTokenCost)
-	//completionTokensCostInDolars := fmt.Sprintf("%f $", completionTokensCost)
-	//totalTokenCostInDolars := fmt.Sprintf("%f $", totalTokenCost)
-	//
-	//articleDescription.WriteString("<p>Usage:</p>" +
-	//	"<p>PromptTokenCost: " + fmt.Sprintf("%d", c.Usage.PromptTokens) + " - " + promptTokenCostInDolars + "</p>" +
-	//	"<p>CompletionTokenCost: " + fmt.Sprintf("%d", c.Usage.CompletionTokens) + " - " + completionTokensCostInDolars + "</p>" +
-	//	"<p>TotalTokenCost: " + fmt.Sprintf("%d", c.Usage.TotalTokens) + " - " + totalTokenCostInDolars + "</p>")
-
-	c.Usage = &openai.Usage{PromptTokens: 0, CompletionTokens: 0, TotalTokens: 0}
-
 	re := regexp.MustCompile("<h1[^>]*>(.*?)</h1>")
 	articleDescriptionWithoutH1 := re.ReplaceAllString(articleDescription.String(), "")
 
-	return articleDescriptionWithoutH1, nil
+	versions := make([]prompts.Version, 0, len(usedPrompts))
+	for _, version := range usedPrompts {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Name < versions[j].Name })
+
+	return &ArticleDescription{Body: articleDescriptionWithoutH1, Agenda: articleAgenda, PromptVersions: versions}, nil
 }
 
-func (c *chatGPT) newChatCompletion(messages []openai.ChatCompletionMessage, model string) (openai.ChatCompletionResponse, error) {
+// Embed returns the embeddings of texts with the embedding model, in the order
+// of texts. Embeddings are not cached here, callers keep the ones they reuse.
+func (c *chatGPT) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
 
-	logger.Info().Msg(model)
+	logger.Info().Msgf("%s: %s", c.provider.Name(), c.embeddingModel)
 
-	resp, err := c.Client.CreateChatCompletion(
-		context.Background(),
-		openai.ChatCompletionRequest{
-			Model:       model,
-			Messages:    messages,
-			Temperature: 0,
-		},
-	)
+	resp, err := c.provider.CreateEmbedding(ctx, llm.EmbeddingRequest{
+		Model: c.embeddingModel,
+		Input: texts,
+	})
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
 
-	return resp, err
-}
+	c.meter.Add(c.embeddingModel, resp.Usage)
 
-func (c *chatGPT) retry(messages []openai.ChatCompletionMessage, retriesLimit int, model string) (openai.ChatCompletionResponse, error) {
-	var (
-		retries     = 0
-		isAskFailed = true
-	)
+	if len(resp.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("%s returned %d embeddings for %d texts", c.provider.Name(), len(resp.Embeddings), len(texts))
+	}
 
-	for {
-		logger.Info().Msg("Re-attempting...")
-		retries += 1
-		logger.Info().Msgf("Retry number: %d", retries)
+	return resp.Embeddings, nil
+}
 
-		resp, err := c.newChatCompletion(messages, model)
+func (c *chatGPT) EmbeddingModel() string {
+	return c.embeddingModel
+}
 
-		if err != nil {
-			logger.Err(err).Send()
-		} else {
-			isAskFailed = false
-		}
+func (c *chatGPT) newChatCompletion(ctx context.Context, request llm.CompletionRequest) (llm.CompletionResponse, error) {
 
-		if retries > retriesLimit || !isAskFailed {
-			logger.Info().Msg("Repeated query successful")
-			return resp, err
-		}
-	}
+	logger.Info().Msgf("%s: %s", c.provider.Name(), request.Model)
+
+	resp, err := c.provider.CreateCompletion(ctx, request)
 
+	return resp, err
 }
 
-func (c *chatGPT) ask(messages []openai.ChatCompletionMessage, model ...string) (string, error) {
-	chatCompletionModel := openai.GPT4TurboPreview
+func (c *chatGPT) ask(ctx context.Context, messages []llm.Message, model ...string) (string, error) {
+	chatCompletionModel := c.model
 	if len(model) > 0 {
 		chatCompletionModel = model[0]
 	}
 
-	resp, err := c.newChatCompletion(messages, chatCompletionModel)
+	return c.complete(ctx, llm.CompletionRequest{
+		Model:       chatCompletionModel,
+		Messages:    messages,
+		Temperature: 0,
+	})
+}
+
+// complete returns the reply to request. Cached replies cost nothing, so they
+// are not added to the meter.
+func (c *chatGPT) complete(ctx context.Context, request llm.CompletionRequest) (string, error) {
+	key := llm.CacheKey(request)
+
+	if cached := c.cached(ctx, key); cached != nil {
+		logger.Info().Msgf("%s: %s served from cache", c.provider.Name(), request.Model)
+		return cached.Content, nil
+	}
 
+	resp, err := c.newChatCompletion(ctx, request)
 	if err != nil {
 		logger.Err(err).Send()
-		resp, err = c.retry(messages, c.retriesLimit, chatCompletionModel)
+		return "", err
+	}
+
+	c.meter.Add(request.Model, resp.Usage)
+	c.remember(ctx, request, resp.Content)
+
+	logger.Info().Interface("Usage: ", resp.Usage).Send()
+	return resp.Content, nil
+}
+
+// cached returns nil on a cache miss. The cache only saves money, so its
+// errors are logged and the request goes to the provider.
+func (c *chatGPT) cached(ctx context.Context, key string) *llm.CompletionResponse {
+	if c.cache == nil || c.skipCacheRead {
+		return nil
 	}
 
+	resp, err := c.cache.Get(ctx, key)
 	if err != nil {
-		return "", err
+		logger.Err(err).Msg("Cannot read completion from cache")
+		return nil
 	}
 
-	c.Usage = &openai.Usage{
-		PromptTokens:     c.Usage.PromptTokens + resp.Usage.PromptTokens,
-		CompletionTokens: c.Usage.CompletionTokens + resp.Usage.CompletionTokens,
-		TotalTokens:      c.Usage.TotalTokens + resp.Usage.TotalTokens,
+	return resp
+}
+
+// remember caches content as the reply to request.
+func (c *chatGPT) remember(ctx context.Context, request llm.CompletionRequest, content string) {
+	if c.cache == nil {
+		return
 	}
 
-	logger.Info().Interface("Usage: ", resp.Usage).Send()
-	return resp.Choices[0].Message.Content, nil
+	err := c.cache.Set(ctx, llm.CacheKey(request), llm.CompletionResponse{Content: content, Model: request.Model})
+	if err != nil {
+		logger.Err(err).Msg("Cannot cache completion")
+	}
+}
+
+// forget removes the cached reply to request, e.g. because it was rejected.
+func (c *chatGPT) forget(ctx context.Context, request llm.CompletionRequest) {
+	if c.cache == nil {
+		return
+	}
+
+	if err := c.cache.Delete(ctx, llm.CacheKey(request)); err != nil {
+		logger.Err(err).Msg("Cannot remove completion from cache")
+	}
 }
 
 func init() {
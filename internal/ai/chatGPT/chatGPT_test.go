@@ -0,0 +1,37 @@
+package chatgpt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+)
+
+// shortEmbeddingProvider drops the last embedding of every request.
+type shortEmbeddingProvider struct {
+	*llm.FakeProvider
+}
+
+func (p shortEmbeddingProvider) CreateEmbedding(ctx context.Context, request llm.EmbeddingRequest) (llm.EmbeddingResponse, error) {
+	resp, err := p.FakeProvider.CreateEmbedding(ctx, request)
+	resp.Embeddings = resp.Embeddings[:len(resp.Embeddings)-1]
+	return resp, err
+}
+
+func TestEmbed(t *testing.T) {
+	texts := []string{"roof", "gutter"}
+
+	c := newTestClient(t, llm.NewFakeProvider(), 0)
+	embeddings, err := c.Embed(context.Background(), texts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(embeddings) != len(texts) {
+		t.Errorf("got %d embeddings, want %d", len(embeddings), len(texts))
+	}
+
+	c = newTestClient(t, shortEmbeddingProvider{llm.NewFakeProvider()}, 0)
+	if embeddings, err := c.Embed(context.Background(), texts); err == nil {
+		t.Errorf("got %d embeddings for %d texts and no error", len(embeddings), len(texts))
+	}
+}
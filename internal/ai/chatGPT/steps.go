@@ -0,0 +1,65 @@
+package chatgpt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Names of the steps of GenerateArticleDescription. Sections are numbered by
+// their position in the agenda.
+const (
+	StepAgenda       = "agenda"
+	StepIntroduction = "introduction"
+)
+
+func SummaryStep(pageContent int) string {
+	return fmt.Sprintf("summary:%d", pageContent)
+}
+
+func SectionStep(section int) string {
+	return fmt.Sprintf("section:%d", section)
+}
+
+func SubsectionStep(section int, subsection int) string {
+	return fmt.Sprintf("section:%d:%d", section, subsection)
+}
+
+// IsSectionStep reports whether step writes a part of the article body.
+func IsSectionStep(step string) bool {
+	return step == StepIntroduction || strings.HasPrefix(step, "section:")
+}
+
+// Steps keeps the output of every completed step of an article generation, so
+// a generation that failed half way does not start over. A section the model
+// rejected is completed with an empty output.
+type Steps interface {
+	// Completed returns the output of step and whether it was completed before.
+	Completed(step string) (string, bool)
+	Complete(ctx context.Context, step string, output string) error
+}
+
+// step returns the output the step was completed with before, or runs generate
+// and records its output in steps. steps may be nil.
+func (c *chatGPT) step(ctx context.Context, steps Steps, name string, generate func() (string, error)) (string, error) {
+	if steps != nil {
+		if output, ok := steps.Completed(name); ok {
+			logger.Info().Msgf("Step %s is already completed", name)
+			return output, nil
+		}
+	}
+
+	output, err := generate()
+	if err != nil {
+		return "", err
+	}
+
+	if steps != nil {
+		// Losing the step only means it is generated again on a retry.
+		if err := steps.Complete(ctx, name, output); err != nil {
+			logger.Err(err).Msgf("Cannot save step %s", name)
+		}
+	}
+
+	return output, nil
+}
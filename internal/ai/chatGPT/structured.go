@@ -0,0 +1,123 @@
+package chatgpt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+	"github.com/rustoma/octo-pulse/internal/ai/schema"
+)
+
+const (
+	structuredOutputInstruction = "Reply only with a JSON object that matches this JSON schema:\n%s"
+	repairInstruction           = "Your reply is invalid: %s\nReply again with the corrected JSON object only."
+)
+
+// askStructured asks the model for a JSON reply and decodes it into out, which
+// must be a pointer. The reply is validated against the schema derived from out
+// and then by check, if given. An invalid reply is sent back to the model with
+// the validation errors, up to c.repairLimit times, before giving up.
+func (c *chatGPT) askStructured(ctx context.Context, messages []llm.Message, out interface{}, check func() error) error {
+	if reflect.ValueOf(out).Kind() != reflect.Pointer {
+		return fmt.Errorf("structured output target must be a pointer, got %T", out)
+	}
+
+	outSchema, err := schema.For(out)
+	if err != nil {
+		return err
+	}
+
+	conversation := make([]llm.Message, 0, len(messages)+1)
+	conversation = append(conversation, llm.Message{
+		Role:    llm.RoleSystem,
+		Content: fmt.Sprintf(structuredOutputInstruction, outSchema),
+	})
+	conversation = append(conversation, messages...)
+
+	var firstRequest llm.CompletionRequest
+
+	for attempt := 0; ; attempt++ {
+		request := llm.CompletionRequest{
+			Model:       c.model,
+			Messages:    conversation,
+			Temperature: 0,
+			JSONMode:    c.jsonMode,
+		}
+		if attempt == 0 {
+			firstRequest = request
+		}
+
+		reply, err := c.complete(ctx, request)
+		if err != nil {
+			return err
+		}
+
+		err = decodeStructured(reply, outSchema, out, check)
+		if err == nil {
+			// Next time answer the original question with the repaired reply.
+			if attempt > 0 {
+				c.remember(ctx, firstRequest, reply)
+			}
+			return nil
+		}
+
+		// Never serve an invalid reply again, a retried task should get a new one.
+		c.forget(ctx, request)
+
+		if attempt >= c.repairLimit {
+			logger.Info().Interface("Reply: ", reply).Send()
+			return fmt.Errorf("structured output still invalid after %d repair attempts: %w", attempt, err)
+		}
+
+		logger.Warn().Msgf("Invalid structured output, repair attempt %d: %v", attempt+1, err)
+
+		conversation = append(conversation,
+			llm.Message{
+				Role:    llm.RoleAssistant,
+				Content: reply,
+			},
+			llm.Message{
+				Role:    llm.RoleUser,
+				Content: fmt.Sprintf(repairInstruction, err),
+			})
+	}
+}
+
+func decodeStructured(reply string, outSchema *schema.Schema, out interface{}, check func() error) error {
+	content := []byte(stripCodeFence(reply))
+
+	if err := outSchema.Validate(content); err != nil {
+		return err
+	}
+
+	// Reset out so nothing is left over from a previous, rejected reply.
+	value := reflect.ValueOf(out).Elem()
+	value.Set(reflect.Zero(value.Type()))
+
+	if err := json.Unmarshal(content, out); err != nil {
+		return err
+	}
+
+	if check != nil {
+		return check()
+	}
+
+	return nil
+}
+
+// stripCodeFence removes the markdown code block models like to wrap JSON in.
+func stripCodeFence(reply string) string {
+	reply = strings.TrimSpace(reply)
+	if !strings.HasPrefix(reply, "```") {
+		return reply
+	}
+
+	reply = strings.TrimPrefix(reply, "```")
+	reply = strings.TrimPrefix(reply, "json")
+	reply = strings.TrimSuffix(reply, "```")
+
+	return strings.TrimSpace(reply)
+}
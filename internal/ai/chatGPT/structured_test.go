@@ -0,0 +1,140 @@
+package chatgpt
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+)
+
+type testHeadings struct {
+	Headings []string `json:"headings" jsonschema:"minItems=2"`
+}
+
+func newTestClient(t *testing.T, provider llm.Provider, repairLimit int) *chatGPT {
+	cache, err := llm.NewFileCache(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &chatGPT{repairLimit: repairLimit, jsonMode: true, provider: provider, model: "test", cache: cache}
+}
+
+var headingsQuestion = []llm.Message{{Role: llm.RoleUser, Content: "Write the headings of an article about roof cleaning."}}
+
+func TestAskStructured(t *testing.T) {
+	provider := llm.NewFakeProvider("```json\n{\"headings\":[\"Tools\",\"Steps\"]}\n```")
+	c := newTestClient(t, provider, 2)
+
+	var out testHeadings
+	if err := c.askStructured(context.Background(), headingsQuestion, &out, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Join(out.Headings, ",") != "Tools,Steps" {
+		t.Errorf("headings = %v, want [Tools Steps]", out.Headings)
+	}
+
+	requests := provider.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("provider got %d requests, want 1", len(requests))
+	}
+
+	if system := requests[0].Messages[0]; system.Role != llm.RoleSystem || !strings.Contains(system.Content, `"minItems":2`) {
+		t.Errorf("the schema is not sent to the model: %q", system.Content)
+	}
+	if !requests[0].JSONMode {
+		t.Error("JSON mode is off")
+	}
+}
+
+func TestAskStructuredRepair(t *testing.T) {
+	provider := llm.NewFakeProvider(
+		`{"headings":["Tools"]}`,
+		`{"headings":["Tools","Steps","Safety"]}`,
+	)
+	c := newTestClient(t, provider, 2)
+
+	var out testHeadings
+	if err := c.askStructured(context.Background(), headingsQuestion, &out, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(out.Headings) != 3 {
+		t.Errorf("headings = %v, want the repaired reply", out.Headings)
+	}
+
+	requests := provider.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("provider got %d requests, want 2", len(requests))
+	}
+
+	repair := requests[1].Messages[len(requests[1].Messages)-1]
+	if !strings.Contains(repair.Content, "$.headings: expected at least 2 items, got 1") {
+		t.Errorf("the repair request does not name the problem: %q", repair.Content)
+	}
+
+	// The repaired reply answers the original question from now on.
+	out = testHeadings{}
+	if err := c.askStructured(context.Background(), headingsQuestion, &out, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Headings) != 3 || len(provider.Requests()) != 2 {
+		t.Errorf("the repaired reply was not served from the cache, headings = %v", out.Headings)
+	}
+}
+
+func TestAskStructuredGivesUp(t *testing.T) {
+	provider := llm.NewFakeProvider(`not json`, `{"headings":[]}`, `{"headings":["Tools","Steps"]}`)
+	c := newTestClient(t, provider, 1)
+
+	var out testHeadings
+	err := c.askStructured(context.Background(), headingsQuestion, &out, nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if len(provider.Requests()) != 2 {
+		t.Errorf("provider got %d requests, want the question and a single repair", len(provider.Requests()))
+	}
+}
+
+func TestAskStructuredCheck(t *testing.T) {
+	provider := llm.NewFakeProvider(`{"headings":["Tools","Tools"]}`, `{"headings":["Tools","Steps"]}`)
+	c := newTestClient(t, provider, 2)
+
+	var out testHeadings
+	check := func() error {
+		if out.Headings[0] == out.Headings[1] {
+			return errors.New("headings must differ")
+		}
+		return nil
+	}
+
+	if err := c.askStructured(context.Background(), headingsQuestion, &out, check); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := provider.Requests()
+	if len(requests) != 2 || !strings.Contains(requests[1].Messages[len(requests[1].Messages)-1].Content, "headings must differ") {
+		t.Errorf("the failed check was not sent back to the model")
+	}
+}
+
+func TestStripCodeFence(t *testing.T) {
+	tests := map[string]string{
+		`{"a":1}`:                 `{"a":1}`,
+		"```json\n{\"a\":1}\n```": `{"a":1}`,
+		"```\n{\"a\":1}\n```":     `{"a":1}`,
+		"  \n{\"a\":1}\n  ":       `{"a":1}`,
+	}
+
+	for reply, want := range tests {
+		if got := stripCodeFence(reply); got != want {
+			t.Errorf("stripCodeFence(%q) = %q, want %q", reply, got, want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package chatgpt
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+	"github.com/rustoma/octo-pulse/internal/ai/prompts"
+	"github.com/rustoma/octo-pulse/internal/language"
+	"github.com/rustoma/octo-pulse/internal/models"
+)
+
+// translationChunkLimit is the number of characters of the body translated in
+// one request. The body is split before its headings, a single section longer
+// than the limit is sent whole.
+const translationChunkLimit = 8000
+
+var headingStart = regexp.MustCompile(`(?i)<h[2-3][\s>]`)
+
+// TranslatedMetadata holds the translated title and SEO fields of an article.
+// The limits match the article validator.
+type TranslatedMetadata struct {
+	Title           string `json:"title" jsonschema:"minLength=1"`
+	MetaTitle       string `json:"metaTitle" jsonschema:"maxLength=60"`
+	MetaDescription string `json:"metaDescription" jsonschema:"maxLength=160"`
+	FocusKeyword    string `json:"focusKeyword" jsonschema:"maxLength=60"`
+	Excerpt         string `json:"excerpt" jsonschema:"maxLength=300"`
+}
+
+// ArticleTranslation is an article translated into the language of another
+// domain.
+type ArticleTranslation struct {
+	TranslatedMetadata
+	Body string
+}
+
+// TranslateArticle translates the title, HTML body and SEO metadata of the
+// article from the language of the from domain into the language of the to
+// domain.
+func (c *chatGPT) TranslateArticle(ctx context.Context, article *models.Article, from *models.Domain, to *models.Domain) (*ArticleTranslation, error) {
+	sourceLang := language.Get(from.Language)
+	targetLang := language.Get(to.Language)
+
+	fields, err := json.Marshal(TranslatedMetadata{
+		Title:           article.Title,
+		MetaTitle:       article.MetaTitle,
+		MetaDescription: article.MetaDescription,
+		FocusKeyword:    article.FocusKeyword,
+		Excerpt:         article.Excerpt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prompt, err := c.render(ctx, prompts.TranslateMetadata, to.ID, targetLang, map[string]interface{}{
+		"Fields":         string(fields),
+		"SourceLanguage": sourceLang.Name,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	translation := &ArticleTranslation{}
+	err = c.askStructured(ctx, []llm.Message{{Role: llm.RoleUser, Content: prompt}}, &translation.TranslatedMetadata, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var body strings.Builder
+	for _, chunk := range splitHtml(article.Body, translationChunkLimit) {
+		prompt, err := c.render(ctx, prompts.TranslateHtml, to.ID, targetLang, map[string]interface{}{
+			"Text":           chunk,
+			"SourceLanguage": sourceLang.Name,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		translated, err := c.ask(ctx, []llm.Message{{Role: llm.RoleUser, Content: prompt}})
+		if err != nil {
+			return nil, err
+		}
+
+		body.WriteString(stripHtmlFence(translated))
+		body.WriteString("\n")
+	}
+
+	translation.Body = strings.TrimSpace(body.String())
+
+	return translation, nil
+}
+
+// splitHtml cuts body before its h2 and h3 headings and joins the consecutive
+// parts into chunks of at most limit characters.
+func splitHtml(body string, limit int) []string {
+	var parts []string
+	start := 0
+	for _, match := range headingStart.FindAllStringIndex(body, -1) {
+		if match[0] > start {
+			parts = append(parts, body[start:match[0]])
+		}
+		start = match[0]
+	}
+	parts = append(parts, body[start:])
+
+	var chunks []string
+	var chunk strings.Builder
+	for _, part := range parts {
+		if chunk.Len() > 0 && chunk.Len()+len(part) > limit {
+			chunks = append(chunks, chunk.String())
+			chunk.Reset()
+		}
+		chunk.WriteString(part)
+	}
+
+	if strings.TrimSpace(chunk.String()) != "" {
+		chunks = append(chunks, chunk.String())
+	}
+
+	return chunks
+}
+
+// stripHtmlFence removes the markdown code block the model may wrap the
+// translated HTML in.
+func stripHtmlFence(reply string) string {
+	reply = strings.TrimSpace(reply)
+	if !strings.HasPrefix(reply, "```") {
+		return reply
+	}
+
+	reply = strings.TrimPrefix(reply, "```")
+	reply = strings.TrimPrefix(reply, "html")
+	reply = strings.TrimSuffix(reply, "```")
+
+	return strings.TrimSpace(reply)
+}
@@ -0,0 +1,161 @@
+package llm
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the provider while the breaker is open.
+var ErrCircuitOpen = errors.New("llm circuit breaker is open")
+
+// CircuitOpenError tells how long the breaker stays open.
+type CircuitOpenError struct {
+	RetryIn time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s, retry in %s", ErrCircuitOpen, e.RetryIn.Round(time.Second))
+}
+
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// Breaker stops calls to a provider after threshold consecutive outage errors
+// (see IsOutage). Once cooldown has passed a single probe call is let through:
+// if it succeeds the breaker closes, otherwise it opens again. Errors caused by
+// the request itself, like a too long prompt, do not count. It is safe for
+// concurrent use.
+type Breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     BreakerState
+	failures  int
+	openUntil time.Time
+	probing   bool
+	listeners []func(state BreakerState, retryIn time.Duration)
+}
+
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	return &Breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// OnStateChange registers listener to be called after every state change.
+// retryIn is the remaining cooldown when the breaker opens, zero otherwise.
+func (b *Breaker) OnStateChange(listener func(state BreakerState, retryIn time.Duration)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.listeners = append(b.listeners, listener)
+}
+
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// Allow returns a *CircuitOpenError when the call must not reach the provider.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+
+	switch b.state {
+	case BreakerOpen:
+		if retryIn := time.Until(b.openUntil); retryIn > 0 {
+			b.mu.Unlock()
+			return &CircuitOpenError{RetryIn: retryIn}
+		}
+		b.probing = true
+		b.setState(BreakerHalfOpen, 0)
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			b.mu.Unlock()
+			return &CircuitOpenError{RetryIn: b.cooldown}
+		}
+		b.probing = true
+	}
+
+	b.mu.Unlock()
+	return nil
+}
+
+// Record updates the breaker with the result of a call that was allowed.
+func (b *Breaker) Record(err error) {
+	b.mu.Lock()
+
+	b.probing = false
+
+	if err == nil || !IsOutage(err) {
+		b.failures = 0
+		if b.state != BreakerClosed {
+			b.setState(BreakerClosed, 0)
+			return
+		}
+		b.mu.Unlock()
+		return
+	}
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+		b.setState(BreakerOpen, b.cooldown)
+		return
+	}
+
+	b.mu.Unlock()
+}
+
+// Abandon releases a call that was allowed but whose result tells nothing about
+// the provider, e.g. because the caller cancelled it. A probing breaker lets the
+// next call through instead.
+func (b *Breaker) Abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// setState must be called with b.mu held and unlocks it before notifying the
+// listeners, so they are free to call back into the breaker.
+func (b *Breaker) setState(state BreakerState, retryIn time.Duration) {
+	b.state = state
+	listeners := append([]func(BreakerState, time.Duration){}, b.listeners...)
+	b.mu.Unlock()
+
+	logger.Warn().Msgf("LLM circuit breaker is %s", state)
+
+	for _, listener := range listeners {
+		listener(state, retryIn)
+	}
+}
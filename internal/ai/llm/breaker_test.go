@@ -0,0 +1,171 @@
+package llm
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const testCooldown = 20 * time.Millisecond
+
+var (
+	outageErr  = &APIError{Provider: ProviderLocal, StatusCode: http.StatusServiceUnavailable}
+	requestErr = &APIError{Provider: ProviderLocal, StatusCode: http.StatusBadRequest}
+)
+
+type breakerStep struct {
+	// action is one of allow, record, abandon and wait.
+	action string
+	// err is the result of the call passed to record.
+	err error
+	// blocked is whether allow is expected to refuse the call.
+	blocked   bool
+	wantState BreakerState
+}
+
+func TestBreaker(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold int
+		steps     []breakerStep
+	}{
+		{
+			name:      "stays closed below the threshold",
+			threshold: 3,
+			steps: []breakerStep{
+				{action: "record", err: outageErr, wantState: BreakerClosed},
+				{action: "record", err: outageErr, wantState: BreakerClosed},
+				{action: "allow", wantState: BreakerClosed},
+			},
+		},
+		{
+			name:      "opens at the threshold",
+			threshold: 2,
+			steps: []breakerStep{
+				{action: "record", err: outageErr, wantState: BreakerClosed},
+				{action: "record", err: outageErr, wantState: BreakerOpen},
+				{action: "allow", blocked: true, wantState: BreakerOpen},
+			},
+		},
+		{
+			name:      "request errors and successes reset the failures",
+			threshold: 2,
+			steps: []breakerStep{
+				{action: "record", err: outageErr, wantState: BreakerClosed},
+				{action: "record", err: requestErr, wantState: BreakerClosed},
+				{action: "record", err: outageErr, wantState: BreakerClosed},
+				{action: "record", err: nil, wantState: BreakerClosed},
+				{action: "record", err: outageErr, wantState: BreakerClosed},
+			},
+		},
+		{
+			name:      "lets a single probe through after the cooldown",
+			threshold: 1,
+			steps: []breakerStep{
+				{action: "record", err: outageErr, wantState: BreakerOpen},
+				{action: "wait", wantState: BreakerOpen},
+				{action: "allow", wantState: BreakerHalfOpen},
+				{action: "allow", blocked: true, wantState: BreakerHalfOpen},
+			},
+		},
+		{
+			name:      "closes when the probe succeeds",
+			threshold: 1,
+			steps: []breakerStep{
+				{action: "record", err: outageErr, wantState: BreakerOpen},
+				{action: "wait", wantState: BreakerOpen},
+				{action: "allow", wantState: BreakerHalfOpen},
+				{action: "record", err: nil, wantState: BreakerClosed},
+				{action: "allow", wantState: BreakerClosed},
+			},
+		},
+		{
+			name:      "opens again when the probe fails",
+			threshold: 3,
+			steps: []breakerStep{
+				{action: "record", err: outageErr, wantState: BreakerClosed},
+				{action: "record", err: outageErr, wantState: BreakerClosed},
+				{action: "record", err: outageErr, wantState: BreakerOpen},
+				{action: "wait", wantState: BreakerOpen},
+				{action: "allow", wantState: BreakerHalfOpen},
+				{action: "record", err: outageErr, wantState: BreakerOpen},
+				{action: "allow", blocked: true, wantState: BreakerOpen},
+			},
+		},
+		{
+			name:      "an abandoned probe lets the next call probe",
+			threshold: 1,
+			steps: []breakerStep{
+				{action: "record", err: outageErr, wantState: BreakerOpen},
+				{action: "wait", wantState: BreakerOpen},
+				{action: "allow", wantState: BreakerHalfOpen},
+				{action: "abandon", wantState: BreakerHalfOpen},
+				{action: "allow", wantState: BreakerHalfOpen},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaker := NewBreaker(tt.threshold, testCooldown)
+
+			for i, step := range tt.steps {
+				switch step.action {
+				case "allow":
+					err := breaker.Allow()
+					if blocked := err != nil; blocked != step.blocked {
+						t.Fatalf("step %d: Allow() = %v, blocked want %t", i, err, step.blocked)
+					}
+					if err != nil && !errors.Is(err, ErrCircuitOpen) {
+						t.Fatalf("step %d: Allow() = %v, want ErrCircuitOpen", i, err)
+					}
+				case "record":
+					breaker.Record(step.err)
+				case "abandon":
+					breaker.Abandon()
+				case "wait":
+					time.Sleep(testCooldown + 5*time.Millisecond)
+				}
+
+				if state := breaker.State(); state != step.wantState {
+					t.Fatalf("step %d (%s): state = %s, want %s", i, step.action, state, step.wantState)
+				}
+			}
+		})
+	}
+}
+
+func TestBreakerNotifiesListeners(t *testing.T) {
+	breaker := NewBreaker(1, testCooldown)
+
+	var states []BreakerState
+	var openFor time.Duration
+	breaker.OnStateChange(func(state BreakerState, retryIn time.Duration) {
+		states = append(states, state)
+		if state == BreakerOpen {
+			openFor = retryIn
+		}
+	})
+
+	breaker.Record(outageErr)
+	time.Sleep(testCooldown + 5*time.Millisecond)
+	if err := breaker.Allow(); err != nil {
+		t.Fatal(err)
+	}
+	breaker.Record(nil)
+
+	want := []BreakerState{BreakerOpen, BreakerHalfOpen, BreakerClosed}
+	if len(states) != len(want) {
+		t.Fatalf("states = %v, want %v", states, want)
+	}
+	for i := range want {
+		if states[i] != want[i] {
+			t.Fatalf("states = %v, want %v", states, want)
+		}
+	}
+
+	if openFor != testCooldown {
+		t.Errorf("retryIn = %s, want %s", openFor, testCooldown)
+	}
+}
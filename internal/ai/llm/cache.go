@@ -0,0 +1,221 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rustoma/octo-pulse/internal/models"
+	"github.com/rustoma/octo-pulse/internal/storage"
+)
+
+const (
+	CacheNone     = ""
+	CacheFile     = "file"
+	CachePostgres = "postgres"
+)
+
+// Cache stores completions under the key of the request (see CacheKey). Entries
+// expire after the TTL the cache was created with.
+type Cache interface {
+	// Get returns nil when there is no fresh entry for key.
+	Get(ctx context.Context, key string) (*CompletionResponse, error)
+	Set(ctx context.Context, key string, resp CompletionResponse) error
+	Delete(ctx context.Context, key string) error
+	// Purge removes the expired entries.
+	Purge(ctx context.Context) error
+}
+
+// cacheKeyVersion is part of every key. Bump it when the meaning of a cached
+// completion changes, e.g. when CompletionRequest gets a new field.
+const cacheKeyVersion = 1
+
+// CacheKey returns the sha256 of everything that affects the completion: the
+// model, the messages and the parameters of the request.
+func CacheKey(request CompletionRequest) string {
+	payload, _ := json.Marshal(struct {
+		Version     int       `json:"v"`
+		Model       string    `json:"model"`
+		Messages    []Message `json:"messages"`
+		Temperature float32   `json:"temperature"`
+		JSONMode    bool      `json:"jsonMode"`
+	}{cacheKeyVersion, request.Model, request.Messages, request.Temperature, request.JSONMode})
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// NewCache returns the cache selected by kind. CacheNone returns a nil cache.
+// The file cache keeps its entries in dir, the postgres cache in store.
+func NewCache(kind string, ttl time.Duration, dir string, store storage.LlmCacheStore) (Cache, error) {
+	switch kind {
+	case CacheNone:
+		return nil, nil
+	case CacheFile:
+		if dir == "" {
+			return nil, fmt.Errorf("a directory is required for the %s cache", CacheFile)
+		}
+		return NewFileCache(dir, ttl)
+	case CachePostgres:
+		if store == nil {
+			return nil, fmt.Errorf("a store is required for the %s cache", CachePostgres)
+		}
+		return NewStoreCache(store, ttl), nil
+	default:
+		return nil, fmt.Errorf("unknown AI cache: %s", kind)
+	}
+}
+
+type fileCache struct {
+	dir string
+	ttl time.Duration
+}
+
+type fileCacheEntry struct {
+	Response  CompletionResponse `json:"response"`
+	ExpiresAt time.Time          `json:"expiresAt"`
+}
+
+// NewFileCache keeps one JSON file per entry in dir.
+func NewFileCache(dir string, ttl time.Duration) (Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &fileCache{dir: dir, ttl: ttl}, nil
+}
+
+func (c *fileCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+func (c *fileCache) Get(ctx context.Context, key string) (*CompletionResponse, error) {
+	content, err := os.ReadFile(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entry fileCacheEntry
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return nil, err
+	}
+
+	if !entry.ExpiresAt.After(time.Now()) {
+		return nil, c.Delete(ctx, key)
+	}
+
+	return &entry.Response, nil
+}
+
+func (c *fileCache) Set(ctx context.Context, key string, resp CompletionResponse) error {
+	content, err := json.Marshal(fileCacheEntry{Response: resp, ExpiresAt: time.Now().Add(c.ttl)})
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so readers never see a partial entry.
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+func (c *fileCache) Delete(ctx context.Context, key string) error {
+	err := os.Remove(c.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+func (c *fileCache) Purge(ctx context.Context) error {
+	paths, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		key := filepath.Base(path)
+		if _, err := c.Get(ctx, key[:len(key)-len(".json")]); err != nil {
+			logger.Err(err).Msgf("Cannot purge cache entry %s", path)
+		}
+	}
+
+	return nil
+}
+
+type storeCache struct {
+	store storage.LlmCacheStore
+	ttl   time.Duration
+}
+
+// NewStoreCache keeps the entries in the database.
+func NewStoreCache(store storage.LlmCacheStore, ttl time.Duration) Cache {
+	return &storeCache{store: store, ttl: ttl}
+}
+
+func (c *storeCache) Get(ctx context.Context, key string) (*CompletionResponse, error) {
+	entry, err := c.store.GetLlmCacheEntry(ctx, key)
+	if err != nil || entry == nil {
+		return nil, err
+	}
+
+	return &CompletionResponse{
+		Content: entry.Content,
+		Model:   entry.Model,
+		Usage: Usage{
+			PromptTokens:     entry.PromptTokens,
+			CompletionTokens: entry.CompletionTokens,
+			TotalTokens:      entry.TotalTokens,
+		},
+	}, nil
+}
+
+func (c *storeCache) Set(ctx context.Context, key string, resp CompletionResponse) error {
+	return c.store.UpsertLlmCacheEntry(ctx, &models.LlmCacheEntry{
+		Key:              key,
+		Model:            resp.Model,
+		Content:          resp.Content,
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+		TotalTokens:      resp.Usage.TotalTokens,
+		ExpiresAt:        time.Now().UTC().Add(c.ttl),
+	})
+}
+
+func (c *storeCache) Delete(ctx context.Context, key string) error {
+	return c.store.DeleteLlmCacheEntry(ctx, key)
+}
+
+func (c *storeCache) Purge(ctx context.Context) error {
+	deleted, err := c.store.DeleteExpiredLlmCacheEntries(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("Purged %d expired LLM cache entries", deleted)
+	return nil
+}
@@ -0,0 +1,43 @@
+package llm
+
+import "math"
+
+// CosineSimilarity returns the cosine of the angle between a and b, from -1 to
+// 1. Vectors of different length or without direction have a similarity of 0.
+func CosineSimilarity(a []float32, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// Normalize scales v to unit length in place and returns it.
+func Normalize(v []float32) []float32 {
+	var norm float64
+	for _, x := range v {
+		norm += float64(x) * float64(x)
+	}
+
+	if norm == 0 {
+		return v
+	}
+
+	norm = math.Sqrt(norm)
+	for i := range v {
+		v[i] = float32(float64(v[i]) / norm)
+	}
+
+	return v
+}
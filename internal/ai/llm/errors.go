@@ -0,0 +1,153 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrorKind tells how a failed call should be handled.
+type ErrorKind int
+
+const (
+	// ErrorUnknown is an error the provider did not explain. It is retried.
+	ErrorUnknown ErrorKind = iota
+	// ErrorRateLimit means the provider asked to slow down (HTTP 429).
+	ErrorRateLimit
+	// ErrorUnavailable covers 5xx responses, timeouts and network failures.
+	ErrorUnavailable
+	// ErrorContextLength means the prompt does not fit the model.
+	ErrorContextLength
+	// ErrorInvalidRequest covers other 4xx responses, e.g. an unknown model.
+	ErrorInvalidRequest
+	// ErrorAuth means the API key is missing, invalid or lacks permissions.
+	ErrorAuth
+	// ErrorCanceled means the caller gave up on the request.
+	ErrorCanceled
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorRateLimit:
+		return "rate_limit"
+	case ErrorUnavailable:
+		return "unavailable"
+	case ErrorContextLength:
+		return "context_length"
+	case ErrorInvalidRequest:
+		return "invalid_request"
+	case ErrorAuth:
+		return "auth"
+	case ErrorCanceled:
+		return "canceled"
+	default:
+		return "unknown"
+	}
+}
+
+// APIError is a non 2xx response of a provider.
+type APIError struct {
+	Provider   string
+	StatusCode int
+	Code       string
+	Message    string
+	// RetryAfter is the delay requested by the provider, zero when not given.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("%s returned status %d (%s): %s", e.Provider, e.StatusCode, e.Code, e.Message)
+	}
+
+	return fmt.Sprintf("%s returned status %d: %s", e.Provider, e.StatusCode, e.Message)
+}
+
+func (e *APIError) Kind() ErrorKind {
+	if e.Code == "context_length_exceeded" || strings.Contains(strings.ToLower(e.Message), "maximum context length") {
+		return ErrorContextLength
+	}
+
+	switch {
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrorRateLimit
+	case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+		return ErrorAuth
+	case e.StatusCode == http.StatusRequestTimeout || e.StatusCode == http.StatusConflict || e.StatusCode >= 500:
+		return ErrorUnavailable
+	case e.StatusCode >= 400:
+		return ErrorInvalidRequest
+	}
+
+	return ErrorUnknown
+}
+
+// Classify returns the kind of err.
+func Classify(err error) ErrorKind {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Kind()
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return ErrorCanceled
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) {
+		return ErrorUnavailable
+	}
+
+	return ErrorUnknown
+}
+
+// IsRetryable reports whether repeating the same request can succeed.
+func IsRetryable(err error) bool {
+	switch Classify(err) {
+	case ErrorRateLimit, ErrorUnavailable, ErrorUnknown:
+		return true
+	}
+
+	return false
+}
+
+// IsOutage reports whether err means the provider itself is unhealthy, as
+// opposed to a problem with the request.
+func IsOutage(err error) bool {
+	switch Classify(err) {
+	case ErrorRateLimit, ErrorUnavailable:
+		return true
+	}
+
+	return false
+}
+
+// parseRetryAfter reads the delay requested by the provider from the
+// Retry-After header (seconds or HTTP date) or the retry-after-ms header.
+func parseRetryAfter(header http.Header) time.Duration {
+	if ms, err := strconv.Atoi(header.Get("retry-after-ms")); err == nil && ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
+
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,143 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"image"
+	"image/png"
+	"strings"
+	"sync"
+)
+
+// FakeProvider is a deterministic Provider for tests and offline development.
+// Queued responses are returned in order; once the queue is empty every
+// completion is derived from a hash of the last message, so the same prompt
+// always gets the same answer.
+type FakeProvider struct {
+	mu        sync.Mutex
+	responses []string
+	requests  []CompletionRequest
+}
+
+func NewFakeProvider(responses ...string) *FakeProvider {
+	return &FakeProvider{responses: responses}
+}
+
+func (p *FakeProvider) Name() string {
+	return ProviderFake
+}
+
+// Enqueue appends responses returned by the next completions.
+func (p *FakeProvider) Enqueue(responses ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.responses = append(p.responses, responses...)
+}
+
+// Requests returns every completion request the provider has received.
+func (p *FakeProvider) Requests() []CompletionRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	requests := make([]CompletionRequest, len(p.requests))
+	copy(requests, p.requests)
+
+	return requests
+}
+
+func (p *FakeProvider) CreateCompletion(ctx context.Context, request CompletionRequest) (CompletionResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return CompletionResponse{}, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requests = append(p.requests, request)
+
+	var content string
+	if len(p.responses) > 0 {
+		content = p.responses[0]
+		p.responses = p.responses[1:]
+	} else {
+		var last string
+		if len(request.Messages) > 0 {
+			last = request.Messages[len(request.Messages)-1].Content
+		}
+		sum := sha256.Sum256([]byte(request.Model + last))
+		content = "fake-" + hex.EncodeToString(sum[:8])
+	}
+
+	var promptTokens int
+	for _, message := range request.Messages {
+		promptTokens += len(strings.Fields(message.Content))
+	}
+	completionTokens := len(strings.Fields(content))
+
+	return CompletionResponse{
+		Content: content,
+		Model:   request.Model,
+		Usage: Usage{
+			PromptTokens:     promptTokens,
+			CompletionTokens: completionTokens,
+			TotalTokens:      promptTokens + completionTokens,
+		},
+	}, nil
+}
+
+func (p *FakeProvider) CreateImage(ctx context.Context, request ImageRequest) (ImageResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return ImageResponse{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		return ImageResponse{}, err
+	}
+
+	return ImageResponse{
+		B64JSON:       base64.StdEncoding.EncodeToString(buf.Bytes()),
+		RevisedPrompt: request.Prompt,
+	}, nil
+}
+
+// fakeEmbeddingSize is the length of the fake embeddings.
+const fakeEmbeddingSize = 256
+
+// CreateEmbedding hashes the words of every input into a normalized vector, so
+// texts sharing words are similar and the same text always gets the same
+// embedding.
+func (p *FakeProvider) CreateEmbedding(ctx context.Context, request EmbeddingRequest) (EmbeddingResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return EmbeddingResponse{}, err
+	}
+
+	embeddings := make([][]float32, 0, len(request.Input))
+	var tokens int
+
+	for _, input := range request.Input {
+		embedding := make([]float32, fakeEmbeddingSize)
+		words := strings.Fields(strings.ToLower(input))
+		tokens += len(words)
+
+		for _, word := range words {
+			sum := sha256.Sum256([]byte(word))
+			embedding[int(sum[0])%fakeEmbeddingSize]++
+		}
+
+		embeddings = append(embeddings, Normalize(embedding))
+	}
+
+	return EmbeddingResponse{
+		Embeddings: embeddings,
+		Model:      request.Model,
+		Usage: Usage{
+			PromptTokens: tokens,
+			TotalTokens:  tokens,
+		},
+	}, nil
+}
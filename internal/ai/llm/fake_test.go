@@ -0,0 +1,103 @@
+package llm
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFakeProviderCompletion(t *testing.T) {
+	ctx := context.Background()
+	request := CompletionRequest{
+		Model:    "gpt-4",
+		Messages: []Message{{Role: RoleSystem, Content: "You write titles."}, {Role: RoleUser, Content: "How to clean a roof"}},
+	}
+
+	first, err := NewFakeProvider().CreateCompletion(ctx, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := NewFakeProvider().CreateCompletion(ctx, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first.Content != second.Content || !strings.HasPrefix(first.Content, "fake-") {
+		t.Errorf("contents = %q, %q, want the same fake reply", first.Content, second.Content)
+	}
+	if first.Usage.PromptTokens != 8 || first.Usage.CompletionTokens != 1 || first.Usage.TotalTokens != 9 {
+		t.Errorf("usage = %+v, want 8 + 1 tokens", first.Usage)
+	}
+
+	request.Messages[1].Content = "How to paint a roof"
+	other, err := NewFakeProvider().CreateCompletion(ctx, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.Content == first.Content {
+		t.Errorf("another prompt got the same reply %q", other.Content)
+	}
+}
+
+func TestFakeProviderQueue(t *testing.T) {
+	ctx := context.Background()
+	provider := NewFakeProvider("first")
+	provider.Enqueue("second")
+
+	var contents []string
+	for _, prompt := range []string{"a", "b", "c"} {
+		resp, err := provider.CreateCompletion(ctx, CompletionRequest{Messages: []Message{{Role: RoleUser, Content: prompt}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents = append(contents, resp.Content)
+	}
+
+	if contents[0] != "first" || contents[1] != "second" || !strings.HasPrefix(contents[2], "fake-") {
+		t.Errorf("contents = %q, want the queued replies first", contents)
+	}
+
+	requests := provider.Requests()
+	if len(requests) != 3 || requests[2].Messages[0].Content != "c" {
+		t.Errorf("requests = %+v, want the 3 requests in order", requests)
+	}
+}
+
+func TestFakeProviderEmbedding(t *testing.T) {
+	ctx := context.Background()
+	input := []string{"moss on the roof", "Moss on the ROOF", "paint for the bathroom"}
+
+	first, err := NewFakeProvider().CreateEmbedding(ctx, EmbeddingRequest{Input: input})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := NewFakeProvider().CreateEmbedding(ctx, EmbeddingRequest{Input: input})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(first.Embeddings, second.Embeddings) {
+		t.Error("the same input got different embeddings")
+	}
+
+	if len(first.Embeddings) != len(input) {
+		t.Fatalf("got %d embeddings, want %d", len(first.Embeddings), len(input))
+	}
+	for i, embedding := range first.Embeddings {
+		if len(embedding) != fakeEmbeddingSize {
+			t.Errorf("embedding %d has %d dimensions, want %d", i, len(embedding), fakeEmbeddingSize)
+		}
+		if norm := CosineSimilarity(embedding, embedding); math.Abs(norm-1) > 1e-6 {
+			t.Errorf("embedding %d is not normalized: %f", i, norm)
+		}
+	}
+
+	if similarity := CosineSimilarity(first.Embeddings[0], first.Embeddings[1]); math.Abs(similarity-1) > 1e-6 {
+		t.Errorf("similarity ignoring case = %f, want 1", similarity)
+	}
+	if similarity := CosineSimilarity(first.Embeddings[0], first.Embeddings[2]); similarity >= 1-1e-6 {
+		t.Errorf("similarity of different texts = %f, want below 1", similarity)
+	}
+}
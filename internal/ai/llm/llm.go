@@ -0,0 +1,105 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog"
+	lr "github.com/rustoma/octo-pulse/internal/logger"
+)
+
+var logger *zerolog.Logger
+
+const (
+	ProviderOpenAI = "openai"
+	ProviderLocal  = "local"
+	ProviderFake   = "fake"
+)
+
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type CompletionRequest struct {
+	Model       string
+	Messages    []Message
+	Temperature float32
+	// JSONMode asks the model to reply with a single JSON object. The prompt
+	// itself must still describe the expected shape.
+	JSONMode bool
+}
+
+type Usage struct {
+	PromptTokens     int `json:"promptTokens"`
+	CompletionTokens int `json:"completionTokens"`
+	TotalTokens      int `json:"totalTokens"`
+}
+
+type CompletionResponse struct {
+	Content string
+	Model   string
+	Usage   Usage
+}
+
+type ImageRequest struct {
+	Model   string
+	Prompt  string
+	Size    string
+	Quality string
+}
+
+type ImageResponse struct {
+	B64JSON       string
+	RevisedPrompt string
+}
+
+type EmbeddingRequest struct {
+	Model string
+	Input []string
+}
+
+type EmbeddingResponse struct {
+	// Embeddings are in the order of the request input.
+	Embeddings [][]float32
+	Model      string
+	Usage      Usage
+}
+
+// Provider is implemented by every LLM backend the AI layer can talk to.
+type Provider interface {
+	Name() string
+	CreateCompletion(ctx context.Context, request CompletionRequest) (CompletionResponse, error)
+	CreateImage(ctx context.Context, request ImageRequest) (ImageResponse, error)
+	CreateEmbedding(ctx context.Context, request EmbeddingRequest) (EmbeddingResponse, error)
+}
+
+// NewProvider returns the provider selected by name. An empty name falls back to OpenAI.
+func NewProvider(name string) (Provider, error) {
+	switch name {
+	case "", ProviderOpenAI:
+		return NewOpenAIProvider(os.Getenv("AI_KEY"), os.Getenv("AI_BASE_URL")), nil
+	case ProviderLocal:
+		if os.Getenv("AI_BASE_URL") == "" {
+			return nil, fmt.Errorf("AI_BASE_URL is required for the %s provider", ProviderLocal)
+		}
+		return NewLocalProvider(os.Getenv("AI_BASE_URL"), os.Getenv("AI_KEY")), nil
+	case ProviderFake:
+		return NewFakeProvider(), nil
+	default:
+		return nil, fmt.Errorf("unknown AI provider: %s", name)
+	}
+}
+
+func init() {
+	l, logFile := lr.NewLogger()
+	defer logFile.Close()
+	logger = l
+}
@@ -0,0 +1,57 @@
+package llm
+
+import "testing"
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name      string
+		baseURL   string
+		wantName  string
+		wantError bool
+	}{
+		{name: "", wantName: ProviderOpenAI},
+		{name: ProviderOpenAI, baseURL: "http://localhost:8080/v1", wantName: ProviderOpenAI},
+		{name: ProviderLocal, baseURL: "http://localhost:11434/v1", wantName: ProviderLocal},
+		{name: ProviderLocal, wantError: true},
+		{name: ProviderFake, wantName: ProviderFake},
+		{name: "anthropic", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.baseURL, func(t *testing.T) {
+			t.Setenv("AI_KEY", "key")
+			t.Setenv("AI_BASE_URL", tt.baseURL)
+
+			provider, err := NewProvider(tt.name)
+
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("NewProvider(%q) = %s, want an error", tt.name, provider.Name())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewProvider(%q): %s", tt.name, err)
+			}
+			if provider.Name() != tt.wantName {
+				t.Errorf("NewProvider(%q) = %s, want %s", tt.name, provider.Name(), tt.wantName)
+			}
+		})
+	}
+}
+
+func TestNewProviderLocalSettings(t *testing.T) {
+	t.Setenv("AI_KEY", "secret")
+	t.Setenv("AI_BASE_URL", "http://localhost:11434/v1/")
+
+	provider, err := NewProvider(ProviderLocal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	local := provider.(*localProvider)
+	if local.baseURL != "http://localhost:11434/v1" || local.apiKey != "secret" {
+		t.Errorf("base URL, key = %q, %q, want the environment without the trailing slash", local.baseURL, local.apiKey)
+	}
+}
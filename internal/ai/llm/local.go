@@ -0,0 +1,245 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// localProvider talks to any server exposing the OpenAI chat completions API,
+// e.g. Ollama (http://localhost:11434/v1), llama.cpp or vLLM.
+type localProvider struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func NewLocalProvider(baseURL string, apiKey string) Provider {
+	return &localProvider{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: 10 * time.Minute},
+	}
+}
+
+type localChatRequest struct {
+	Model          string               `json:"model"`
+	Messages       []Message            `json:"messages"`
+	Temperature    float32              `json:"temperature"`
+	Stream         bool                 `json:"stream"`
+	ResponseFormat *localResponseFormat `json:"response_format,omitempty"`
+}
+
+type localResponseFormat struct {
+	Type string `json:"type"`
+}
+
+type localChatResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message Message `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+type localImageRequest struct {
+	Model          string `json:"model,omitempty"`
+	Prompt         string `json:"prompt"`
+	Size           string `json:"size,omitempty"`
+	Quality        string `json:"quality,omitempty"`
+	N              int    `json:"n"`
+	ResponseFormat string `json:"response_format"`
+}
+
+type localImageResponse struct {
+	Data []struct {
+		B64JSON       string `json:"b64_json"`
+		RevisedPrompt string `json:"revised_prompt"`
+	} `json:"data"`
+}
+
+type localEmbeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type localEmbeddingResponse struct {
+	Model string `json:"model"`
+	Data  []struct {
+		Embedding []float32 `json:"embedding"`
+		Index     int       `json:"index"`
+	} `json:"data"`
+	Usage struct {
+		PromptTokens int `json:"prompt_tokens"`
+		TotalTokens  int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+func (p *localProvider) Name() string {
+	return ProviderLocal
+}
+
+func (p *localProvider) CreateCompletion(ctx context.Context, request CompletionRequest) (CompletionResponse, error) {
+	var resp localChatResponse
+
+	chatRequest := localChatRequest{
+		Model:       request.Model,
+		Messages:    request.Messages,
+		Temperature: request.Temperature,
+	}
+
+	if request.JSONMode {
+		chatRequest.ResponseFormat = &localResponseFormat{Type: "json_object"}
+	}
+
+	err := p.post(ctx, "/chat/completions", chatRequest, &resp)
+	if err != nil {
+		return CompletionResponse{}, err
+	}
+
+	if len(resp.Choices) == 0 {
+		return CompletionResponse{}, errors.New("local provider returned no choices")
+	}
+
+	return CompletionResponse{
+		Content: resp.Choices[0].Message.Content,
+		Model:   resp.Model,
+		Usage: Usage{
+			PromptTokens:     resp.Usage.PromptTokens,
+			CompletionTokens: resp.Usage.CompletionTokens,
+			TotalTokens:      resp.Usage.TotalTokens,
+		},
+	}, nil
+}
+
+func (p *localProvider) CreateImage(ctx context.Context, request ImageRequest) (ImageResponse, error) {
+	var resp localImageResponse
+
+	err := p.post(ctx, "/images/generations", localImageRequest{
+		Model:          request.Model,
+		Prompt:         request.Prompt,
+		Size:           request.Size,
+		Quality:        request.Quality,
+		N:              1,
+		ResponseFormat: "b64_json",
+	}, &resp)
+	if err != nil {
+		return ImageResponse{}, err
+	}
+
+	if len(resp.Data) == 0 {
+		return ImageResponse{}, errors.New("local provider returned no images")
+	}
+
+	return ImageResponse{
+		B64JSON:       resp.Data[0].B64JSON,
+		RevisedPrompt: resp.Data[0].RevisedPrompt,
+	}, nil
+}
+
+func (p *localProvider) CreateEmbedding(ctx context.Context, request EmbeddingRequest) (EmbeddingResponse, error) {
+	var resp localEmbeddingResponse
+
+	err := p.post(ctx, "/embeddings", localEmbeddingRequest{
+		Model: request.Model,
+		Input: request.Input,
+	}, &resp)
+	if err != nil {
+		return EmbeddingResponse{}, err
+	}
+
+	if len(resp.Data) != len(request.Input) {
+		return EmbeddingResponse{}, fmt.Errorf("local provider returned %d embeddings for %d inputs", len(resp.Data), len(request.Input))
+	}
+
+	embeddings := make([][]float32, len(resp.Data))
+	for _, data := range resp.Data {
+		if data.Index < 0 || data.Index >= len(embeddings) {
+			return EmbeddingResponse{}, fmt.Errorf("local provider returned an embedding with index %d", data.Index)
+		}
+		embeddings[data.Index] = data.Embedding
+	}
+
+	return EmbeddingResponse{
+		Embeddings: embeddings,
+		Model:      resp.Model,
+		Usage: Usage{
+			PromptTokens: resp.Usage.PromptTokens,
+			TotalTokens:  resp.Usage.TotalTokens,
+		},
+	}, nil
+}
+
+func (p *localProvider) post(ctx context.Context, path string, body interface{}, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newLocalAPIError(resp, respBody)
+	}
+
+	return json.Unmarshal(respBody, out)
+}
+
+// localErrorResponse is the OpenAI error body most compatible servers return.
+type localErrorResponse struct {
+	Error struct {
+		Message string      `json:"message"`
+		Type    string      `json:"type"`
+		Code    interface{} `json:"code"`
+	} `json:"error"`
+}
+
+func newLocalAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		Provider:   ProviderLocal,
+		StatusCode: resp.StatusCode,
+		Message:    strings.TrimSpace(string(body)),
+		RetryAfter: parseRetryAfter(resp.Header),
+	}
+
+	var errResp localErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error.Message != "" {
+		apiErr.Message = errResp.Error.Message
+		if errResp.Error.Code != nil {
+			apiErr.Code = fmt.Sprint(errResp.Error.Code)
+		} else {
+			apiErr.Code = errResp.Error.Type
+		}
+	}
+
+	return apiErr
+}
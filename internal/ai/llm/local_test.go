@@ -0,0 +1,180 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// newLocalServer serves one endpoint of the local provider. It checks the
+// request and records its decoded body in got.
+func newLocalServer(t *testing.T, path string, got interface{}, status int, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != path {
+			t.Errorf("request = %s %s, want POST %s", r.Method, r.URL.Path, path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer key" {
+			t.Errorf("Authorization = %q, want the bearer key", auth)
+		}
+		if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+			t.Errorf("decode request: %s", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestLocalProviderCompletion(t *testing.T) {
+	var got localChatRequest
+	server := newLocalServer(t, "/v1/chat/completions", &got, http.StatusOK,
+		`{"model":"llama3","choices":[{"message":{"role":"assistant","content":"{\"title\":\"Roof\"}"}}],"usage":{"prompt_tokens":12,"completion_tokens":5,"total_tokens":17}}`)
+
+	provider := NewLocalProvider(server.URL+"/v1/", "key")
+	resp, err := provider.CreateCompletion(context.Background(), CompletionRequest{
+		Model:       "llama3",
+		Messages:    []Message{{Role: RoleUser, Content: "Title?"}},
+		Temperature: 0.5,
+		JSONMode:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Model != "llama3" || got.Stream || got.Temperature != 0.5 || len(got.Messages) != 1 || got.Messages[0].Content != "Title?" {
+		t.Errorf("request = %+v, want the completion request", got)
+	}
+	if got.ResponseFormat == nil || got.ResponseFormat.Type != "json_object" {
+		t.Errorf("response format = %+v, want json_object", got.ResponseFormat)
+	}
+
+	want := CompletionResponse{
+		Content: `{"title":"Roof"}`,
+		Model:   "llama3",
+		Usage:   Usage{PromptTokens: 12, CompletionTokens: 5, TotalTokens: 17},
+	}
+	if resp != want {
+		t.Errorf("response = %+v, want %+v", resp, want)
+	}
+}
+
+func TestLocalProviderCompletionWithoutChoices(t *testing.T) {
+	var got localChatRequest
+	server := newLocalServer(t, "/chat/completions", &got, http.StatusOK, `{"model":"llama3","choices":[]}`)
+
+	_, err := NewLocalProvider(server.URL, "key").CreateCompletion(context.Background(), CompletionRequest{Model: "llama3"})
+	if err == nil {
+		t.Error("got no error, want one for a response without choices")
+	}
+	if got.ResponseFormat != nil {
+		t.Errorf("response format = %+v, want none without JSON mode", got.ResponseFormat)
+	}
+}
+
+func TestLocalProviderImage(t *testing.T) {
+	var got localImageRequest
+	server := newLocalServer(t, "/images/generations", &got, http.StatusOK,
+		`{"data":[{"b64_json":"aW1hZ2U=","revised_prompt":"A clean roof"}]}`)
+
+	resp, err := NewLocalProvider(server.URL, "key").CreateImage(context.Background(), ImageRequest{
+		Model:  "sdxl",
+		Prompt: "A roof",
+		Size:   "1024x1024",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := localImageRequest{Model: "sdxl", Prompt: "A roof", Size: "1024x1024", N: 1, ResponseFormat: "b64_json"}
+	if got != want {
+		t.Errorf("request = %+v, want %+v", got, want)
+	}
+	if resp.B64JSON != "aW1hZ2U=" || resp.RevisedPrompt != "A clean roof" {
+		t.Errorf("response = %+v, want the first image", resp)
+	}
+}
+
+func TestLocalProviderEmbedding(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		want      [][]float32
+		wantError bool
+	}{
+		{
+			name: "ordered by index",
+			body: `{"model":"nomic","data":[{"embedding":[0,1],"index":1},{"embedding":[1,0],"index":0}],"usage":{"prompt_tokens":4,"total_tokens":4}}`,
+			want: [][]float32{{1, 0}, {0, 1}},
+		},
+		{
+			name:      "missing embedding",
+			body:      `{"model":"nomic","data":[{"embedding":[1,0],"index":0}]}`,
+			wantError: true,
+		},
+		{
+			name:      "index out of range",
+			body:      `{"model":"nomic","data":[{"embedding":[1,0],"index":0},{"embedding":[0,1],"index":2}]}`,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got localEmbeddingRequest
+			server := newLocalServer(t, "/embeddings", &got, http.StatusOK, tt.body)
+
+			resp, err := NewLocalProvider(server.URL, "key").CreateEmbedding(context.Background(), EmbeddingRequest{
+				Model: "nomic",
+				Input: []string{"roof", "gutter"},
+			})
+
+			if !reflect.DeepEqual(got.Input, []string{"roof", "gutter"}) {
+				t.Errorf("input = %q, want both texts", got.Input)
+			}
+
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("embeddings = %v, want an error", resp.Embeddings)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(resp.Embeddings, tt.want) {
+				t.Errorf("embeddings = %v, want %v", resp.Embeddings, tt.want)
+			}
+			if resp.Usage.PromptTokens != 4 || resp.Usage.TotalTokens != 4 {
+				t.Errorf("usage = %+v, want 4 tokens", resp.Usage)
+			}
+		})
+	}
+}
+
+func TestLocalProviderAPIError(t *testing.T) {
+	var got localChatRequest
+	server := newLocalServer(t, "/chat/completions", &got, http.StatusNotFound,
+		`{"error":{"message":"model \"llama9\" not found","type":"invalid_request_error","code":null}}`)
+
+	_, err := NewLocalProvider(server.URL, "key").CreateCompletion(context.Background(), CompletionRequest{Model: "llama9"})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error = %v, want an APIError", err)
+	}
+
+	want := APIError{Provider: ProviderLocal, StatusCode: http.StatusNotFound, Code: "invalid_request_error", Message: `model "llama9" not found`}
+	if *apiErr != want {
+		t.Errorf("error = %+v, want %+v", *apiErr, want)
+	}
+}
@@ -0,0 +1,193 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+type openAIProvider struct {
+	client *openai.Client
+}
+
+// NewOpenAIProvider talks to the OpenAI API, or to baseURL when it is not empty.
+func NewOpenAIProvider(apiKey string, baseURL string) Provider {
+	config := openai.DefaultConfig(apiKey)
+	if baseURL != "" {
+		config.BaseURL = baseURL
+	}
+	config.HTTPClient = &http.Client{Transport: headerRecorder{base: http.DefaultTransport}}
+
+	return &openAIProvider{
+		client: openai.NewClientWithConfig(config),
+	}
+}
+
+type responseHeaderKey struct{}
+
+// headerRecorder keeps the response headers for the caller, since the openai
+// client drops them on errors and Retry-After is needed to back off properly.
+type headerRecorder struct {
+	base http.RoundTripper
+}
+
+func (t headerRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if header, ok := req.Context().Value(responseHeaderKey{}).(*http.Header); ok {
+		*header = resp.Header
+	}
+
+	return resp, nil
+}
+
+func withHeaderRecorder(ctx context.Context) (context.Context, *http.Header) {
+	header := make(http.Header)
+	return context.WithValue(ctx, responseHeaderKey{}, &header), &header
+}
+
+// toAPIError converts the errors of the openai client to *APIError.
+func toAPIError(err error, header http.Header) error {
+	var openAIErr *openai.APIError
+	if errors.As(err, &openAIErr) {
+		apiErr := &APIError{
+			Provider:   ProviderOpenAI,
+			StatusCode: openAIErr.HTTPStatusCode,
+			Code:       openAIErr.Type,
+			Message:    openAIErr.Message,
+			RetryAfter: parseRetryAfter(header),
+		}
+		if openAIErr.Code != nil {
+			apiErr.Code = fmt.Sprint(openAIErr.Code)
+		}
+		return apiErr
+	}
+
+	var requestErr *openai.RequestError
+	if errors.As(err, &requestErr) {
+		message := http.StatusText(requestErr.HTTPStatusCode)
+		if requestErr.Err != nil {
+			message = requestErr.Err.Error()
+		}
+		return &APIError{
+			Provider:   ProviderOpenAI,
+			StatusCode: requestErr.HTTPStatusCode,
+			Message:    message,
+			RetryAfter: parseRetryAfter(header),
+		}
+	}
+
+	return err
+}
+
+func (p *openAIProvider) Name() string {
+	return ProviderOpenAI
+}
+
+func (p *openAIProvider) CreateCompletion(ctx context.Context, request CompletionRequest) (CompletionResponse, error) {
+	messages := make([]openai.ChatCompletionMessage, 0, len(request.Messages))
+	for _, message := range request.Messages {
+		messages = append(messages, openai.ChatCompletionMessage{
+			Role:    message.Role,
+			Content: message.Content,
+		})
+	}
+
+	chatRequest := openai.ChatCompletionRequest{
+		Model:       request.Model,
+		Messages:    messages,
+		Temperature: request.Temperature,
+	}
+
+	if request.JSONMode {
+		chatRequest.ResponseFormat = &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject}
+	}
+
+	ctx, header := withHeaderRecorder(ctx)
+	resp, err := p.client.CreateChatCompletion(ctx, chatRequest)
+	if err != nil {
+		return CompletionResponse{}, toAPIError(err, *header)
+	}
+
+	if len(resp.Choices) == 0 {
+		return CompletionResponse{}, errors.New("openai returned no choices")
+	}
+
+	return CompletionResponse{
+		Content: resp.Choices[0].Message.Content,
+		Model:   resp.Model,
+		Usage: Usage{
+			PromptTokens:     resp.Usage.PromptTokens,
+			CompletionTokens: resp.Usage.CompletionTokens,
+			TotalTokens:      resp.Usage.TotalTokens,
+		},
+	}, nil
+}
+
+func (p *openAIProvider) CreateImage(ctx context.Context, request ImageRequest) (ImageResponse, error) {
+	ctx, header := withHeaderRecorder(ctx)
+	resp, err := p.client.CreateImage(ctx, openai.ImageRequest{
+		Model:          request.Model,
+		Prompt:         request.Prompt,
+		Size:           request.Size,
+		Quality:        request.Quality,
+		N:              1,
+		ResponseFormat: openai.CreateImageResponseFormatB64JSON,
+	})
+	if err != nil {
+		return ImageResponse{}, toAPIError(err, *header)
+	}
+
+	if len(resp.Data) == 0 {
+		return ImageResponse{}, errors.New("openai returned no images")
+	}
+
+	return ImageResponse{
+		B64JSON:       resp.Data[0].B64JSON,
+		RevisedPrompt: resp.Data[0].RevisedPrompt,
+	}, nil
+}
+
+func (p *openAIProvider) CreateEmbedding(ctx context.Context, request EmbeddingRequest) (EmbeddingResponse, error) {
+	// The openai client only knows the embedding models it enumerates.
+	var model openai.EmbeddingModel
+	if err := model.UnmarshalText([]byte(request.Model)); err != nil || model == openai.Unknown {
+		return EmbeddingResponse{}, fmt.Errorf("unsupported openai embedding model: %s", request.Model)
+	}
+
+	ctx, header := withHeaderRecorder(ctx)
+	resp, err := p.client.CreateEmbeddings(ctx, openai.EmbeddingRequestStrings{
+		Model: model,
+		Input: request.Input,
+	})
+	if err != nil {
+		return EmbeddingResponse{}, toAPIError(err, *header)
+	}
+
+	if len(resp.Data) != len(request.Input) {
+		return EmbeddingResponse{}, fmt.Errorf("openai returned %d embeddings for %d inputs", len(resp.Data), len(request.Input))
+	}
+
+	embeddings := make([][]float32, len(resp.Data))
+	for _, data := range resp.Data {
+		if data.Index < 0 || data.Index >= len(embeddings) {
+			return EmbeddingResponse{}, fmt.Errorf("openai returned an embedding with index %d", data.Index)
+		}
+		embeddings[data.Index] = data.Embedding
+	}
+
+	return EmbeddingResponse{
+		Embeddings: embeddings,
+		Model:      request.Model,
+		Usage: Usage{
+			PromptTokens: resp.Usage.PromptTokens,
+			TotalTokens:  resp.Usage.TotalTokens,
+		},
+	}, nil
+}
@@ -0,0 +1,178 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+type RetryPolicy struct {
+	// MaxAttempts is the number of calls made for a single request, including the first one.
+	MaxAttempts int
+	// BaseDelay is the backoff before the second attempt. It doubles with every attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff. When the provider asks to wait longer than
+	// MaxDelay the error is returned instead, so the task queue can retry later.
+	MaxDelay time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   2 * time.Second,
+	MaxDelay:    time.Minute,
+}
+
+// backoff returns the delay before the given retry (1 for the first one) using
+// exponential backoff with full jitter.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.MaxDelay
+	if shift := retry - 1; shift < 30 {
+		if exp := p.BaseDelay << shift; exp > 0 && exp < p.MaxDelay {
+			delay = exp
+		}
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	jitterMu.Lock()
+	defer jitterMu.Unlock()
+
+	return time.Duration(jitter.Int63n(int64(delay)) + 1)
+}
+
+var (
+	jitterMu sync.Mutex
+	jitter   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// resilientProvider retries retryable errors of the wrapped provider and stops
+// calling it while the breaker is open.
+type resilientProvider struct {
+	provider Provider
+	policy   RetryPolicy
+	breaker  *Breaker
+}
+
+// WithRetry wraps provider so failed calls are retried according to policy. The
+// breaker is optional; when given it is shared by every call of the provider.
+func WithRetry(provider Provider, policy RetryPolicy, breaker *Breaker) Provider {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+
+	return &resilientProvider{
+		provider: provider,
+		policy:   policy,
+		breaker:  breaker,
+	}
+}
+
+func (p *resilientProvider) Name() string {
+	return p.provider.Name()
+}
+
+func (p *resilientProvider) CreateCompletion(ctx context.Context, request CompletionRequest) (CompletionResponse, error) {
+	var resp CompletionResponse
+
+	err := p.do(ctx, func() error {
+		var err error
+		resp, err = p.provider.CreateCompletion(ctx, request)
+		return err
+	})
+
+	return resp, err
+}
+
+func (p *resilientProvider) CreateImage(ctx context.Context, request ImageRequest) (ImageResponse, error) {
+	var resp ImageResponse
+
+	err := p.do(ctx, func() error {
+		var err error
+		resp, err = p.provider.CreateImage(ctx, request)
+		return err
+	})
+
+	return resp, err
+}
+
+func (p *resilientProvider) CreateEmbedding(ctx context.Context, request EmbeddingRequest) (EmbeddingResponse, error) {
+	var resp EmbeddingResponse
+
+	err := p.do(ctx, func() error {
+		var err error
+		resp, err = p.provider.CreateEmbedding(ctx, request)
+		return err
+	})
+
+	return resp, err
+}
+
+func (p *resilientProvider) do(ctx context.Context, call func() error) error {
+	for attempt := 1; ; attempt++ {
+		if p.breaker != nil {
+			if err := p.breaker.Allow(); err != nil {
+				return err
+			}
+		}
+
+		err := call()
+
+		// The caller gave up, which says nothing about the health of the provider.
+		if err != nil && ctx.Err() != nil {
+			if p.breaker != nil {
+				p.breaker.Abandon()
+			}
+			return ctx.Err()
+		}
+
+		if p.breaker != nil {
+			p.breaker.Record(err)
+		}
+
+		if err == nil {
+			return nil
+		}
+
+		if !IsRetryable(err) {
+			logger.Err(err).Msgf("%s request failed with a non-retryable %s error", p.Name(), Classify(err))
+			return err
+		}
+
+		if attempt >= p.policy.MaxAttempts {
+			logger.Err(err).Msgf("%s request failed after %d attempts", p.Name(), attempt)
+			return err
+		}
+
+		delay := p.policy.backoff(attempt)
+		if retryAfter := retryAfterOf(err); retryAfter > 0 {
+			if retryAfter > p.policy.MaxDelay {
+				logger.Err(err).Msgf("%s asked to retry in %s, giving up", p.Name(), retryAfter)
+				return err
+			}
+			delay = retryAfter
+		}
+
+		logger.Warn().Err(err).Msgf("%s request failed (%s), attempt %d of %d, retrying in %s", p.Name(), Classify(err), attempt, p.policy.MaxAttempts, delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func retryAfterOf(err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RetryAfter
+	}
+
+	return 0
+}
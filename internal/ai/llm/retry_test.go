@@ -0,0 +1,289 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubResponse is one reply of the stub server. A zero status is a successful
+// completion.
+type stubResponse struct {
+	status int
+	header map[string]string
+	body   string
+}
+
+// stubServer answers the chat completions of the local provider with the
+// responses in order, repeating the last one.
+type stubServer struct {
+	*httptest.Server
+	mu        sync.Mutex
+	responses []stubResponse
+	calls     int
+}
+
+func newStubServer(t *testing.T, responses ...stubResponse) *stubServer {
+	s := &stubServer{responses: responses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		response := s.responses[len(s.responses)-1]
+		if s.calls < len(s.responses) {
+			response = s.responses[s.calls]
+		}
+		s.calls++
+		s.mu.Unlock()
+
+		for name, value := range response.header {
+			w.Header().Set(name, value)
+		}
+
+		if response.status == 0 {
+			fmt.Fprint(w, `{"model":"stub","choices":[{"message":{"role":"assistant","content":"ok"}}]}`)
+			return
+		}
+
+		w.WriteHeader(response.status)
+		fmt.Fprint(w, response.body)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *stubServer) Calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls
+}
+
+var testPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Millisecond,
+	MaxDelay:    100 * time.Millisecond,
+}
+
+func complete(provider Provider) (CompletionResponse, error) {
+	return provider.CreateCompletion(context.Background(), CompletionRequest{
+		Model:    "stub",
+		Messages: []Message{{Role: RoleUser, Content: "hello"}},
+	})
+}
+
+func TestWithRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []stubResponse
+		wantCalls int
+		wantKind  ErrorKind
+		wantErr   bool
+		// minElapsed is the least time the call must take, e.g. to honor
+		// Retry-After.
+		minElapsed time.Duration
+	}{
+		{
+			name:      "succeeds after unavailable responses",
+			responses: []stubResponse{{status: 503}, {status: 502}, {}},
+			wantCalls: 3,
+		},
+		{
+			name:      "gives up after the last attempt",
+			responses: []stubResponse{{status: 500}},
+			wantCalls: 3,
+			wantErr:   true,
+			wantKind:  ErrorUnavailable,
+		},
+		{
+			name:      "does not retry invalid requests",
+			responses: []stubResponse{{status: 400, body: `{"error":{"message":"unknown model","type":"invalid_request_error"}}`}},
+			wantCalls: 1,
+			wantErr:   true,
+			wantKind:  ErrorInvalidRequest,
+		},
+		{
+			name:      "does not retry prompts too long for the model",
+			responses: []stubResponse{{status: 400, body: `{"error":{"message":"too long","code":"context_length_exceeded"}}`}},
+			wantCalls: 1,
+			wantErr:   true,
+			wantKind:  ErrorContextLength,
+		},
+		{
+			name:      "does not retry auth errors",
+			responses: []stubResponse{{status: 401}},
+			wantCalls: 1,
+			wantErr:   true,
+			wantKind:  ErrorAuth,
+		},
+		{
+			name:       "waits as long as retry-after-ms asks",
+			responses:  []stubResponse{{status: 429, header: map[string]string{"retry-after-ms": "60"}}, {}},
+			wantCalls:  2,
+			minElapsed: 60 * time.Millisecond,
+		},
+		{
+			name:      "gives up when Retry-After is longer than the max delay",
+			responses: []stubResponse{{status: 429, header: map[string]string{"Retry-After": "120"}}, {}},
+			wantCalls: 1,
+			wantErr:   true,
+			wantKind:  ErrorRateLimit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newStubServer(t, tt.responses...)
+			provider := WithRetry(NewLocalProvider(server.URL, ""), testPolicy, nil)
+
+			start := time.Now()
+			resp, err := complete(provider)
+			elapsed := time.Since(start)
+
+			if calls := server.Calls(); calls != tt.wantCalls {
+				t.Errorf("server got %d calls, want %d", calls, tt.wantCalls)
+			}
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if kind := Classify(err); kind != tt.wantKind {
+					t.Errorf("error kind = %s, want %s", kind, tt.wantKind)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Content != "ok" {
+				t.Errorf("content = %q, want ok", resp.Content)
+			}
+			if elapsed < tt.minElapsed {
+				t.Errorf("took %s, want at least %s", elapsed, tt.minElapsed)
+			}
+		})
+	}
+}
+
+func TestWithRetryStopsAtOpenBreaker(t *testing.T) {
+	server := newStubServer(t, stubResponse{status: 503})
+	breaker := NewBreaker(2, time.Hour)
+	provider := WithRetry(NewLocalProvider(server.URL, ""), testPolicy, breaker)
+
+	_, err := complete(provider)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+	if calls := server.Calls(); calls != 2 {
+		t.Errorf("server got %d calls before the breaker opened, want 2", calls)
+	}
+
+	_, err = complete(provider)
+	var circuitErr *CircuitOpenError
+	if !errors.As(err, &circuitErr) || circuitErr.RetryIn <= 0 {
+		t.Fatalf("err = %v, want a CircuitOpenError with the remaining cooldown", err)
+	}
+	if calls := server.Calls(); calls != 2 {
+		t.Errorf("the open breaker let %d calls through", calls-2)
+	}
+}
+
+func TestWithRetryCanceled(t *testing.T) {
+	server := newStubServer(t, stubResponse{status: 429, header: map[string]string{"retry-after-ms": "50"}})
+	breaker := NewBreaker(2, time.Hour)
+	provider := WithRetry(NewLocalProvider(server.URL, ""), RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: time.Second}, breaker)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := provider.CreateCompletion(ctx, CompletionRequest{Model: "stub"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if calls := server.Calls(); calls != 1 {
+		t.Errorf("server got %d calls, want 1", calls)
+	}
+	if state := breaker.State(); state != BreakerClosed {
+		t.Errorf("breaker is %s, want closed", state)
+	}
+}
+
+func TestBackoffBounds(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: 10 * time.Millisecond, MaxDelay: 100 * time.Millisecond}
+
+	tests := []struct {
+		retry   int
+		ceiling time.Duration
+	}{
+		{1, 10 * time.Millisecond},
+		{2, 20 * time.Millisecond},
+		{3, 40 * time.Millisecond},
+		{4, 80 * time.Millisecond},
+		{5, 100 * time.Millisecond},
+		{40, 100 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		var longest time.Duration
+		for i := 0; i < 500; i++ {
+			delay := policy.backoff(tt.retry)
+			if delay <= 0 || delay > tt.ceiling {
+				t.Fatalf("backoff(%d) = %s, want in (0, %s]", tt.retry, delay, tt.ceiling)
+			}
+			if delay > longest {
+				longest = delay
+			}
+		}
+
+		// Full jitter spreads the delays over the whole range.
+		if longest < tt.ceiling/2 {
+			t.Errorf("backoff(%d) never exceeded %s in 500 draws, want up to %s", tt.retry, longest, tt.ceiling)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   time.Duration
+		// approx allows the HTTP date, whose delay depends on the clock, to
+		// be off by a second.
+		approx bool
+	}{
+		{"none", nil, 0, false},
+		{"seconds", map[string]string{"Retry-After": "3"}, 3 * time.Second, false},
+		{"milliseconds take precedence", map[string]string{"Retry-After": "3", "retry-after-ms": "1500"}, 1500 * time.Millisecond, false},
+		{"zero", map[string]string{"Retry-After": "0"}, 0, false},
+		{"garbage", map[string]string{"Retry-After": "soon"}, 0, false},
+		{"http date", map[string]string{"Retry-After": time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)}, 10 * time.Second, true},
+		{"http date in the past", map[string]string{"Retry-After": time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for name, value := range tt.header {
+				header.Set(name, value)
+			}
+
+			got := parseRetryAfter(header)
+			if tt.approx {
+				if got < tt.want-time.Second || got > tt.want {
+					t.Errorf("parseRetryAfter = %s, want about %s", got, tt.want)
+				}
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("parseRetryAfter = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
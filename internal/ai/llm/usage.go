@@ -0,0 +1,72 @@
+package llm
+
+import (
+	"sort"
+	"sync"
+)
+
+// ModelUsage is the token usage accumulated for a single model.
+type ModelUsage struct {
+	Model string `json:"model"`
+	Usage
+}
+
+// Meter accumulates token usage per model. Create one meter per unit of work
+// (e.g. a task run) so concurrent workers never share counters. It is safe
+// for concurrent use.
+type Meter struct {
+	mu    sync.Mutex
+	usage map[string]Usage
+}
+
+func NewMeter() *Meter {
+	return &Meter{usage: make(map[string]Usage)}
+}
+
+// Add records the usage of a single completion. A nil meter ignores the call.
+func (m *Meter) Add(model string, usage Usage) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current := m.usage[model]
+	m.usage[model] = Usage{
+		PromptTokens:     current.PromptTokens + usage.PromptTokens,
+		CompletionTokens: current.CompletionTokens + usage.CompletionTokens,
+		TotalTokens:      current.TotalTokens + usage.TotalTokens,
+	}
+}
+
+// ImageUsageModel is the model the images of the given size are recorded
+// under. Images are priced per size, not per token.
+func ImageUsageModel(model string, size string) string {
+	return model + "/" + size
+}
+
+// AddImage records one generated image as a single completion token of
+// ImageUsageModel, so the completion price of that model is the price of 1000
+// images. A nil meter ignores the call.
+func (m *Meter) AddImage(model string, size string) {
+	m.Add(ImageUsageModel(model, size), Usage{CompletionTokens: 1, TotalTokens: 1})
+}
+
+// Usage returns the accumulated usage per model, sorted by model name.
+func (m *Meter) Usage() []ModelUsage {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	usage := make([]ModelUsage, 0, len(m.usage))
+	for model, modelUsage := range m.usage {
+		usage = append(usage, ModelUsage{Model: model, Usage: modelUsage})
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Model < usage[j].Model })
+
+	return usage
+}
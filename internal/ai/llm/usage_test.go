@@ -0,0 +1,30 @@
+package llm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMeter(t *testing.T) {
+	meter := NewMeter()
+	meter.Add("gpt-4", Usage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15})
+	meter.Add("gpt-4", Usage{PromptTokens: 1, CompletionTokens: 2, TotalTokens: 3})
+	meter.AddImage("dall-e-3", "1024x1024")
+	meter.AddImage("dall-e-3", "1024x1024")
+	meter.AddImage("dall-e-3", "1792x1024")
+
+	want := []ModelUsage{
+		{Model: "dall-e-3/1024x1024", Usage: Usage{CompletionTokens: 2, TotalTokens: 2}},
+		{Model: "dall-e-3/1792x1024", Usage: Usage{CompletionTokens: 1, TotalTokens: 1}},
+		{Model: "gpt-4", Usage: Usage{PromptTokens: 11, CompletionTokens: 7, TotalTokens: 18}},
+	}
+	if usage := meter.Usage(); !reflect.DeepEqual(usage, want) {
+		t.Errorf("usage = %+v, want %+v", usage, want)
+	}
+
+	var nilMeter *Meter
+	nilMeter.AddImage("dall-e-3", "1024x1024")
+	if usage := nilMeter.Usage(); usage != nil {
+		t.Errorf("usage of a nil meter = %+v, want nil", usage)
+	}
+}
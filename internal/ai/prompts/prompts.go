@@ -0,0 +1,267 @@
+package prompts
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/rs/zerolog"
+	"github.com/rustoma/octo-pulse/internal/language"
+	lr "github.com/rustoma/octo-pulse/internal/logger"
+	"github.com/rustoma/octo-pulse/internal/models"
+	"github.com/rustoma/octo-pulse/internal/storage"
+)
+
+var logger *zerolog.Logger
+
+//go:embed templates/*/*.tmpl
+var embeddedTemplates embed.FS
+
+// FallbackLanguage is the language whose templates are used when no template
+// exists in the requested language. Its templates take the target language name
+// as the Language variable, so they work for any language.
+const FallbackLanguage = "en"
+
+const (
+	Agenda            = "agenda"
+	Summary           = "summary"
+	Introduction      = "introduction"
+	SectionH2         = "section_h2"
+	SectionH3         = "section_h3"
+	CorrectGrammar    = "correct_grammar"
+	AssignCategory    = "assign_category"
+	Thumbnail         = "thumbnail"
+	SeoMetadata       = "seo_metadata"
+	Faq               = "faq"
+	TranslateMetadata = "translate_metadata"
+	TranslateHtml     = "translate_html"
+)
+
+const (
+	SourceEmbedded = "embedded"
+	SourceDisk     = "disk"
+	SourceDatabase = "database"
+)
+
+// Version identifies the exact template that rendered a prompt.
+type Version struct {
+	Name     string `json:"name"`
+	Version  int    `json:"version"`
+	Source   string `json:"source"`
+	Language string `json:"language"`
+}
+
+type Registry interface {
+	Render(ctx context.Context, name string, domainId int, language string, data interface{}) (string, Version, error)
+}
+
+type fileTemplate struct {
+	version int
+	source  string
+	body    string
+}
+
+// fileKey scopes file templates. Global templates are keyed by language, domain
+// overrides by domain only since they are written in the language of the domain.
+type fileKey struct {
+	domainId int
+	language string
+}
+
+type registry struct {
+	store storage.PromptTemplateStore
+	// files holds the newest file template per scope and name
+	files map[fileKey]map[string]fileTemplate
+}
+
+var fileNamePattern = regexp.MustCompile(`^([a-z0-9_]+)\.v(\d+)\.tmpl$`)
+
+// NewRegistry resolves templates from the database, from dir and from the
+// templates embedded in the binary, domain overrides first, see resolve. Files
+// in dir are named <name>.v<version>.tmpl and grouped by language code in
+// dir/<language>/; files placed directly in dir are treated as
+// language.Default. Domain overrides live in dir/domains/<domainId>/. Both
+// store and dir are optional.
+func NewRegistry(store storage.PromptTemplateStore, dir string) (Registry, error) {
+	r := &registry{
+		store: store,
+		files: make(map[fileKey]map[string]fileTemplate),
+	}
+
+	if err := r.loadLanguageDirs(embeddedTemplates, "templates", SourceEmbedded); err != nil {
+		return nil, err
+	}
+
+	if dir == "" {
+		return r, nil
+	}
+
+	if err := r.loadFiles(os.DirFS(dir), ".", fileKey{language: language.Default}, SourceDisk); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadLanguageDirs(os.DirFS(dir), ".", SourceDisk); err != nil {
+		return nil, err
+	}
+
+	domainDirs, err := os.ReadDir(filepath.Join(dir, "domains"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	for _, domainDir := range domainDirs {
+		if !domainDir.IsDir() {
+			continue
+		}
+
+		domainId, err := strconv.Atoi(domainDir.Name())
+		if err != nil {
+			logger.Warn().Msgf("Skipping prompt directory %s: not a domain id", domainDir.Name())
+			continue
+		}
+
+		if err := r.loadFiles(os.DirFS(dir), path.Join("domains", domainDir.Name()), fileKey{domainId: domainId}, SourceDisk); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+func (r *registry) loadLanguageDirs(fsys fs.FS, dir string, source string) error {
+	for _, code := range language.Codes() {
+		_, err := fs.Stat(fsys, path.Join(dir, code))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := r.loadFiles(fsys, path.Join(dir, code), fileKey{language: code}, source); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *registry) loadFiles(fsys fs.FS, dir string, key fileKey, source string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.Atoi(match[2])
+		if err != nil {
+			return err
+		}
+
+		body, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+
+		if err := Validate(match[1], string(body)); err != nil {
+			return fmt.Errorf("prompt template %s: %w", entry.Name(), err)
+		}
+
+		if r.files[key] == nil {
+			r.files[key] = make(map[string]fileTemplate)
+		}
+
+		if current, ok := r.files[key][match[1]]; ok && current.version > version {
+			continue
+		}
+
+		r.files[key][match[1]] = fileTemplate{version: version, source: source, body: string(body)}
+	}
+
+	return nil
+}
+
+func (r *registry) Render(ctx context.Context, name string, domainId int, language string, data interface{}) (string, Version, error) {
+	body, version, err := r.resolve(ctx, name, domainId, language)
+	if err != nil {
+		return "", Version{}, err
+	}
+
+	tmpl, err := parse(name, body)
+	if err != nil {
+		return "", Version{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", Version{}, fmt.Errorf("cannot render prompt %s v%d: %w", name, version.Version, err)
+	}
+
+	return strings.TrimSpace(buf.String()), version, nil
+}
+
+// resolve picks the most specific template: the database override of the
+// domain, the file override of the domain, the global database template, the
+// file of the language and finally the file of FallbackLanguage.
+func (r *registry) resolve(ctx context.Context, name string, domainId int, lang string) (string, Version, error) {
+	var dbTemplate *models.PromptTemplate
+
+	if r.store != nil {
+		var err error
+		dbTemplate, err = r.store.GetActivePromptTemplate(ctx, name, domainId, lang)
+		if err != nil {
+			return "", Version{}, err
+		}
+	}
+
+	if dbTemplate != nil && dbTemplate.DomainId != nil {
+		return dbTemplate.Body, Version{Name: name, Version: dbTemplate.Version, Source: SourceDatabase, Language: dbTemplate.Language}, nil
+	}
+
+	if file, ok := r.files[fileKey{domainId: domainId}][name]; ok {
+		return file.body, Version{Name: name, Version: file.version, Source: file.source, Language: lang}, nil
+	}
+
+	if dbTemplate != nil {
+		return dbTemplate.Body, Version{Name: name, Version: dbTemplate.Version, Source: SourceDatabase, Language: dbTemplate.Language}, nil
+	}
+
+	for _, key := range []fileKey{{language: lang}, {language: FallbackLanguage}} {
+		if file, ok := r.files[key][name]; ok {
+			return file.body, Version{Name: name, Version: file.version, Source: file.source, Language: key.language}, nil
+		}
+	}
+
+	return "", Version{}, fmt.Errorf("prompt template %s (%s) not found", name, lang)
+}
+
+// Validate checks that body is a valid text/template.
+func Validate(name string, body string) error {
+	_, err := parse(name, body)
+	return err
+}
+
+func parse(name string, body string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Parse(body)
+}
+
+func init() {
+	l, logFile := lr.NewLogger()
+	defer logFile.Close()
+	logger = l
+}
@@ -0,0 +1,88 @@
+package prompts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rustoma/octo-pulse/internal/models"
+	"github.com/rustoma/octo-pulse/internal/storage"
+)
+
+// stubTemplateStore returns the active template the way the database does: the
+// domain override first, then the global one.
+type stubTemplateStore struct {
+	storage.PromptTemplateStore
+	templates []*models.PromptTemplate
+}
+
+func (s *stubTemplateStore) GetActivePromptTemplate(ctx context.Context, name string, domainId int, language string) (*models.PromptTemplate, error) {
+	var global *models.PromptTemplate
+	for _, template := range s.templates {
+		if template.Name != name || template.Language != language {
+			continue
+		}
+		if template.DomainId != nil && *template.DomainId == domainId {
+			return template, nil
+		}
+		if template.DomainId == nil {
+			global = template
+		}
+	}
+
+	return global, nil
+}
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	domainDir := filepath.Join(dir, "domains", "7")
+	if err := os.MkdirAll(domainDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(domainDir, "agenda.v3.tmpl"), []byte("disk domain 7"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	domainId := 7
+	otherDomainId := 8
+	global := &models.PromptTemplate{Name: Agenda, Version: 5, Language: "en", Body: "db global"}
+	override := &models.PromptTemplate{Name: Agenda, Version: 2, Language: "en", Body: "db domain 8", DomainId: &otherDomainId}
+
+	tests := []struct {
+		name       string
+		templates  []*models.PromptTemplate
+		domainId   int
+		language   string
+		wantBody   string
+		wantSource string
+	}{
+		{"database domain override wins", []*models.PromptTemplate{global, override}, 8, "en", "db domain 8", SourceDatabase},
+		{"disk domain override beats database global", []*models.PromptTemplate{global, override}, domainId, "en", "disk domain 7", SourceDisk},
+		{"database global beats language file", []*models.PromptTemplate{global}, 9, "en", "db global", SourceDatabase},
+		{"language file without database", nil, 9, "en", "", SourceEmbedded},
+		{"fallback language file", nil, 9, "xx", "", SourceEmbedded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRegistry(&stubTemplateStore{templates: tt.templates}, dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			body, version, err := r.(*registry).resolve(context.Background(), Agenda, tt.domainId, tt.language)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if version.Source != tt.wantSource {
+				t.Errorf("source = %s, want %s", version.Source, tt.wantSource)
+			}
+
+			if tt.wantBody != "" && body != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Schema is the subset of JSON Schema needed to describe model replies.
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+}
+
+// For derives the schema of v, which must be a struct, a pointer to a struct
+// or any other JSON encodable type. Property names follow the json tags and
+// every field without omitempty is required. Constraints are read from the
+// jsonschema tag, e.g. `jsonschema:"description=Heading,minItems=1,maxItems=4"`.
+// Supported keys: description (without commas), enum (values separated by |),
+// minItems, maxItems, minLength, maxLength, minimum and maximum.
+func For(v interface{}) (*Schema, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, fmt.Errorf("schema: cannot derive schema of nil")
+	}
+
+	return forType(t, make(map[reflect.Type]bool))
+}
+
+func forType(t reflect.Type, visiting map[reflect.Type]bool) (*Schema, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := forType(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("schema: map keys of %s must be strings", t)
+		}
+		return &Schema{Type: "object"}, nil
+	case reflect.Struct:
+		return forStruct(t, visiting)
+	}
+
+	return nil, fmt.Errorf("schema: unsupported type %s", t)
+}
+
+func forStruct(t reflect.Type, visiting map[reflect.Type]bool) (*Schema, error) {
+	if visiting[t] {
+		return nil, fmt.Errorf("schema: recursive type %s is not supported", t)
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	noAdditionalProperties := false
+	s := &Schema{
+		Type:                 "object",
+		Properties:           make(map[string]*Schema),
+		Required:             make([]string, 0),
+		AdditionalProperties: &noAdditionalProperties,
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, omitEmpty := jsonName(field)
+		if name == "-" {
+			continue
+		}
+
+		property, err := forType(field.Type, visiting)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := applyTag(property, field.Tag.Get("jsonschema")); err != nil {
+			return nil, fmt.Errorf("schema: field %s.%s: %w", t.Name(), field.Name, err)
+		}
+
+		s.Properties[name] = property
+		if !omitEmpty {
+			s.Required = append(s.Required, name)
+		}
+	}
+
+	return s, nil
+}
+
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		return field.Name, false
+	}
+
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = field.Name
+	}
+
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			return name, true
+		}
+	}
+
+	return name, false
+}
+
+func applyTag(s *Schema, tag string) error {
+	if tag == "" {
+		return nil
+	}
+
+	for _, option := range strings.Split(tag, ",") {
+		key, value, found := strings.Cut(option, "=")
+		if !found {
+			return fmt.Errorf("invalid jsonschema option %q", option)
+		}
+
+		switch key {
+		case "description":
+			s.Description = value
+		case "enum":
+			s.Enum = strings.Split(value, "|")
+		case "minItems", "maxItems", "minLength", "maxLength":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", key, err)
+			}
+			switch key {
+			case "minItems":
+				s.MinItems = &n
+			case "maxItems":
+				s.MaxItems = &n
+			case "minLength":
+				s.MinLength = &n
+			case "maxLength":
+				s.MaxLength = &n
+			}
+		case "minimum", "maximum":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", key, err)
+			}
+			if key == "minimum" {
+				s.Minimum = &n
+			} else {
+				s.Maximum = &n
+			}
+		default:
+			return fmt.Errorf("unknown jsonschema option %q", key)
+		}
+	}
+
+	return nil
+}
+
+// String returns the schema as compact JSON, ready to be put in a prompt.
+func (s *Schema) String() string {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "{}"
+	}
+
+	return string(b)
+}
@@ -0,0 +1,175 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ValidationError lists every place where a document does not match a schema.
+// Its message is meant to be sent back to the model, so it names the JSON path
+// of each problem.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Problems, "; ")
+}
+
+// Validate checks that data is a single JSON value matching the schema.
+func (s *Schema) Validate(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return &ValidationError{Problems: []string{fmt.Sprintf("$: invalid JSON: %v", err)}}
+	}
+
+	if decoder.More() {
+		return &ValidationError{Problems: []string{"$: expected a single JSON value"}}
+	}
+
+	var problems []string
+	s.validate("$", value, &problems)
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+
+	return nil
+}
+
+func (s *Schema) validate(path string, value interface{}, problems *[]string) {
+	addProblem := func(format string, args ...interface{}) {
+		*problems = append(*problems, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			addProblem("expected object, got %s", typeName(value))
+			return
+		}
+
+		for _, name := range s.Required {
+			if _, ok := object[name]; !ok {
+				addProblem("missing required property %q", name)
+			}
+		}
+
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			property, ok := s.Properties[name]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					addProblem("unexpected property %q", name)
+				}
+				continue
+			}
+			property.validate(path+"."+name, object[name], problems)
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			addProblem("expected array, got %s", typeName(value))
+			return
+		}
+
+		if s.MinItems != nil && len(array) < *s.MinItems {
+			addProblem("expected at least %d items, got %d", *s.MinItems, len(array))
+		}
+		if s.MaxItems != nil && len(array) > *s.MaxItems {
+			addProblem("expected at most %d items, got %d", *s.MaxItems, len(array))
+		}
+
+		if s.Items != nil {
+			for i, item := range array {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, problems)
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			addProblem("expected string, got %s", typeName(value))
+			return
+		}
+
+		if s.MinLength != nil && len([]rune(str)) < *s.MinLength {
+			addProblem("expected at least %d characters", *s.MinLength)
+		}
+
+		if s.MaxLength != nil && len([]rune(str)) > *s.MaxLength {
+			addProblem("expected at most %d characters, got %d", *s.MaxLength, len([]rune(str)))
+		}
+
+		if len(s.Enum) > 0 && !contains(s.Enum, str) {
+			addProblem("expected one of %s, got %q", strings.Join(s.Enum, ", "), str)
+		}
+	case "integer", "number":
+		number, ok := value.(json.Number)
+		if !ok {
+			addProblem("expected %s, got %s", s.Type, typeName(value))
+			return
+		}
+
+		f, err := number.Float64()
+		if err != nil {
+			addProblem("invalid number %s", number)
+			return
+		}
+
+		if s.Type == "integer" && f != math.Trunc(f) {
+			addProblem("expected integer, got %s", number)
+		}
+		if s.Minimum != nil && f < *s.Minimum {
+			addProblem("expected a value >= %v, got %s", *s.Minimum, number)
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			addProblem("expected a value <= %v, got %s", *s.Maximum, number)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			addProblem("expected boolean, got %s", typeName(value))
+		}
+	}
+}
+
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+
+	return fmt.Sprintf("%T", value)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
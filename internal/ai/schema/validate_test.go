@@ -0,0 +1,155 @@
+package schema
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testFaqItem struct {
+	Question string `json:"question" jsonschema:"minLength=5"`
+	Answer   string `json:"answer"`
+}
+
+type testReply struct {
+	Title    string        `json:"title" jsonschema:"description=Title of the article,maxLength=20"`
+	Tone     string        `json:"tone" jsonschema:"enum=formal|casual"`
+	Score    int           `json:"score" jsonschema:"minimum=1,maximum=10"`
+	Draft    bool          `json:"draft"`
+	Faq      []testFaqItem `json:"faq" jsonschema:"minItems=1,maxItems=2"`
+	Keywords []string      `json:"keywords,omitempty"`
+	internal string
+}
+
+func TestFor(t *testing.T) {
+	s, err := For(&testReply{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Type != "object" || *s.AdditionalProperties {
+		t.Fatalf("schema is %s, want a closed object", s)
+	}
+
+	wantRequired := []string{"title", "tone", "score", "draft", "faq"}
+	if !reflect.DeepEqual(s.Required, wantRequired) {
+		t.Errorf("required = %v, want %v", s.Required, wantRequired)
+	}
+
+	if _, ok := s.Properties["internal"]; ok {
+		t.Error("unexported field in the schema")
+	}
+
+	if title := s.Properties["title"]; title.Description != "Title of the article" || *title.MaxLength != 20 {
+		t.Errorf("title = %s, want the description and maxLength of the tag", title)
+	}
+
+	if faq := s.Properties["faq"]; faq.Type != "array" || faq.Items.Type != "object" || *faq.MinItems != 1 {
+		t.Errorf("faq = %s, want an array of objects with minItems", faq)
+	}
+}
+
+func TestForErrors(t *testing.T) {
+	type badTag struct {
+		Name string `jsonschema:"minLength=many"`
+	}
+	type unknownTag struct {
+		Name string `jsonschema:"pattern=x"`
+	}
+	type node struct {
+		Children []node
+	}
+
+	for _, v := range []interface{}{nil, badTag{}, unknownTag{}, node{}, map[int]string{}, make(chan int)} {
+		if _, err := For(v); err == nil {
+			t.Errorf("For(%T) did not fail", v)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s, err := For(testReply{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		json         string
+		wantProblems []string
+	}{
+		{
+			name: "valid",
+			json: `{"title":"Roof cleaning","tone":"casual","score":7,"draft":false,"faq":[{"question":"How often?","answer":"Yearly."}]}`,
+		},
+		{
+			name: "optional property",
+			json: `{"title":"Roof","tone":"formal","score":1,"draft":true,"faq":[{"question":"How often?","answer":""}],"keywords":["roof"]}`,
+		},
+		{
+			name:         "invalid JSON",
+			json:         `{"title":`,
+			wantProblems: []string{"$: invalid JSON: unexpected EOF"},
+		},
+		{
+			name:         "trailing value",
+			json:         `{} {}`,
+			wantProblems: []string{"$: expected a single JSON value"},
+		},
+		{
+			name:         "not an object",
+			json:         `[]`,
+			wantProblems: []string{"$: expected object, got array"},
+		},
+		{
+			name: "every problem with its path",
+			json: `{"title":"A title much longer than twenty","tone":"angry","score":2.5,"draft":"no","faq":[{"question":"Why","answer":1},{"question":"How often?"},{}],"extra":1}`,
+			wantProblems: []string{
+				`$.draft: expected boolean, got string`,
+				`$: unexpected property "extra"`,
+				`$.faq: expected at most 2 items, got 3`,
+				`$.faq[0].answer: expected string, got number`,
+				`$.faq[0].question: expected at least 5 characters`,
+				`$.faq[1]: missing required property "answer"`,
+				`$.faq[2]: missing required property "question"`,
+				`$.faq[2]: missing required property "answer"`,
+				`$.score: expected integer, got 2.5`,
+				`$.title: expected at most 20 characters, got 31`,
+				`$.tone: expected one of formal, casual, got "angry"`,
+			},
+		},
+		{
+			name: "missing properties and bounds",
+			json: `{"score":11,"faq":[]}`,
+			wantProblems: []string{
+				`$: missing required property "title"`,
+				`$: missing required property "tone"`,
+				`$: missing required property "draft"`,
+				`$.faq: expected at least 1 items, got 0`,
+				`$.score: expected a value <= 10, got 11`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Validate([]byte(tt.json))
+
+			if tt.wantProblems == nil {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Validate = %v, want a ValidationError", err)
+			}
+
+			if !reflect.DeepEqual(validationErr.Problems, tt.wantProblems) {
+				t.Errorf("problems =\n%q\nwant\n%q", validationErr.Problems, tt.wantProblems)
+			}
+		})
+	}
+}
@@ -29,6 +29,8 @@ func HandleErrorStatus(err interface{}) int {
 	switch err.(type) {
 	case e.Unauthorized:
 		return http.StatusUnauthorized
+	case e.Forbidden:
+		return http.StatusForbidden
 	case e.BadRequest, e.NotFound:
 		return http.StatusBadRequest
 	default:
@@ -16,12 +16,14 @@ import (
 
 type ArticleController struct {
 	articleService services.ArticleService
+	authService    services.AuthService
 	articleTasks   tasks.ArticleTasker
 }
 
-func NewArticleController(articleService services.ArticleService, articleTasks tasks.ArticleTasker) *ArticleController {
+func NewArticleController(articleService services.ArticleService, authService services.AuthService, articleTasks tasks.ArticleTasker) *ArticleController {
 	return &ArticleController{
 		articleService: articleService,
+		authService:    authService,
 		articleTasks:   articleTasks,
 	}
 }
@@ -33,7 +35,7 @@ func (c *ArticleController) HandleGenerateArticles(w http.ResponseWriter, r *htt
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	err = c.articleTasks.NewGenerateArticlesTask(request.DomainId, request.NumberOfArticles, request.QuestionCategoryId, request.ImagesCategory)
+	err = c.articleTasks.NewGenerateArticlesTask(r.Context(), request.DomainId, request.NumberOfArticles, request.QuestionCategoryId, request.ImagesCategory, request.GenerateThumbnails)
 
 	if err != nil {
 		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
@@ -56,7 +58,28 @@ func (c *ArticleController) HandleGenerateDescritption(w http.ResponseWriter, r
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	err = c.articleTasks.NewGenerateDescriptionTask(pageId, request.QuestionId)
+	article, err := c.articleService.GetArticle(r.Context(), pageId)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	if article == nil {
+		return api.Error{Err: "article not found", Status: http.StatusNotFound}
+	}
+
+	if article.Status != models.ArticleStatusGenerating {
+		user, err := c.authService.GetRequestUser(r.Context(), r)
+		if err != nil {
+			return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+		}
+
+		_, err = c.articleService.ChangeArticleStatus(r.Context(), pageId, &dto.ArticleStatusRequest{Status: models.ArticleStatusGenerating}, user)
+		if err != nil {
+			return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+		}
+	}
+
+	err = c.articleTasks.NewGenerateDescriptionTask(r.Context(), pageId, request.QuestionId, request.Fresh)
 
 	if err != nil {
 		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
@@ -65,7 +88,45 @@ func (c *ArticleController) HandleGenerateDescritption(w http.ResponseWriter, r
 	return api.WriteJSON(w, http.StatusOK, "")
 }
 
+// HandleGetArticles lists the articles in any status, or in the one given by
+// the status parameter.
 func (c *ArticleController) HandleGetArticles(w http.ResponseWriter, r *http.Request) error {
+	filters, err := articlesFilters(r)
+	if err != nil {
+		return err
+	}
+
+	filters.Status = r.URL.Query().Get("status")
+
+	articles, err := c.articleService.GetArticlesPage(r.Context(), filters)
+
+	if err != nil {
+		return api.Error{Err: "Cannot get articles", Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, articles)
+}
+
+// HandleGetPublicArticles lists the published articles. The status parameter
+// is ignored.
+func (c *ArticleController) HandleGetPublicArticles(w http.ResponseWriter, r *http.Request) error {
+	filters, err := articlesFilters(r)
+	if err != nil {
+		return err
+	}
+
+	filters.Status = models.ArticleStatusPublished
+
+	articles, err := c.articleService.GetArticlesPage(r.Context(), filters)
+
+	if err != nil {
+		return api.Error{Err: "Cannot get articles", Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, articles)
+}
+
+func articlesFilters(r *http.Request) (*storage.GetArticlesFilters, error) {
 	domainIdParam := r.URL.Query().Get("domainId")
 	categoryIdParam := r.URL.Query().Get("categoryId")
 	limitParam := r.URL.Query().Get("limit")
@@ -73,13 +134,14 @@ func (c *ArticleController) HandleGetArticles(w http.ResponseWriter, r *http.Req
 	featuredParam := r.URL.Query().Get("featured")
 	slug := r.URL.Query().Get("slug")
 	excludeBodyParam := r.URL.Query().Get("excludeBody")
+	cursorParam := r.URL.Query().Get("cursor")
 
 	var filters storage.GetArticlesFilters
 
 	if domainIdParam != "" {
 		domainId, err := strconv.Atoi(domainIdParam)
 		if err != nil {
-			return api.Error{Err: "bad request - domainId wrong format", Status: http.StatusBadRequest}
+			return nil, api.Error{Err: "bad request - domainId wrong format", Status: http.StatusBadRequest}
 		}
 
 		filters.DomainId = domainId
@@ -88,7 +150,7 @@ func (c *ArticleController) HandleGetArticles(w http.ResponseWriter, r *http.Req
 	if categoryIdParam != "" {
 		categoryId, err := strconv.Atoi(categoryIdParam)
 		if err != nil {
-			return api.Error{Err: "bad request - categoryId wrong format", Status: http.StatusBadRequest}
+			return nil, api.Error{Err: "bad request - categoryId wrong format", Status: http.StatusBadRequest}
 		}
 
 		filters.CategoryId = categoryId
@@ -97,7 +159,7 @@ func (c *ArticleController) HandleGetArticles(w http.ResponseWriter, r *http.Req
 	if limitParam != "" {
 		limit, err := strconv.Atoi(limitParam)
 		if err != nil {
-			return api.Error{Err: "bad request - limit wrong format", Status: http.StatusBadRequest}
+			return nil, api.Error{Err: "bad request - limit wrong format", Status: http.StatusBadRequest}
 		}
 
 		filters.Limit = limit
@@ -106,12 +168,21 @@ func (c *ArticleController) HandleGetArticles(w http.ResponseWriter, r *http.Req
 	if offsetParam != "" {
 		offset, err := strconv.Atoi(offsetParam)
 		if err != nil {
-			return api.Error{Err: "bad request - offset wrong format", Status: http.StatusBadRequest}
+			return nil, api.Error{Err: "bad request - offset wrong format", Status: http.StatusBadRequest}
 		}
 
 		filters.Offset = offset
 	}
 
+	if cursorParam != "" {
+		cursor, err := storage.DecodeCursor(cursorParam)
+		if err != nil {
+			return nil, api.Error{Err: "bad request - cursor wrong format", Status: http.StatusBadRequest}
+		}
+
+		filters.Cursor = cursor
+	}
+
 	if featuredParam == "true" || featuredParam == "false" {
 		filters.Featured = featuredParam
 	}
@@ -124,13 +195,7 @@ func (c *ArticleController) HandleGetArticles(w http.ResponseWriter, r *http.Req
 		filters.Slug = slug
 	}
 
-	articles, err := c.articleService.GetArticles(&filters)
-
-	if err != nil {
-		return api.Error{Err: "Cannot get articles", Status: api.HandleErrorStatus(err)}
-	}
-
-	return api.WriteJSON(w, http.StatusOK, articles)
+	return &filters, nil
 }
 
 func (c *ArticleController) HandleGetArticle(w http.ResponseWriter, r *http.Request) error {
@@ -142,8 +207,25 @@ func (c *ArticleController) HandleGetArticle(w http.ResponseWriter, r *http.Requ
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	article, err := c.articleService.GetArticle(articleId)
+	article, err := c.articleService.GetArticle(r.Context(), articleId)
+
+	if err != nil {
+		return api.Error{Err: "Cannot get article", Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, article)
+}
+
+// HandleGetPublicArticle returns the article together with its FAQ and the
+// FAQPage structured data.
+func (c *ArticleController) HandleGetPublicArticle(w http.ResponseWriter, r *http.Request) error {
+	articleIdParam := chi.URLParam(r, "id")
+	articleId, err := strconv.Atoi(articleIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
 
+	article, err := c.articleService.GetPublicArticle(r.Context(), articleId)
 	if err != nil {
 		return api.Error{Err: "Cannot get article", Status: api.HandleErrorStatus(err)}
 	}
@@ -166,7 +248,12 @@ func (c *ArticleController) HandleUpdateArticle(w http.ResponseWriter, r *http.R
 		return api.Error{Err: err.Error(), Status: http.StatusBadRequest}
 	}
 
-	updatedArticle, err := c.articleService.UpdateArticle(articleId, article)
+	user, err := c.authService.GetRequestUser(r.Context(), r)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	updatedArticle, err := c.articleService.UpdateArticle(r.Context(), articleId, article, &models.ArticleRevisionAuthor{Source: models.ArticleRevisionSourceDashboard, UserId: &user.ID})
 	if err != nil {
 		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
 	}
@@ -182,7 +269,7 @@ func (c *ArticleController) HandleDeleteArticle(w http.ResponseWriter, r *http.R
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	article, err := c.articleService.DeleteArticle(articleId)
+	article, err := c.articleService.DeleteArticle(r.Context(), articleId)
 
 	if err != nil {
 		return api.Error{Err: fmt.Sprintf("cannot delete article with ID: %d , err: %+v\n", articleId, err), Status: api.HandleErrorStatus(err)}
@@ -191,6 +278,21 @@ func (c *ArticleController) HandleDeleteArticle(w http.ResponseWriter, r *http.R
 	return api.WriteJSON(w, http.StatusOK, article)
 }
 
+func (c *ArticleController) HandleGenerateSeo(w http.ResponseWriter, r *http.Request) error {
+	articleIdParam := chi.URLParam(r, "id")
+	articleId, err := strconv.Atoi(articleIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	err = c.articleTasks.NewGenerateSeoTask(r.Context(), articleId)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, "Generate SEO task created successfully")
+}
+
 func (c *ArticleController) HandleRemoveDuplicatesFromArticle(w http.ResponseWriter, r *http.Request) error {
 	articleIdParam := chi.URLParam(r, "id")
 	articleId, err := strconv.Atoi(articleIdParam)
@@ -198,7 +300,7 @@ func (c *ArticleController) HandleRemoveDuplicatesFromArticle(w http.ResponseWri
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	err = c.articleService.RemoveDuplicateHeadingsFromArticle(articleId)
+	err = c.articleService.RemoveDuplicateHeadingsFromArticle(r.Context(), articleId)
 	if err != nil {
 		return api.Error{Err: fmt.Sprintf("cannot remove duplicate from article with ID: %d , err: %+v\n", articleId, err), Status: api.HandleErrorStatus(err)}
 	}
@@ -214,10 +316,353 @@ func (c *ArticleController) HandleCreateArticle(w http.ResponseWriter, r *http.R
 		return api.Error{Err: err.Error(), Status: http.StatusBadRequest}
 	}
 
-	createdArticleId, err := c.articleService.CreateArticle(article)
+	createdArticleId, err := c.articleService.CreateArticle(r.Context(), article)
 	if err != nil {
 		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
 	}
 
 	return api.WriteJSON(w, http.StatusOK, fmt.Sprintf("Article with ID %d was created successfully", createdArticleId))
 }
+
+func (c *ArticleController) HandleGetArticlePromptVersions(w http.ResponseWriter, r *http.Request) error {
+	articleIdParam := chi.URLParam(r, "id")
+	articleId, err := strconv.Atoi(articleIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	versions, err := c.articleService.GetArticlePromptVersions(r.Context(), articleId)
+	if err != nil {
+		return api.Error{Err: "cannot get article prompt versions", Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, versions)
+}
+
+func (c *ArticleController) HandleGetArticleGenerationProgress(w http.ResponseWriter, r *http.Request) error {
+	articleIdParam := chi.URLParam(r, "id")
+	articleId, err := strconv.Atoi(articleIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	progress, err := c.articleService.GetArticleGenerationProgress(r.Context(), articleId)
+	if err != nil {
+		return api.Error{Err: "cannot get article generation progress", Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, progress)
+}
+
+func (c *ArticleController) HandleChangeArticleStatus(w http.ResponseWriter, r *http.Request) error {
+	var request dto.ArticleStatusRequest
+
+	articleIdParam := chi.URLParam(r, "id")
+	articleId, err := strconv.Atoi(articleIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	err = api.ReadJSON(w, r, &request)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: http.StatusBadRequest}
+	}
+
+	user, err := c.authService.GetRequestUser(r.Context(), r)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	article, err := c.articleService.ChangeArticleStatus(r.Context(), articleId, &request, user)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, article)
+}
+
+func (c *ArticleController) HandleGetArticleStatusTransitions(w http.ResponseWriter, r *http.Request) error {
+	articleIdParam := chi.URLParam(r, "id")
+	articleId, err := strconv.Atoi(articleIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	transitions, err := c.articleService.GetArticleStatusTransitions(r.Context(), articleId)
+	if err != nil {
+		return api.Error{Err: "cannot get article status transitions", Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, transitions)
+}
+
+func (c *ArticleController) HandleTranslateArticle(w http.ResponseWriter, r *http.Request) error {
+	articleIdParam := chi.URLParam(r, "id")
+	articleId, err := strconv.Atoi(articleIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	var request dto.TranslateArticleRequest
+
+	err = api.ReadJSON(w, r, &request)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: http.StatusBadRequest}
+	}
+
+	article, err := c.articleService.GetArticle(r.Context(), articleId)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	if article == nil {
+		return api.Error{Err: fmt.Sprintf("article with id %d not found", articleId), Status: http.StatusNotFound}
+	}
+
+	if request.DomainId == 0 || request.DomainId == article.DomainId {
+		return api.Error{Err: "translation needs a domain other than the domain of the article", Status: http.StatusBadRequest}
+	}
+
+	err = c.articleTasks.NewTranslateArticleTask(r.Context(), articleId, request.DomainId)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, "Translate article task created successfully")
+}
+
+func (c *ArticleController) HandleGetArticleTranslations(w http.ResponseWriter, r *http.Request) error {
+	articleIdParam := chi.URLParam(r, "id")
+	articleId, err := strconv.Atoi(articleIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	articles, err := c.articleService.GetArticleTranslations(r.Context(), articleId)
+	if err != nil {
+		return api.Error{Err: "cannot get article translations", Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, articles)
+}
+
+// HandleSearchPublicArticles searches the published articles of a domain.
+func (c *ArticleController) HandleSearchPublicArticles(w http.ResponseWriter, r *http.Request) error {
+	filters, err := searchArticlesFilters(r)
+	if err != nil {
+		return err
+	}
+
+	filters.Status = models.ArticleStatusPublished
+
+	results, err := c.articleService.SearchArticles(r.Context(), filters)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, results)
+}
+
+// HandleSearchArticles searches the articles of a domain in any status, or in
+// the one given by the status parameter.
+func (c *ArticleController) HandleSearchArticles(w http.ResponseWriter, r *http.Request) error {
+	filters, err := searchArticlesFilters(r)
+	if err != nil {
+		return err
+	}
+
+	filters.Status = r.URL.Query().Get("status")
+
+	results, err := c.articleService.SearchArticles(r.Context(), filters)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, results)
+}
+
+func searchArticlesFilters(r *http.Request) (*storage.SearchArticlesFilters, error) {
+	filters := &storage.SearchArticlesFilters{Query: r.URL.Query().Get("q")}
+
+	params := map[string]*int{
+		"domainId":   &filters.DomainId,
+		"categoryId": &filters.CategoryId,
+		"limit":      &filters.Limit,
+		"offset":     &filters.Offset,
+	}
+
+	for name, value := range params {
+		param := r.URL.Query().Get(name)
+		if param == "" {
+			continue
+		}
+
+		parsed, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, api.Error{Err: fmt.Sprintf("bad request - %s wrong format", name), Status: http.StatusBadRequest}
+		}
+
+		*value = parsed
+	}
+
+	return filters, nil
+}
+
+func (c *ArticleController) HandleGetDeletedArticles(w http.ResponseWriter, r *http.Request) error {
+	domainIdParam := r.URL.Query().Get("domainId")
+	limitParam := r.URL.Query().Get("limit")
+	offsetParam := r.URL.Query().Get("offset")
+
+	var filters storage.GetDeletedArticlesFilters
+
+	if domainIdParam != "" {
+		domainId, err := strconv.Atoi(domainIdParam)
+		if err != nil {
+			return api.Error{Err: "bad request - domainId wrong format", Status: http.StatusBadRequest}
+		}
+
+		filters.DomainId = domainId
+	}
+
+	if limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil {
+			return api.Error{Err: "bad request - limit wrong format", Status: http.StatusBadRequest}
+		}
+
+		filters.Limit = limit
+	}
+
+	if offsetParam != "" {
+		offset, err := strconv.Atoi(offsetParam)
+		if err != nil {
+			return api.Error{Err: "bad request - offset wrong format", Status: http.StatusBadRequest}
+		}
+
+		filters.Offset = offset
+	}
+
+	articles, err := c.articleService.GetDeletedArticles(r.Context(), &filters)
+	if err != nil {
+		return api.Error{Err: "cannot get deleted articles", Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, articles)
+}
+
+func (c *ArticleController) HandleRestoreArticle(w http.ResponseWriter, r *http.Request) error {
+	articleIdParam := chi.URLParam(r, "id")
+	articleId, err := strconv.Atoi(articleIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	restoredArticleId, err := c.articleService.RestoreArticle(r.Context(), articleId)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, restoredArticleId)
+}
+
+func (c *ArticleController) HandlePurgeArticle(w http.ResponseWriter, r *http.Request) error {
+	articleIdParam := chi.URLParam(r, "id")
+	articleId, err := strconv.Atoi(articleIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	purgedArticleId, err := c.articleService.PurgeArticle(r.Context(), articleId)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, purgedArticleId)
+}
+
+func (c *ArticleController) HandleGetArticleRevisions(w http.ResponseWriter, r *http.Request) error {
+	articleIdParam := chi.URLParam(r, "id")
+	articleId, err := strconv.Atoi(articleIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	revisions, err := c.articleService.GetArticleRevisions(r.Context(), articleId)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, revisions)
+}
+
+func (c *ArticleController) HandleGetArticleRevision(w http.ResponseWriter, r *http.Request) error {
+	articleIdParam := chi.URLParam(r, "id")
+	articleId, err := strconv.Atoi(articleIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	revisionIdParam := chi.URLParam(r, "revisionId")
+	revisionId, err := strconv.Atoi(revisionIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	revision, err := c.articleService.GetArticleRevision(r.Context(), articleId, revisionId)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, revision)
+}
+
+func (c *ArticleController) HandleDiffArticleRevisions(w http.ResponseWriter, r *http.Request) error {
+	articleIdParam := chi.URLParam(r, "id")
+	articleId, err := strconv.Atoi(articleIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	fromId, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if err != nil {
+		return api.Error{Err: "bad request - from wrong format", Status: http.StatusBadRequest}
+	}
+
+	toId, err := strconv.Atoi(r.URL.Query().Get("to"))
+	if err != nil {
+		return api.Error{Err: "bad request - to wrong format", Status: http.StatusBadRequest}
+	}
+
+	diff, err := c.articleService.DiffArticleRevisions(r.Context(), articleId, fromId, toId)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, diff)
+}
+
+func (c *ArticleController) HandleRestoreArticleRevision(w http.ResponseWriter, r *http.Request) error {
+	articleIdParam := chi.URLParam(r, "id")
+	articleId, err := strconv.Atoi(articleIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	revisionIdParam := chi.URLParam(r, "revisionId")
+	revisionId, err := strconv.Atoi(revisionIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	user, err := c.authService.GetRequestUser(r.Context(), r)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	article, err := c.articleService.RestoreArticleRevision(r.Context(), articleId, revisionId, user)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, article)
+}
@@ -34,7 +34,7 @@ func (c *AuthController) HandleLogin(w http.ResponseWriter, r *http.Request) err
 		return api.Error{Err: "bad login request", Status: http.StatusBadRequest}
 	}
 
-	authUser, cookie, err := c.authService.Login(userCredentials)
+	authUser, cookie, err := c.authService.Login(r.Context(), userCredentials)
 
 	if err != nil {
 		return api.Error{Err: "Cannot login", Status: api.HandleErrorStatus(err)}
@@ -54,7 +54,7 @@ func (c *AuthController) HandleLogout(w http.ResponseWriter, r *http.Request) er
 		return api.WriteJSON(w, http.StatusNoContent, "")
 	}
 
-	cookie, err := c.authService.Logout(logoutRequest)
+	cookie, err := c.authService.Logout(r.Context(), logoutRequest)
 
 	if err != nil {
 		if cookie != nil {
@@ -76,7 +76,7 @@ func (c *AuthController) HandleRefreshToken(w http.ResponseWriter, r *http.Reque
 		return api.Error{Err: "refresh token not found", Status: http.StatusBadRequest}
 	}
 
-	encodedJWT, err := c.authService.RefreshToken(refreshTokenRequest)
+	encodedJWT, err := c.authService.RefreshToken(r.Context(), refreshTokenRequest)
 
 	if err != nil {
 		return err
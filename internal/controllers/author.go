@@ -27,7 +27,7 @@ func (c *AuthorController) HandleGetAuthor(w http.ResponseWriter, r *http.Reques
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	category, err := c.authorService.GetAuthor(authorId)
+	category, err := c.authorService.GetAuthor(r.Context(), authorId)
 	if err != nil {
 		return api.Error{Err: "cannot get author", Status: api.HandleErrorStatus(err)}
 	}
@@ -36,7 +36,7 @@ func (c *AuthorController) HandleGetAuthor(w http.ResponseWriter, r *http.Reques
 }
 
 func (c *AuthorController) HandleGetAuthors(w http.ResponseWriter, r *http.Request) error {
-	categories, err := c.authorService.GetAuthors()
+	categories, err := c.authorService.GetAuthors(r.Context())
 	if err != nil {
 		return api.Error{Err: "cannot get authors", Status: api.HandleErrorStatus(err)}
 	}
@@ -53,7 +53,7 @@ func (c *AuthorController) HandleCreateAuthor(w http.ResponseWriter, r *http.Req
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	authorId, err := c.authorService.CreateAuthor(request)
+	authorId, err := c.authorService.CreateAuthor(r.Context(), request)
 	if err != nil {
 		logger.Err(err).Send()
 		return api.Error{Err: "cannot create domain", Status: api.HandleErrorStatus(err)}
@@ -76,7 +76,7 @@ func (c *AuthorController) HandleUpdateAuthor(w http.ResponseWriter, r *http.Req
 		return api.Error{Err: err.Error(), Status: http.StatusBadRequest}
 	}
 
-	updatedAuthor, err := c.authorService.UpdateAuthor(authorId, author)
+	updatedAuthor, err := c.authorService.UpdateAuthor(r.Context(), authorId, author)
 	if err != nil {
 		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
 	}
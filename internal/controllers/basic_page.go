@@ -29,7 +29,7 @@ func (c *BasicPageController) HandleGetBasicPage(w http.ResponseWriter, r *http.
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	page, err := c.basicPageService.GetBasicPage(pageId)
+	page, err := c.basicPageService.GetBasicPage(r.Context(), pageId)
 	if err != nil {
 		return api.Error{Err: "cannot get the page", Status: api.HandleErrorStatus(err)}
 	}
@@ -52,7 +52,7 @@ func (c *BasicPageController) HandleGetBasicPageBySlug(w http.ResponseWriter, r
 		filters.DomainId = domainId
 	}
 
-	pages, err := c.basicPageService.GetBasicPageBySlug(slug, &filters)
+	pages, err := c.basicPageService.GetBasicPageBySlug(r.Context(), slug, &filters)
 	if err != nil {
 		return api.Error{Err: "cannot get the page", Status: api.HandleErrorStatus(err)}
 	}
@@ -62,6 +62,9 @@ func (c *BasicPageController) HandleGetBasicPageBySlug(w http.ResponseWriter, r
 
 func (c *BasicPageController) HandleGetBasicPages(w http.ResponseWriter, r *http.Request) error {
 	domainIdParam := r.URL.Query().Get("domainId")
+	limitParam := r.URL.Query().Get("limit")
+	offsetParam := r.URL.Query().Get("offset")
+	cursorParam := r.URL.Query().Get("cursor")
 
 	var filters storage.GetBasicPagesFilters
 
@@ -74,7 +77,34 @@ func (c *BasicPageController) HandleGetBasicPages(w http.ResponseWriter, r *http
 		filters.DomainId = domainId
 	}
 
-	domains, err := c.basicPageService.GetBasicPages(&filters)
+	if limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil {
+			return api.Error{Err: "bad request - limit wrong format", Status: http.StatusBadRequest}
+		}
+
+		filters.Limit = limit
+	}
+
+	if offsetParam != "" {
+		offset, err := strconv.Atoi(offsetParam)
+		if err != nil {
+			return api.Error{Err: "bad request - offset wrong format", Status: http.StatusBadRequest}
+		}
+
+		filters.Offset = offset
+	}
+
+	if cursorParam != "" {
+		cursor, err := storage.DecodeCursor(cursorParam)
+		if err != nil {
+			return api.Error{Err: "bad request - cursor wrong format", Status: http.StatusBadRequest}
+		}
+
+		filters.Cursor = cursor
+	}
+
+	domains, err := c.basicPageService.GetBasicPagesPage(r.Context(), &filters)
 
 	if err != nil {
 		return api.Error{Err: "cannot get the basic pages", Status: api.HandleErrorStatus(err)}
@@ -92,7 +122,7 @@ func (c *BasicPageController) HandleCreateBasicPage(w http.ResponseWriter, r *ht
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	pageId, err := c.basicPageService.CreateBasicPage(request)
+	pageId, err := c.basicPageService.CreateBasicPage(r.Context(), request)
 	if err != nil {
 		logger.Err(err).Send()
 		return api.Error{Err: "cannot create basic page", Status: api.HandleErrorStatus(err)}
@@ -115,7 +145,7 @@ func (c *BasicPageController) HandleUpdateBasicPage(w http.ResponseWriter, r *ht
 		return api.Error{Err: err.Error(), Status: http.StatusBadRequest}
 	}
 
-	updatedBasicPage, err := c.basicPageService.UpdateBasicPage(basicPageId, basicPage)
+	updatedBasicPage, err := c.basicPageService.UpdateBasicPage(r.Context(), basicPageId, basicPage)
 	if err != nil {
 		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
 	}
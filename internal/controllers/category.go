@@ -23,6 +23,63 @@ func NewCategoryController(categoryService services.CategoryService) *CategoryCo
 	}
 }
 
+func (c *CategoryController) HandleGetCategoryAssignments(w http.ResponseWriter, r *http.Request) error {
+	domainIdParam := r.URL.Query().Get("domainId")
+	categoryIdParam := r.URL.Query().Get("categoryId")
+	limitParam := r.URL.Query().Get("limit")
+	offsetParam := r.URL.Query().Get("offset")
+	method := r.URL.Query().Get("method")
+
+	var filters storage.GetCategoryAssignmentsFilters
+
+	if domainIdParam != "" {
+		domainId, err := strconv.Atoi(domainIdParam)
+		if err != nil {
+			return api.Error{Err: "bad request - domainId wrong format", Status: http.StatusBadRequest}
+		}
+
+		filters.DomainId = domainId
+	}
+
+	if categoryIdParam != "" {
+		categoryId, err := strconv.Atoi(categoryIdParam)
+		if err != nil {
+			return api.Error{Err: "bad request - categoryId wrong format", Status: http.StatusBadRequest}
+		}
+
+		filters.CategoryId = categoryId
+	}
+
+	if limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil {
+			return api.Error{Err: "bad request - limit wrong format", Status: http.StatusBadRequest}
+		}
+
+		filters.Limit = limit
+	}
+
+	if offsetParam != "" {
+		offset, err := strconv.Atoi(offsetParam)
+		if err != nil {
+			return api.Error{Err: "bad request - offset wrong format", Status: http.StatusBadRequest}
+		}
+
+		filters.Offset = offset
+	}
+
+	if method != "" {
+		filters.Method = method
+	}
+
+	assignments, err := c.categoryService.GetCategoryAssignments(r.Context(), &filters)
+	if err != nil {
+		return api.Error{Err: "cannot get category assignments", Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, assignments)
+}
+
 func (c *CategoryController) HandleGetCategories(w http.ResponseWriter, r *http.Request) error {
 	slug := r.URL.Query().Get("slug")
 
@@ -32,7 +89,7 @@ func (c *CategoryController) HandleGetCategories(w http.ResponseWriter, r *http.
 		filters.Slug = slug
 	}
 
-	categories, err := c.categoryService.GetCategories(&filters)
+	categories, err := c.categoryService.GetCategories(r.Context(), &filters)
 
 	if err != nil {
 		return api.Error{Err: "cannot get categories", Status: api.HandleErrorStatus(err)}
@@ -49,7 +106,7 @@ func (c *CategoryController) HandleGetCategory(w http.ResponseWriter, r *http.Re
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	category, err := c.categoryService.GetCategory(categoryId)
+	category, err := c.categoryService.GetCategory(r.Context(), categoryId)
 
 	if err != nil {
 		return api.Error{Err: "cannot get category", Status: api.HandleErrorStatus(err)}
@@ -66,7 +123,7 @@ func (c *CategoryController) HandleGetDomainCategories(w http.ResponseWriter, r
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	domainCategories, err := c.categoryService.GetDomainCategories(domainId)
+	domainCategories, err := c.categoryService.GetDomainCategories(r.Context(), domainId)
 
 	if err != nil {
 		return api.Error{Err: "cannot get domain categories", Status: api.HandleErrorStatus(err)}
@@ -84,7 +141,7 @@ func (c *CategoryController) HandleCreateCategory(w http.ResponseWriter, r *http
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	categoryId, err := c.categoryService.CreateCategory(request)
+	categoryId, err := c.categoryService.CreateCategory(r.Context(), request)
 	if err != nil {
 		logger.Err(err).Send()
 		return api.Error{Err: "cannot create category", Status: api.HandleErrorStatus(err)}
@@ -102,7 +159,7 @@ func (c *CategoryController) HandleAssignCategoryToDomain(w http.ResponseWriter,
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	err = c.categoryService.AssignCategoryToDomain(request.CategoryId, request.DomainId)
+	err = c.categoryService.AssignCategoryToDomain(r.Context(), request.CategoryId, request.DomainId)
 	if err != nil {
 		logger.Err(err).Send()
 		return api.Error{Err: "cannot create category", Status: api.HandleErrorStatus(err)}
@@ -125,7 +182,7 @@ func (c *CategoryController) HandleUpdateCategory(w http.ResponseWriter, r *http
 		return api.Error{Err: err.Error(), Status: http.StatusBadRequest}
 	}
 
-	updatedCategory, err := c.categoryService.UpdateCategory(categoryId, category)
+	updatedCategory, err := c.categoryService.UpdateCategory(r.Context(), categoryId, category)
 	if err != nil {
 		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
 	}
@@ -22,7 +22,7 @@ func NewDomainController(domainService services.DomainService) *DomainController
 }
 
 func (c *DomainController) HandleGetDomains(w http.ResponseWriter, r *http.Request) error {
-	domains, err := c.domainService.GetDomains()
+	domains, err := c.domainService.GetDomains(r.Context())
 
 	if err != nil {
 		return api.Error{Err: "cannot get domains", Status: api.HandleErrorStatus(err)}
@@ -39,7 +39,7 @@ func (c *DomainController) HandleGetDomain(w http.ResponseWriter, r *http.Reques
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	domains, err := c.domainService.GetDomain(domainId)
+	domains, err := c.domainService.GetDomain(r.Context(), domainId)
 
 	if err != nil {
 		return api.Error{Err: "cannot get domain", Status: api.HandleErrorStatus(err)}
@@ -56,7 +56,7 @@ func (c *DomainController) HandleGetDomainPublicData(w http.ResponseWriter, r *h
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	domains, err := c.domainService.GetDomainPublicData(domainId)
+	domains, err := c.domainService.GetDomainPublicData(r.Context(), domainId)
 	if err != nil {
 		return api.Error{Err: "cannot get domain data", Status: api.HandleErrorStatus(err)}
 	}
@@ -73,7 +73,7 @@ func (c *DomainController) HandleCreateDomain(w http.ResponseWriter, r *http.Req
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	domainId, err := c.domainService.CreateDomain(request)
+	domainId, err := c.domainService.CreateDomain(r.Context(), request)
 	if err != nil {
 		logger.Err(err).Send()
 		return api.Error{Err: "cannot create domain", Status: api.HandleErrorStatus(err)}
@@ -96,7 +96,7 @@ func (c *DomainController) HandleUpdateDomain(w http.ResponseWriter, r *http.Req
 		return api.Error{Err: err.Error(), Status: http.StatusBadRequest}
 	}
 
-	updatedAuthor, err := c.domainService.UpdateDomain(domainId, domain)
+	updatedAuthor, err := c.domainService.UpdateDomain(r.Context(), domainId, domain)
 	if err != nil {
 		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
 	}
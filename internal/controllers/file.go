@@ -25,7 +25,7 @@ func (c *FileController) HandleCreateArticles(w http.ResponseWriter, r *http.Req
 		return api.Error{Err: "bad login request", Status: http.StatusBadRequest}
 	}
 
-	err = c.fileService.CreateArticles(request.Ids)
+	err = c.fileService.CreateArticles(r.Context(), request.Ids)
 	if err != nil {
 		return api.Error{Err: "cannot create article files", Status: api.HandleErrorStatus(err)}
 	}
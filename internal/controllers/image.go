@@ -46,6 +46,7 @@ func (c *ImageController) HandleGetImages(w http.ResponseWriter, r *http.Request
 	limitParam := r.URL.Query().Get("limit")
 	offsetParam := r.URL.Query().Get("offset")
 	pathParam := r.URL.Query().Get("path")
+	cursorParam := r.URL.Query().Get("cursor")
 
 	var filters storage.GetImagesFilters
 
@@ -67,6 +68,15 @@ func (c *ImageController) HandleGetImages(w http.ResponseWriter, r *http.Request
 		filters.Offset = offset
 	}
 
+	if cursorParam != "" {
+		cursor, err := storage.DecodeCursor(cursorParam)
+		if err != nil {
+			return api.Error{Err: "bad request - cursor wrong format", Status: http.StatusBadRequest}
+		}
+
+		filters.Cursor = cursor
+	}
+
 	if categoryIdParam != "" {
 		categoryId, err := strconv.Atoi(categoryIdParam)
 		if err != nil {
@@ -80,7 +90,7 @@ func (c *ImageController) HandleGetImages(w http.ResponseWriter, r *http.Request
 		filters.Path = pathParam
 	}
 
-	articles, err := c.imageService.GetImages(&filters)
+	articles, err := c.imageService.GetImagesPage(r.Context(), &filters)
 	if err != nil {
 		return api.Error{Err: "Cannot get images", Status: api.HandleErrorStatus(err)}
 	}
@@ -96,7 +106,7 @@ func (c *ImageController) HandleGetImage(w http.ResponseWriter, r *http.Request)
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	image, err := c.imageService.GetImage(imageId)
+	image, err := c.imageService.GetImage(r.Context(), imageId)
 	if err != nil {
 		return api.Error{Err: "Cannot get image", Status: api.HandleErrorStatus(err)}
 	}
@@ -112,7 +122,7 @@ func (c *ImageController) HandleGetImageCategory(w http.ResponseWriter, r *http.
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	category, err := c.imageService.GetImageCategory(categoryId)
+	category, err := c.imageService.GetImageCategory(r.Context(), categoryId)
 	if err != nil {
 		return api.Error{Err: "cannot get image category", Status: api.HandleErrorStatus(err)}
 	}
@@ -121,7 +131,7 @@ func (c *ImageController) HandleGetImageCategory(w http.ResponseWriter, r *http.
 }
 
 func (c *ImageController) HandleGetImageCategories(w http.ResponseWriter, r *http.Request) error {
-	categories, err := c.imageService.GetImageCategories()
+	categories, err := c.imageService.GetImageCategories(r.Context())
 	if err != nil {
 		return api.Error{Err: "Cannot get image categories", Status: api.HandleErrorStatus(err)}
 	}
@@ -148,7 +158,7 @@ func (c *ImageController) HandleUploadImage(w http.ResponseWriter, r *http.Reque
 	}
 	defer file.Close()
 
-	_, err = c.imageService.UploadImage(file, handler, categoryId)
+	_, err = c.imageService.UploadImage(r.Context(), file, handler, categoryId)
 	if err != nil {
 		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
 	}
@@ -165,7 +175,7 @@ func (c *ImageController) HandleCreateImageCategory(w http.ResponseWriter, r *ht
 		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
 	}
 
-	imageCategoryId, err := c.imageService.CreateImageCategory(request)
+	imageCategoryId, err := c.imageService.CreateImageCategory(r.Context(), request)
 	if err != nil {
 		logger.Err(err).Send()
 		return api.Error{Err: "cannot create image category", Status: api.HandleErrorStatus(err)}
@@ -188,7 +198,7 @@ func (c *ImageController) HandleUpdateImageCategory(w http.ResponseWriter, r *ht
 		return api.Error{Err: err.Error(), Status: http.StatusBadRequest}
 	}
 
-	updatedImageCategory, err := c.imageService.UpdateImageCategory(categoryId, category)
+	updatedImageCategory, err := c.imageService.UpdateImageCategory(r.Context(), categoryId, category)
 	if err != nil {
 		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
 	}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rustoma/octo-pulse/internal/api"
+	"github.com/rustoma/octo-pulse/internal/models"
+	"github.com/rustoma/octo-pulse/internal/services"
+	"github.com/rustoma/octo-pulse/internal/storage"
+)
+
+type PromptTemplateController struct {
+	promptTemplateService services.PromptTemplateService
+}
+
+func NewPromptTemplateController(promptTemplateService services.PromptTemplateService) *PromptTemplateController {
+	return &PromptTemplateController{
+		promptTemplateService,
+	}
+}
+
+func (c *PromptTemplateController) HandleGetPromptTemplates(w http.ResponseWriter, r *http.Request) error {
+	nameParam := r.URL.Query().Get("name")
+	domainIdParam := r.URL.Query().Get("domainId")
+	languageParam := r.URL.Query().Get("language")
+
+	var filters storage.GetPromptTemplatesFilters
+
+	if domainIdParam != "" {
+		domainId, err := strconv.Atoi(domainIdParam)
+		if err != nil {
+			return api.Error{Err: "bad request - domainId wrong format", Status: http.StatusBadRequest}
+		}
+
+		filters.DomainId = domainId
+	}
+
+	if nameParam != "" {
+		filters.Name = nameParam
+	}
+
+	if languageParam != "" {
+		filters.Language = languageParam
+	}
+
+	templates, err := c.promptTemplateService.GetPromptTemplates(r.Context(), &filters)
+	if err != nil {
+		return api.Error{Err: "cannot get prompt templates", Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, templates)
+}
+
+func (c *PromptTemplateController) HandleGetPromptTemplate(w http.ResponseWriter, r *http.Request) error {
+	templateIdParam := chi.URLParam(r, "id")
+	templateId, err := strconv.Atoi(templateIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	template, err := c.promptTemplateService.GetPromptTemplate(r.Context(), templateId)
+	if err != nil {
+		return api.Error{Err: "cannot get prompt template", Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, template)
+}
+
+func (c *PromptTemplateController) HandleCreatePromptTemplate(w http.ResponseWriter, r *http.Request) error {
+	var request *models.PromptTemplate
+
+	err := api.ReadJSON(w, r, &request)
+	if err != nil {
+		logger.Err(err).Msg("Bad request")
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	templateId, err := c.promptTemplateService.CreatePromptTemplate(r.Context(), request)
+	if err != nil {
+		logger.Err(err).Send()
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, fmt.Sprintf("Prompt template with ID %d was created successfully", templateId))
+}
+
+func (c *PromptTemplateController) HandleActivatePromptTemplate(w http.ResponseWriter, r *http.Request) error {
+	templateIdParam := chi.URLParam(r, "id")
+	templateId, err := strconv.Atoi(templateIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	err = c.promptTemplateService.ActivatePromptTemplate(r.Context(), templateId)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, fmt.Sprintf("Prompt template with ID %d was activated successfully", templateId))
+}
@@ -17,7 +17,7 @@ func NewScrapperController(scrapperService services.ScrapperService) *ScrapperCo
 }
 
 func (c *ScrapperController) HandleGetQuestionCategories(w http.ResponseWriter, r *http.Request) error {
-	categories, err := c.scrapperService.GetQuestionCategories()
+	categories, err := c.scrapperService.GetQuestionCategories(r.Context())
 
 	if err != nil {
 		return api.Error{Err: "cannot get question categories", Status: api.HandleErrorStatus(err)}
@@ -0,0 +1,150 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rustoma/octo-pulse/internal/api"
+	"github.com/rustoma/octo-pulse/internal/models"
+	"github.com/rustoma/octo-pulse/internal/services"
+	"github.com/rustoma/octo-pulse/internal/storage"
+)
+
+const usageDateLayout = "2006-01-02"
+
+type UsageController struct {
+	usageService services.UsageService
+}
+
+func NewUsageController(usageService services.UsageService) *UsageController {
+	return &UsageController{
+		usageService: usageService,
+	}
+}
+
+func (c *UsageController) HandleGetUsagePerDomain(w http.ResponseWriter, r *http.Request) error {
+	filters, err := getUsageFilters(r)
+	if err != nil {
+		return err
+	}
+
+	usage, err := c.usageService.GetUsagePerDomain(r.Context(), filters)
+	if err != nil {
+		return api.Error{Err: "cannot get usage per domain", Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, usage)
+}
+
+func (c *UsageController) HandleGetUsagePerDay(w http.ResponseWriter, r *http.Request) error {
+	filters, err := getUsageFilters(r)
+	if err != nil {
+		return err
+	}
+
+	usage, err := c.usageService.GetUsagePerDay(r.Context(), filters)
+	if err != nil {
+		return api.Error{Err: "cannot get usage per day", Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, usage)
+}
+
+func (c *UsageController) HandleGetUsagePerBatch(w http.ResponseWriter, r *http.Request) error {
+	filters, err := getUsageFilters(r)
+	if err != nil {
+		return err
+	}
+
+	usage, err := c.usageService.GetUsagePerBatch(r.Context(), filters)
+	if err != nil {
+		return api.Error{Err: "cannot get usage per batch", Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, usage)
+}
+
+func (c *UsageController) HandleGetArticleUsage(w http.ResponseWriter, r *http.Request) error {
+	articleIdParam := chi.URLParam(r, "id")
+	articleId, err := strconv.Atoi(articleIdParam)
+	if err != nil {
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	usage, err := c.usageService.GetArticleUsage(r.Context(), articleId)
+	if err != nil {
+		return api.Error{Err: "cannot get article usage", Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, usage)
+}
+
+func (c *UsageController) HandleGetModelPrices(w http.ResponseWriter, r *http.Request) error {
+	prices, err := c.usageService.GetModelPrices(r.Context())
+	if err != nil {
+		return api.Error{Err: "cannot get model prices", Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, prices)
+}
+
+func (c *UsageController) HandleUpdateModelPrice(w http.ResponseWriter, r *http.Request) error {
+	var request *models.LlmModelPrice
+
+	err := api.ReadJSON(w, r, &request)
+	if err != nil {
+		logger.Err(err).Msg("Bad request")
+		return api.Error{Err: "bad request", Status: http.StatusBadRequest}
+	}
+
+	request.Model = chi.URLParam(r, "model")
+
+	err = c.usageService.UpdateModelPrice(r.Context(), request)
+	if err != nil {
+		return api.Error{Err: err.Error(), Status: api.HandleErrorStatus(err)}
+	}
+
+	return api.WriteJSON(w, http.StatusOK, fmt.Sprintf("Price of model %s was updated successfully", request.Model))
+}
+
+// getUsageFilters reads the domainId, from and to query params. Both dates are
+// inclusive and formatted as YYYY-MM-DD.
+func getUsageFilters(r *http.Request) (*storage.GetUsageFilters, error) {
+	domainIdParam := r.URL.Query().Get("domainId")
+	fromParam := r.URL.Query().Get("from")
+	toParam := r.URL.Query().Get("to")
+
+	var filters storage.GetUsageFilters
+
+	if domainIdParam != "" {
+		domainId, err := strconv.Atoi(domainIdParam)
+		if err != nil {
+			return nil, api.Error{Err: "bad request - domainId wrong format", Status: http.StatusBadRequest}
+		}
+
+		filters.DomainId = domainId
+	}
+
+	if fromParam != "" {
+		from, err := time.Parse(usageDateLayout, fromParam)
+		if err != nil {
+			return nil, api.Error{Err: "bad request - from wrong format", Status: http.StatusBadRequest}
+		}
+
+		filters.From = from
+	}
+
+	if toParam != "" {
+		to, err := time.Parse(usageDateLayout, toParam)
+		if err != nil {
+			return nil, api.Error{Err: "bad request - to wrong format", Status: http.StatusBadRequest}
+		}
+
+		filters.To = to.AddDate(0, 0, 1)
+	}
+
+	return &filters, nil
+}
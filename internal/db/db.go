@@ -34,7 +34,6 @@ func init() {
 	l, logFile := lr.NewLogger()
 	defer logFile.Close()
 	logger = l
-	logFile = logFile
 
 	//Init .env
 	dir, err := filepath.Abs(filepath.Dir(os.Args[0]))
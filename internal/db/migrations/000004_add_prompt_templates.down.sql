@@ -0,0 +1,11 @@
+-- DropForeignKey
+ALTER TABLE public.article_prompt_version DROP CONSTRAINT "article_prompt_version_article_id_fkey";
+
+-- DropForeignKey
+ALTER TABLE public.prompt_template DROP CONSTRAINT "prompt_template_domain_id_fkey";
+
+-- DropTable
+DROP TABLE public.article_prompt_version;
+
+-- DropTable
+DROP TABLE public.prompt_template;
\ No newline at end of file
@@ -0,0 +1,33 @@
+-- CreateTable
+CREATE TABLE IF NOT EXISTS public.prompt_template (
+    "id" SERIAL NOT NULL,
+    "name" TEXT NOT NULL,
+    "version" INTEGER NOT NULL,
+    "domain_id" INTEGER,
+    "body" TEXT NOT NULL,
+    "is_active" BOOLEAN NOT NULL DEFAULT false,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL,
+
+    CONSTRAINT "prompt_template_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateTable
+CREATE TABLE IF NOT EXISTS public.article_prompt_version (
+    "article_id" INTEGER NOT NULL,
+    "name" TEXT NOT NULL,
+    "version" INTEGER NOT NULL,
+    "source" TEXT NOT NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "article_prompt_version_pkey" PRIMARY KEY ("article_id","name")
+);
+
+-- CreateIndex
+CREATE UNIQUE INDEX "prompt_template_name_domain_id_version_key" ON public.prompt_template("name", COALESCE("domain_id", 0), "version");
+
+-- AddForeignKey
+ALTER TABLE public.prompt_template ADD CONSTRAINT "prompt_template_domain_id_fkey" FOREIGN KEY ("domain_id") REFERENCES public.domain("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE public.article_prompt_version ADD CONSTRAINT "article_prompt_version_article_id_fkey" FOREIGN KEY ("article_id") REFERENCES public.article("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,15 @@
+-- DropIndex
+DROP INDEX IF EXISTS public."prompt_template_name_domain_id_language_version_key";
+
+-- CreateIndex
+CREATE UNIQUE INDEX "prompt_template_name_domain_id_version_key" ON public.prompt_template("name", COALESCE("domain_id", 0), "version");
+
+-- AlterTable
+ALTER TABLE public.article_prompt_version DROP COLUMN "language";
+
+-- AlterTable
+ALTER TABLE public.prompt_template DROP COLUMN "language";
+
+-- AlterTable
+ALTER TABLE public.domain DROP COLUMN "locale";
+ALTER TABLE public.domain DROP COLUMN "language";
\ No newline at end of file
@@ -0,0 +1,15 @@
+-- AlterTable
+ALTER TABLE public.domain ADD COLUMN "language" TEXT NOT NULL DEFAULT 'pl';
+ALTER TABLE public.domain ADD COLUMN "locale" TEXT NOT NULL DEFAULT 'pl_PL';
+
+-- AlterTable
+ALTER TABLE public.prompt_template ADD COLUMN "language" TEXT NOT NULL DEFAULT 'pl';
+
+-- AlterTable
+ALTER TABLE public.article_prompt_version ADD COLUMN "language" TEXT NOT NULL DEFAULT 'pl';
+
+-- DropIndex
+DROP INDEX IF EXISTS public."prompt_template_name_domain_id_version_key";
+
+-- CreateIndex
+CREATE UNIQUE INDEX "prompt_template_name_domain_id_language_version_key" ON public.prompt_template("name", COALESCE("domain_id", 0), "language", "version");
@@ -0,0 +1,11 @@
+-- DropForeignKey
+ALTER TABLE public.llm_usage DROP CONSTRAINT "llm_usage_domain_id_fkey";
+
+-- DropForeignKey
+ALTER TABLE public.llm_usage DROP CONSTRAINT "llm_usage_article_id_fkey";
+
+-- DropTable
+DROP TABLE public.llm_usage;
+
+-- DropTable
+DROP TABLE public.llm_model_price;
\ No newline at end of file
@@ -0,0 +1,52 @@
+-- CreateTable
+CREATE TABLE IF NOT EXISTS public.llm_model_price (
+    "model" TEXT NOT NULL,
+    "prompt_price" NUMERIC(12,6) NOT NULL,
+    "completion_price" NUMERIC(12,6) NOT NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "llm_model_price_pkey" PRIMARY KEY ("model")
+);
+
+-- CreateTable
+CREATE TABLE IF NOT EXISTS public.llm_usage (
+    "id" SERIAL NOT NULL,
+    "article_id" INTEGER,
+    "domain_id" INTEGER NOT NULL,
+    "task" TEXT NOT NULL,
+    "batch_id" TEXT,
+    "model" TEXT NOT NULL,
+    "prompt_tokens" INTEGER NOT NULL DEFAULT 0,
+    "completion_tokens" INTEGER NOT NULL DEFAULT 0,
+    "total_tokens" INTEGER NOT NULL DEFAULT 0,
+    "cost" NUMERIC(14,6) NOT NULL DEFAULT 0,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL,
+
+    CONSTRAINT "llm_usage_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "llm_usage_article_id_task_model_idx" ON public.llm_usage("article_id", "task", "model");
+
+-- CreateIndex
+CREATE INDEX "llm_usage_domain_id_created_at_idx" ON public.llm_usage("domain_id", "created_at");
+
+-- CreateIndex
+CREATE INDEX "llm_usage_batch_id_idx" ON public.llm_usage("batch_id");
+
+-- AddForeignKey
+ALTER TABLE public.llm_usage ADD CONSTRAINT "llm_usage_article_id_fkey" FOREIGN KEY ("article_id") REFERENCES public.article("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE public.llm_usage ADD CONSTRAINT "llm_usage_domain_id_fkey" FOREIGN KEY ("domain_id") REFERENCES public.domain("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- InsertData (USD per 1000 tokens)
+INSERT INTO public.llm_model_price ("model", "prompt_price", "completion_price") VALUES
+    ('gpt-4-1106-preview', 0.01, 0.03),
+    ('gpt-4-0125-preview', 0.01, 0.03),
+    ('gpt-4', 0.03, 0.06),
+    ('gpt-3.5-turbo-16k', 0.003, 0.004),
+    ('gpt-3.5-turbo-1106', 0.001, 0.002)
+ON CONFLICT DO NOTHING;
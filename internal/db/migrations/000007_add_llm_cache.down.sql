@@ -0,0 +1,2 @@
+-- DropTable
+DROP TABLE public.llm_cache;
\ No newline at end of file
@@ -0,0 +1,17 @@
+-- CreateTable
+CREATE TABLE IF NOT EXISTS public.llm_cache (
+    "key" TEXT NOT NULL,
+    "model" TEXT NOT NULL,
+    "content" TEXT NOT NULL,
+    "prompt_tokens" INTEGER NOT NULL DEFAULT 0,
+    "completion_tokens" INTEGER NOT NULL DEFAULT 0,
+    "total_tokens" INTEGER NOT NULL DEFAULT 0,
+    "expires_at" TIMESTAMP(3) NOT NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL,
+
+    CONSTRAINT "llm_cache_pkey" PRIMARY KEY ("key")
+);
+
+-- CreateIndex
+CREATE INDEX "llm_cache_expires_at_idx" ON public.llm_cache("expires_at");
@@ -0,0 +1,2 @@
+-- DropTable
+DROP TABLE public.article_generation_step;
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- CreateTable
+CREATE TABLE IF NOT EXISTS public.article_generation_step (
+    "article_id" INTEGER NOT NULL,
+    "step" TEXT NOT NULL,
+    "content" TEXT NOT NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "updated_at" TIMESTAMP(3) NOT NULL,
+
+    CONSTRAINT "article_generation_step_pkey" PRIMARY KEY ("article_id","step")
+);
+
+-- AddForeignKey
+ALTER TABLE public.article_generation_step ADD CONSTRAINT "article_generation_step_article_id_fkey" FOREIGN KEY ("article_id") REFERENCES public.article("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,5 @@
+-- AlterTable
+ALTER TABLE public.article DROP COLUMN "meta_title";
+ALTER TABLE public.article DROP COLUMN "meta_description";
+ALTER TABLE public.article DROP COLUMN "focus_keyword";
+ALTER TABLE public.article DROP COLUMN "excerpt";
\ No newline at end of file
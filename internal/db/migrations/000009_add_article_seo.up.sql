@@ -0,0 +1,5 @@
+-- AlterTable
+ALTER TABLE public.article ADD COLUMN "meta_title" TEXT NOT NULL DEFAULT '';
+ALTER TABLE public.article ADD COLUMN "meta_description" TEXT NOT NULL DEFAULT '';
+ALTER TABLE public.article ADD COLUMN "focus_keyword" TEXT NOT NULL DEFAULT '';
+ALTER TABLE public.article ADD COLUMN "excerpt" TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE public.article DROP COLUMN "source_overlap";
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE public.article ADD COLUMN "source_overlap" DOUBLE PRECISION;
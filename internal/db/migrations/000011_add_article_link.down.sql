@@ -0,0 +1,2 @@
+-- DropTable
+DROP TABLE public.article_link;
\ No newline at end of file
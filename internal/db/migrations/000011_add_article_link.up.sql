@@ -0,0 +1,18 @@
+-- CreateTable
+CREATE TABLE IF NOT EXISTS public.article_link (
+    "source_article_id" INTEGER NOT NULL,
+    "target_article_id" INTEGER NOT NULL,
+    "anchor" TEXT NOT NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "article_link_pkey" PRIMARY KEY ("source_article_id","target_article_id")
+);
+
+-- CreateIndex
+CREATE INDEX "article_link_target_article_id_idx" ON public.article_link("target_article_id");
+
+-- AddForeignKey
+ALTER TABLE public.article_link ADD CONSTRAINT "article_link_source_article_id_fkey" FOREIGN KEY ("source_article_id") REFERENCES public.article("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE public.article_link ADD CONSTRAINT "article_link_target_article_id_fkey" FOREIGN KEY ("target_article_id") REFERENCES public.article("id") ON DELETE CASCADE ON UPDATE CASCADE;
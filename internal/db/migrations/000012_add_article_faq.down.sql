@@ -0,0 +1,2 @@
+-- DropTable
+DROP TABLE public.article_faq_item;
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- CreateTable
+CREATE TABLE IF NOT EXISTS public.article_faq_item (
+    "article_id" INTEGER NOT NULL,
+    "position" INTEGER NOT NULL,
+    "question" TEXT NOT NULL,
+    "answer" TEXT NOT NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "article_faq_item_pkey" PRIMARY KEY ("article_id","position")
+);
+
+-- AddForeignKey
+ALTER TABLE public.article_faq_item ADD CONSTRAINT "article_faq_item_article_id_fkey" FOREIGN KEY ("article_id") REFERENCES public.article("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,9 @@
+-- DropTable
+DROP TABLE public.article_version;
+
+-- AlterTable
+ALTER TABLE public.domain DROP COLUMN "refresh_daily_quota";
+ALTER TABLE public.domain DROP COLUMN "refresh_after_days";
+
+-- AlterTable
+ALTER TABLE public.article DROP COLUMN "question_id";
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- DropTable
+DROP TABLE public.category_assignment;
+
+-- DropTable
+DROP TABLE public.category_embedding;
\ No newline at end of file
@@ -0,0 +1,41 @@
+-- CreateTable
+CREATE TABLE IF NOT EXISTS public.category_embedding (
+    "category_id" INTEGER NOT NULL,
+    "model" TEXT NOT NULL,
+    "text_hash" TEXT NOT NULL,
+    "embedding" REAL[] NOT NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "category_embedding_pkey" PRIMARY KEY ("category_id","model")
+);
+
+-- CreateTable
+CREATE TABLE IF NOT EXISTS public.category_assignment (
+    "id" SERIAL NOT NULL,
+    "question_id" INTEGER NOT NULL,
+    "domain_id" INTEGER NOT NULL,
+    "category_id" INTEGER,
+    "score" DOUBLE PRECISION NOT NULL,
+    "runner_up_category_id" INTEGER,
+    "runner_up_score" DOUBLE PRECISION,
+    "method" TEXT NOT NULL,
+    "model" TEXT NOT NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "category_assignment_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "category_assignment_domain_id_idx" ON public.category_assignment("domain_id");
+
+-- AddForeignKey
+ALTER TABLE public.category_embedding ADD CONSTRAINT "category_embedding_category_id_fkey" FOREIGN KEY ("category_id") REFERENCES public.category("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE public.category_assignment ADD CONSTRAINT "category_assignment_domain_id_fkey" FOREIGN KEY ("domain_id") REFERENCES public.domain("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE public.category_assignment ADD CONSTRAINT "category_assignment_category_id_fkey" FOREIGN KEY ("category_id") REFERENCES public.category("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE public.category_assignment ADD CONSTRAINT "category_assignment_runner_up_category_id_fkey" FOREIGN KEY ("runner_up_category_id") REFERENCES public.category("id") ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,2 @@
+-- DropTable
+DROP TABLE public.question_duplicate;
\ No newline at end of file
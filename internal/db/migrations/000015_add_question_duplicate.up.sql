@@ -0,0 +1,23 @@
+-- CreateTable
+CREATE TABLE IF NOT EXISTS public.question_duplicate (
+    "id" SERIAL NOT NULL,
+    "question_id" INTEGER NOT NULL,
+    "domain_id" INTEGER NOT NULL,
+    "duplicate_of_article_id" INTEGER,
+    "duplicate_of_question_id" INTEGER,
+    "signal" TEXT NOT NULL,
+    "score" DOUBLE PRECISION NOT NULL,
+    "batch_id" TEXT NOT NULL DEFAULT '',
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "question_duplicate_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "question_duplicate_domain_id_idx" ON public.question_duplicate("domain_id");
+
+-- AddForeignKey
+ALTER TABLE public.question_duplicate ADD CONSTRAINT "question_duplicate_domain_id_fkey" FOREIGN KEY ("domain_id") REFERENCES public.domain("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE public.question_duplicate ADD CONSTRAINT "question_duplicate_duplicate_of_article_id_fkey" FOREIGN KEY ("duplicate_of_article_id") REFERENCES public.article("id") ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,5 @@
+-- DropTable
+DROP TABLE public.article_status_transition;
+
+-- AlterTable
+ALTER TABLE public.article DROP COLUMN "status";
\ No newline at end of file
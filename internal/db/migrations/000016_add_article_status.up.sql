@@ -0,0 +1,27 @@
+-- AlterTable
+ALTER TABLE public.article ADD COLUMN "status" TEXT NOT NULL DEFAULT 'draft';
+UPDATE public.article SET "status" = CASE WHEN "is_published" THEN 'published' ELSE 'needs_review' END;
+
+-- CreateIndex
+CREATE INDEX "article_status_idx" ON public.article("status");
+
+-- CreateTable
+CREATE TABLE IF NOT EXISTS public.article_status_transition (
+    "id" SERIAL NOT NULL,
+    "article_id" INTEGER NOT NULL,
+    "from_status" TEXT NOT NULL,
+    "to_status" TEXT NOT NULL,
+    "user_id" INTEGER,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "article_status_transition_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "article_status_transition_article_id_idx" ON public.article_status_transition("article_id");
+
+-- AddForeignKey
+ALTER TABLE public.article_status_transition ADD CONSTRAINT "article_status_transition_article_id_fkey" FOREIGN KEY ("article_id") REFERENCES public.article("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE public.article_status_transition ADD CONSTRAINT "article_status_transition_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES public.user("id") ON DELETE SET NULL ON UPDATE CASCADE;
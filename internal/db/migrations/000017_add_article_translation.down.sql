@@ -0,0 +1,2 @@
+-- DropTable
+DROP TABLE public.article_translation;
\ No newline at end of file
@@ -0,0 +1,17 @@
+-- CreateTable
+CREATE TABLE IF NOT EXISTS public.article_translation (
+    "article_id" INTEGER NOT NULL,
+    "source_article_id" INTEGER NOT NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "article_translation_pkey" PRIMARY KEY ("article_id")
+);
+
+-- CreateIndex
+CREATE INDEX "article_translation_source_article_id_idx" ON public.article_translation("source_article_id");
+
+-- AddForeignKey
+ALTER TABLE public.article_translation ADD CONSTRAINT "article_translation_article_id_fkey" FOREIGN KEY ("article_id") REFERENCES public.article("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE public.article_translation ADD CONSTRAINT "article_translation_source_article_id_fkey" FOREIGN KEY ("source_article_id") REFERENCES public.article("id") ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,11 @@
+-- DropIndex
+DROP INDEX IF EXISTS public."basic_page_domain_created_at_id_idx";
+
+-- DropIndex
+DROP INDEX IF EXISTS public."image_storage_created_at_id_idx";
+
+-- DropIndex
+DROP INDEX IF EXISTS public."article_publication_date_id_idx";
+
+-- DropIndex
+DROP INDEX IF EXISTS public."article_domain_id_publication_date_id_idx";
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- CreateIndex
+CREATE INDEX "article_domain_id_publication_date_id_idx" ON public.article("domain_id", "publication_date" DESC, "id" DESC);
+
+-- CreateIndex
+CREATE INDEX "article_publication_date_id_idx" ON public.article("publication_date" DESC, "id" DESC);
+
+-- CreateIndex
+CREATE INDEX "image_storage_created_at_id_idx" ON public.image_storage("created_at" DESC, "id" DESC);
+
+-- CreateIndex
+CREATE INDEX "basic_page_domain_created_at_id_idx" ON public.basic_page("domain", "created_at" DESC, "id" DESC);
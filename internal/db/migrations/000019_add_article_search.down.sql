@@ -0,0 +1,14 @@
+-- DropTrigger
+DROP TRIGGER IF EXISTS "domain_search_language_update" ON public.domain;
+DROP FUNCTION IF EXISTS public.domain_search_language_update();
+
+-- DropTrigger
+DROP TRIGGER IF EXISTS "article_search_vector_update" ON public.article;
+DROP FUNCTION IF EXISTS public.article_search_vector_update();
+
+-- AlterTable
+ALTER TABLE public.article DROP COLUMN "search_vector";
+
+-- DropFunction
+DROP FUNCTION IF EXISTS public.article_search_vector(regconfig, TEXT, TEXT);
+DROP FUNCTION IF EXISTS public.search_config(TEXT);
\ No newline at end of file
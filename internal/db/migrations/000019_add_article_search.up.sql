@@ -0,0 +1,51 @@
+-- CreateFunction
+CREATE OR REPLACE FUNCTION public.search_config("language" TEXT) RETURNS regconfig AS $$
+    SELECT CASE "language"
+        WHEN 'en' THEN 'english'::regconfig
+        WHEN 'de' THEN 'german'::regconfig
+        ELSE 'simple'::regconfig
+    END
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- CreateFunction
+CREATE OR REPLACE FUNCTION public.article_search_vector("config" regconfig, "title" TEXT, "body" TEXT) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector("config", COALESCE("title", '')), 'A') ||
+           setweight(to_tsvector("config", regexp_replace(COALESCE("body", ''), '<[^>]*>', ' ', 'g')), 'B')
+$$ LANGUAGE SQL IMMUTABLE;
+
+-- AlterTable
+ALTER TABLE public.article ADD COLUMN "search_vector" tsvector;
+UPDATE public.article a SET "search_vector" = public.article_search_vector(public.search_config(d."language"), a."title", a."body")
+    FROM public.domain d WHERE d."id" = a."domain_id";
+
+-- CreateIndex
+CREATE INDEX "article_search_vector_idx" ON public.article USING GIN ("search_vector");
+
+-- CreateFunction
+CREATE OR REPLACE FUNCTION public.article_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW."search_vector" := public.article_search_vector(
+        public.search_config((SELECT "language" FROM public.domain WHERE "id" = NEW."domain_id")),
+        NEW."title",
+        NEW."body"
+    );
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+-- CreateTrigger
+CREATE TRIGGER "article_search_vector_update" BEFORE INSERT OR UPDATE OF "title", "body", "domain_id" ON public.article
+    FOR EACH ROW EXECUTE FUNCTION public.article_search_vector_update();
+
+-- CreateFunction
+CREATE OR REPLACE FUNCTION public.domain_search_language_update() RETURNS trigger AS $$
+BEGIN
+    UPDATE public.article SET "search_vector" = public.article_search_vector(public.search_config(NEW."language"), "title", "body")
+        WHERE "domain_id" = NEW."id";
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+-- CreateTrigger
+CREATE TRIGGER "domain_search_language_update" AFTER UPDATE OF "language" ON public.domain
+    FOR EACH ROW WHEN (OLD."language" IS DISTINCT FROM NEW."language") EXECUTE FUNCTION public.domain_search_language_update();
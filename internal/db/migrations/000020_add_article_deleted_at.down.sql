@@ -0,0 +1,8 @@
+-- DropIndex
+DROP INDEX IF EXISTS public."article_slug_domain_id_key";
+
+-- CreateIndex
+CREATE UNIQUE INDEX "article_slug_domain_id_key" ON public.article("slug", "domain_id");
+
+-- AlterTable
+ALTER TABLE public.article DROP COLUMN "deleted_at";
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- AlterTable
+ALTER TABLE public.article ADD COLUMN "deleted_at" TIMESTAMP(3);
+
+-- CreateIndex
+CREATE INDEX "article_deleted_at_idx" ON public.article("deleted_at") WHERE "deleted_at" IS NOT NULL;
+
+-- DropIndex
+DROP INDEX IF EXISTS public."article_slug_domain_id_key";
+
+-- CreateIndex
+CREATE UNIQUE INDEX "article_slug_domain_id_key" ON public.article("slug", "domain_id") WHERE "deleted_at" IS NULL;
@@ -0,0 +1,35 @@
+-- CreateTable
+CREATE TABLE IF NOT EXISTS public.article_revision (
+    "id" SERIAL NOT NULL,
+    "article_id" INTEGER NOT NULL,
+    "title" TEXT NOT NULL,
+    "slug" TEXT NOT NULL,
+    "body" TEXT NOT NULL,
+    "meta_title" TEXT NOT NULL DEFAULT '',
+    "meta_description" TEXT NOT NULL DEFAULT '',
+    "focus_keyword" TEXT NOT NULL DEFAULT '',
+    "excerpt" TEXT NOT NULL DEFAULT '',
+    "source" TEXT NOT NULL,
+    "user_id" INTEGER,
+    "restored_from_id" INTEGER,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "article_revision_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "article_revision_article_id_idx" ON public.article_revision("article_id");
+
+-- AddForeignKey
+ALTER TABLE public.article_revision ADD CONSTRAINT "article_revision_article_id_fkey" FOREIGN KEY ("article_id") REFERENCES public.article("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE public.article_revision ADD CONSTRAINT "article_revision_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES public.user("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE public.article_revision ADD CONSTRAINT "article_revision_restored_from_id_fkey" FOREIGN KEY ("restored_from_id") REFERENCES public.article_revision("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- The current content of existing articles is their first revision.
+INSERT INTO public.article_revision ("article_id", "title", "slug", "body", "meta_title", "meta_description", "focus_keyword", "excerpt", "source", "created_at")
+    SELECT "id", "title", "slug", "body", "meta_title", "meta_description", "focus_keyword", "excerpt", 'initial', "updated_at"
+    FROM public.article;
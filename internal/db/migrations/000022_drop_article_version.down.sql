@@ -0,0 +1,23 @@
+-- CreateTable
+CREATE TABLE IF NOT EXISTS public.article_version (
+    "id" SERIAL NOT NULL,
+    "article_id" INTEGER NOT NULL,
+    "title" TEXT NOT NULL,
+    "body" TEXT NOT NULL,
+    "reason" TEXT NOT NULL,
+    "created_at" TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT "article_version_pkey" PRIMARY KEY ("id")
+);
+
+-- CreateIndex
+CREATE INDEX "article_version_article_id_idx" ON public.article_version("article_id");
+
+-- AddForeignKey
+ALTER TABLE public.article_version ADD CONSTRAINT "article_version_article_id_fkey" FOREIGN KEY ("article_id") REFERENCES public.article("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- DropIndex
+DROP INDEX IF EXISTS public."article_revision_refresh_created_at_idx";
+
+-- AlterTable
+ALTER TABLE public.article_revision DROP COLUMN "refresh";
\ No newline at end of file
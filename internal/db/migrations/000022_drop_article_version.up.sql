@@ -0,0 +1,8 @@
+-- AlterTable
+ALTER TABLE public.article_revision ADD COLUMN "refresh" BOOLEAN NOT NULL DEFAULT false;
+
+-- CreateIndex
+CREATE INDEX "article_revision_refresh_created_at_idx" ON public.article_revision("created_at") WHERE "refresh";
+
+-- DropTable
+DROP TABLE IF EXISTS public.article_version;
@@ -0,0 +1,5 @@
+-- DropIndex
+DROP INDEX IF EXISTS public."article_domain_id_refresh_requested_at_idx";
+
+-- AlterTable
+ALTER TABLE public.article DROP COLUMN "refresh_requested_at";
\ No newline at end of file
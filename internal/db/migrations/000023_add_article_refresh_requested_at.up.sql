@@ -0,0 +1,5 @@
+-- AlterTable
+ALTER TABLE public.article ADD COLUMN "refresh_requested_at" TIMESTAMP(3);
+
+-- CreateIndex
+CREATE INDEX "article_domain_id_refresh_requested_at_idx" ON public.article("domain_id", "refresh_requested_at") WHERE "refresh_requested_at" IS NOT NULL;
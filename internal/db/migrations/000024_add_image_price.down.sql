@@ -0,0 +1,2 @@
+-- DeleteData
+DELETE FROM public.llm_model_price WHERE "model" = 'dall-e-3/1024x1024';
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- InsertData (USD per 1000 images, an image is recorded as one completion token)
+INSERT INTO public.llm_model_price ("model", "prompt_price", "completion_price") VALUES
+    ('dall-e-3/1024x1024', 0, 40)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+-- DeleteData
+DELETE FROM public.llm_model_price WHERE "model" = 'text-embedding-ada-002';
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- InsertData (USD per 1000 tokens)
+INSERT INTO public.llm_model_price ("model", "prompt_price", "completion_price") VALUES
+    ('text-embedding-ada-002', 0.0001, 0)
+ON CONFLICT DO NOTHING;
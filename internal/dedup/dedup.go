@@ -0,0 +1,154 @@
+package dedup
+
+import (
+	"strings"
+
+	"github.com/gosimple/slug"
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+)
+
+// Signals telling why two texts are duplicates.
+const (
+	// SignalExact means the texts are equal once normalized.
+	SignalExact = "exact"
+	// SignalLexical means the texts share most of their character trigrams,
+	// e.g. they differ by a prefix or an inflection.
+	SignalLexical = "lexical"
+	// SignalSemantic means the embeddings of the texts are very similar.
+	SignalSemantic = "semantic"
+)
+
+// DefaultLexicalThreshold is the trigram similarity from which texts are
+// duplicates. Short questions share many trigrams of their first words ("jak",
+// "how to"), so it is set high.
+const DefaultLexicalThreshold = 0.85
+
+// DefaultSemanticThreshold is the cosine similarity of the embeddings from
+// which texts are duplicates.
+const DefaultSemanticThreshold = 0.95
+
+// Entry is a text other texts are compared against, e.g. an article title.
+type Entry struct {
+	Kind string
+	Id   int
+	Text string
+	// Embedding is optional, entries without one are only compared lexically.
+	Embedding []float32
+}
+
+// Match is the entry a text duplicates.
+type Match struct {
+	Entry  Entry
+	Signal string
+	Score  float64
+}
+
+type indexed struct {
+	entry      Entry
+	normalized string
+	trigrams   map[string]struct{}
+}
+
+// Index finds the entries a text duplicates. The zero value is not usable,
+// create one with NewIndex.
+type Index struct {
+	lang              string
+	lexicalThreshold  float64
+	semanticThreshold float64
+	entries           []indexed
+}
+
+// NewIndex returns an empty index normalizing texts with the rules of lang.
+func NewIndex(lang string, lexicalThreshold float64, semanticThreshold float64) *Index {
+	return &Index{
+		lang:              lang,
+		lexicalThreshold:  lexicalThreshold,
+		semanticThreshold: semanticThreshold,
+	}
+}
+
+func (i *Index) Add(entry Entry) {
+	normalized := Normalize(entry.Text, i.lang)
+
+	i.entries = append(i.entries, indexed{
+		entry:      entry,
+		normalized: normalized,
+		trigrams:   trigrams(normalized),
+	})
+}
+
+// Find returns the entry text duplicates, nil when there is none. An exact
+// match wins over a lexical one, which wins over a semantic one. embedding may
+// be nil to skip the semantic check.
+func (i *Index) Find(text string, embedding []float32) *Match {
+	normalized := Normalize(text, i.lang)
+	textTrigrams := trigrams(normalized)
+
+	var lexical, semantic *Match
+
+	for _, candidate := range i.entries {
+		if normalized != "" && normalized == candidate.normalized {
+			return &Match{Entry: candidate.entry, Signal: SignalExact, Score: 1}
+		}
+
+		if score := dice(textTrigrams, candidate.trigrams); score >= i.lexicalThreshold && (lexical == nil || score > lexical.Score) {
+			lexical = &Match{Entry: candidate.entry, Signal: SignalLexical, Score: score}
+		}
+
+		if embedding == nil || candidate.entry.Embedding == nil {
+			continue
+		}
+
+		if score := llm.CosineSimilarity(embedding, candidate.entry.Embedding); score >= i.semanticThreshold && (semantic == nil || score > semantic.Score) {
+			semantic = &Match{Entry: candidate.entry, Signal: SignalSemantic, Score: score}
+		}
+	}
+
+	if lexical != nil {
+		return lexical
+	}
+
+	return semantic
+}
+
+// Normalize lower-cases text, transliterates it using the rules of lang and
+// drops punctuation, so "Jak wyczyścić dach?" becomes "jak wyczyscic dach".
+func Normalize(text string, lang string) string {
+	return strings.ReplaceAll(slug.MakeLang(text, lang), "-", " ")
+}
+
+// LexicalSimilarity is the Dice coefficient of the character trigrams of the
+// normalized texts, from 0 to 1.
+func LexicalSimilarity(a string, b string, lang string) float64 {
+	return dice(trigrams(Normalize(a, lang)), trigrams(Normalize(b, lang)))
+}
+
+// trigrams returns the character trigrams of every word, padded with spaces so
+// the beginning and the end of a word count too.
+func trigrams(normalized string) map[string]struct{} {
+	result := make(map[string]struct{})
+
+	for _, word := range strings.Fields(normalized) {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			result[string(runes[i:i+3])] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+func dice(a map[string]struct{}, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	common := 0
+	for trigram := range a {
+		if _, ok := b[trigram]; ok {
+			common++
+		}
+	}
+
+	return 2 * float64(common) / float64(len(a)+len(b))
+}
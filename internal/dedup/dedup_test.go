@@ -0,0 +1,139 @@
+package dedup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		text string
+		lang string
+		want string
+	}{
+		{"Jak wyczyścić dach?", "pl", "jak wyczyscic dach"},
+		{"  Jak   WYCZYŚCIĆ dach!!! ", "pl", "jak wyczyscic dach"},
+		{"Wie reinigt man das Dach?", "de", "wie reinigt man das dach"},
+		{"Größe & Gewicht", "de", "groesse und gewicht"},
+		{"How to clean a roof?", "en", "how to clean a roof"},
+	}
+
+	for _, tt := range tests {
+		if got := Normalize(tt.text, tt.lang); got != tt.want {
+			t.Errorf("Normalize(%q, %s) = %q, want %q", tt.text, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	index := NewIndex("pl", DefaultLexicalThreshold, DefaultSemanticThreshold)
+	index.Add(Entry{Kind: "article", Id: 1, Text: "Jak wyczyścić dach?"})
+	index.Add(Entry{Kind: "article", Id: 2, Text: "Ile kosztuje malowanie elewacji domu?"})
+	index.Add(Entry{Kind: "question", Id: 3, Text: "Czym impregnować drewniany taras?"})
+
+	tests := []struct {
+		text       string
+		wantId     int
+		wantSignal string
+	}{
+		{"jak wyczyścić dach", 1, SignalExact},
+		{"Jak wyczyscic dach?", 1, SignalExact},
+		// An inflected verb asks the same question.
+		{"Jak czyścić dach?", 1, SignalLexical},
+		{"Ile kosztuje malowanie elewacji domów?", 2, SignalLexical},
+		{"Jak wyczyścić rynny?", 0, ""},
+		{"Czym impregnować drewniane meble?", 0, ""},
+		{"", 0, ""},
+	}
+
+	for _, tt := range tests {
+		match := index.Find(tt.text, nil)
+
+		if tt.wantId == 0 {
+			if match != nil {
+				t.Errorf("Find(%q) = %d (%s, %.2f), want no match", tt.text, match.Entry.Id, match.Signal, match.Score)
+			}
+			continue
+		}
+
+		if match == nil {
+			t.Errorf("Find(%q) = nil, want %d", tt.text, tt.wantId)
+			continue
+		}
+
+		if match.Entry.Id != tt.wantId || match.Signal != tt.wantSignal {
+			t.Errorf("Find(%q) = %d (%s), want %d (%s)", tt.text, match.Entry.Id, match.Signal, tt.wantId, tt.wantSignal)
+		}
+	}
+}
+
+func TestFindBestLexicalMatch(t *testing.T) {
+	index := NewIndex("pl", 0.5, DefaultSemanticThreshold)
+	index.Add(Entry{Id: 1, Text: "Jak czyścić dach z mchu?"})
+	index.Add(Entry{Id: 2, Text: "Jak czyścić dach?"})
+
+	if match := index.Find("Jak wyczyścić dach?", nil); match == nil || match.Entry.Id != 2 {
+		t.Errorf("Find = %+v, want the closest entry 2", match)
+	}
+}
+
+func TestFindSemantic(t *testing.T) {
+	provider := llm.NewFakeProvider()
+	texts := []string{
+		"How do I remove moss from roof tiles without damaging them",
+		"Remove moss from roof tiles without damaging them how do I",
+		"What paint should I use for a bathroom ceiling",
+	}
+
+	resp, err := provider.CreateEmbedding(context.Background(), llm.EmbeddingRequest{Input: texts})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The lexical check is turned off, so only the embeddings can match.
+	index := NewIndex("en", 1.1, DefaultSemanticThreshold)
+	index.Add(Entry{Id: 1, Text: texts[0], Embedding: resp.Embeddings[0]})
+	index.Add(Entry{Id: 2, Text: "Entry without an embedding"})
+
+	match := index.Find(texts[1], resp.Embeddings[1])
+	if match == nil || match.Entry.Id != 1 || match.Signal != SignalSemantic {
+		t.Fatalf("Find = %+v, want a semantic match of entry 1", match)
+	}
+
+	if match := index.Find(texts[2], resp.Embeddings[2]); match != nil {
+		t.Errorf("Find = %d (%.2f), want no match for an unrelated question", match.Entry.Id, match.Score)
+	}
+
+	if match := index.Find(texts[1], nil); match != nil {
+		t.Errorf("Find without an embedding = %+v, want no match", match)
+	}
+}
+
+func TestFindPrefersLexical(t *testing.T) {
+	embedding := []float32{1, 0}
+
+	index := NewIndex("pl", DefaultLexicalThreshold, DefaultSemanticThreshold)
+	index.Add(Entry{Id: 1, Text: "Zupełnie inne pytanie", Embedding: embedding})
+	index.Add(Entry{Id: 2, Text: "Jak wyczyścić dach?", Embedding: []float32{0, 1}})
+
+	if match := index.Find("Jak czyścić dach?", embedding); match == nil || match.Entry.Id != 2 || match.Signal != SignalLexical {
+		t.Errorf("Find = %+v, want the lexical match of entry 2", match)
+	}
+}
+
+func TestLexicalSimilarity(t *testing.T) {
+	if score := LexicalSimilarity("Jak wyczyścić dach?", "jak czyścić dach", "pl"); score < DefaultLexicalThreshold {
+		t.Errorf("similarity of an inflection = %.2f, want at least %.2f", score, DefaultLexicalThreshold)
+	}
+
+	// Questions share their first words, that alone is not a duplicate.
+	if score := LexicalSimilarity("Jak wyczyścić dach?", "Jak wybrać kosiarkę?", "pl"); score >= DefaultLexicalThreshold {
+		t.Errorf("similarity of different questions = %.2f, want below %.2f", score, DefaultLexicalThreshold)
+	}
+
+	if score := LexicalSimilarity("", "jak", "pl"); score != 0 {
+		t.Errorf("similarity with an empty text = %.2f, want 0", score)
+	}
+}
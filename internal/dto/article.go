@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"encoding/json"
 	"github.com/rustoma/octo-pulse/internal/models"
 	"time"
 )
@@ -21,6 +22,50 @@ type Article struct {
 	IsSponsored     bool            `json:"isSponsored"`
 	CreatedAt       time.Time       `json:"createdAt" validate:"required,min=4"`
 	UpdatedAt       time.Time       `json:"updatedAt" validate:"required,min=4"`
+	MetaTitle       string          `json:"metaTitle"`
+	MetaDescription string          `json:"metaDescription"`
+	FocusKeyword    string          `json:"focusKeyword"`
+	Excerpt         string          `json:"excerpt"`
+	SourceOverlap   *float64        `json:"sourceOverlap"`
+	QuestionId      *int            `json:"questionId"`
+	Status          string          `json:"status"`
+}
+
+// ArticleSearchResult is an article matching a search, without its body. The
+// matched words of TitleHighlight and Snippet are wrapped in <mark> tags.
+type ArticleSearchResult struct {
+	*Article
+	Rank           float64 `json:"rank"`
+	TitleHighlight string  `json:"titleHighlight"`
+	Snippet        string  `json:"snippet"`
+}
+
+// DeletedArticle is an article in the trash, without its body. It is purged
+// permanently at PurgeAt.
+type DeletedArticle struct {
+	*Article
+	DeletedAt time.Time `json:"deletedAt"`
+	PurgeAt   time.Time `json:"purgeAt"`
+}
+
+// ArticleRevisionDiff compares two revisions of an article. Title and Body are
+// the newer revision with the removed words wrapped in <del> and the added
+// words wrapped in <ins>. Insertions and Deletions count the words of the body.
+type ArticleRevisionDiff struct {
+	FromRevisionId int                   `json:"fromRevisionId"`
+	ToRevisionId   int                   `json:"toRevisionId"`
+	Title          string                `json:"title"`
+	Body           string                `json:"body"`
+	Insertions     int                   `json:"insertions"`
+	Deletions      int                   `json:"deletions"`
+	Fields         []*ArticleFieldChange `json:"fields"`
+}
+
+// ArticleFieldChange is a metadata field that differs between two revisions.
+type ArticleFieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
 }
 
 type ArticleValidationErrors struct {
@@ -35,4 +80,51 @@ type ArticleValidationErrors struct {
 	DomainId        string `json:"domainId"`
 	CreatedAt       string `json:"createdAt"`
 	UpdatedAt       string `json:"updatedAt"`
+	MetaTitle       string `json:"metaTitle"`
+	MetaDescription string `json:"metaDescription"`
+	FocusKeyword    string `json:"focusKeyword"`
+	Excerpt         string `json:"excerpt"`
+}
+
+// ArticleGenerationProgress shows how far the generation of the article body
+// got. The steps are removed once the body is saved.
+type ArticleGenerationProgress struct {
+	ArticleId int                             `json:"articleId"`
+	Steps     []*models.ArticleGenerationStep `json:"steps"`
+	// SectionsTotal counts the introduction and the sections of the agenda. It
+	// is 0 until the agenda is generated.
+	SectionsTotal     int `json:"sectionsTotal"`
+	SectionsCompleted int `json:"sectionsCompleted"`
+}
+
+// PublicArticle is the article as shown on the domain, with its FAQ section.
+type PublicArticle struct {
+	*models.Article
+	Faq []*models.ArticleFaqItem `json:"faq"`
+	// FaqJsonLd is the schema.org FAQPage of the FAQ, null without one.
+	FaqJsonLd json.RawMessage `json:"faqJsonLd"`
+	// Alternates are the published translations of the article on the sister
+	// domains, the article included, null without any.
+	Alternates []*ArticleAlternate `json:"alternates"`
+}
+
+// ArticleAlternate is a translation of an article, output as a hreflang link.
+type ArticleAlternate struct {
+	ArticleId int    `json:"articleId"`
+	DomainId  int    `json:"domainId"`
+	HrefLang  string `json:"hrefLang"`
+	Href      string `json:"href"`
+}
+
+// TranslateArticleRequest asks for a translation of an article into the
+// language of another domain.
+type TranslateArticleRequest struct {
+	DomainId int `json:"domainId"`
+}
+
+// ArticleStatusRequest moves an article to another status of the editorial
+// workflow. PublishAt is required when scheduling the article.
+type ArticleStatusRequest struct {
+	Status    string     `json:"status"`
+	PublishAt *time.Time `json:"publishAt"`
 }
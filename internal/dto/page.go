@@ -0,0 +1,9 @@
+package dto
+
+// Page is one page of a listing. NextCursor is passed as the cursor parameter
+// to get the page after it, it is null on the last page.
+type Page[T any] struct {
+	Items      []T     `json:"items"`
+	Total      int     `json:"total"`
+	NextCursor *string `json:"nextCursor"`
+}
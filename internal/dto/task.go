@@ -10,8 +10,13 @@ type GenerateArticlesRequest struct {
 	NumberOfArticles   int `json:"numberOfArticles"`
 	QuestionCategoryId int `json:"questionCategoryId"`
 	ImagesCategory     int `json:"imagesCategory"`
+	// GenerateThumbnails replaces the random thumbnail picked from ImagesCategory
+	// with a generated one, saved in the same category.
+	GenerateThumbnails bool `json:"generateThumbnails"`
 }
 
 type GenerateDescriptionRequest struct {
 	QuestionId int `json:"questionId"`
+	// Fresh skips the completion cache so every prompt is sent to the model again.
+	Fresh bool `json:"fresh"`
 }
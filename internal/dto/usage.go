@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+type UsageTotals struct {
+	PromptTokens     int     `json:"promptTokens"`
+	CompletionTokens int     `json:"completionTokens"`
+	TotalTokens      int     `json:"totalTokens"`
+	Cost             float64 `json:"cost"`
+}
+
+type DomainUsage struct {
+	DomainId int `json:"domainId"`
+	UsageTotals
+}
+
+type DailyUsage struct {
+	Day time.Time `json:"day"`
+	UsageTotals
+}
+
+type BatchUsage struct {
+	BatchId   string    `json:"batchId"`
+	DomainId  int       `json:"domainId"`
+	Articles  int       `json:"articles"`
+	StartedAt time.Time `json:"startedAt"`
+	EndedAt   time.Time `json:"endedAt"`
+	UsageTotals
+}
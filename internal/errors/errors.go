@@ -23,3 +23,11 @@ type BadRequest struct {
 func (e BadRequest) Error() string {
 	return e.Err
 }
+
+type Forbidden struct {
+	Err string
+}
+
+func (e Forbidden) Error() string {
+	return e.Err
+}
@@ -2,6 +2,7 @@ package fixtures
 
 import (
 	"github.com/gosimple/slug"
+	"github.com/rustoma/octo-pulse/internal/language"
 	"github.com/rustoma/octo-pulse/internal/utils"
 	"math/rand"
 	"time"
@@ -49,6 +50,8 @@ func (f *fixtures) CreateDomain(name, email string) *models.Domain {
 	return &models.Domain{
 		Name:      name,
 		Email:     email,
+		Language:  language.Default,
+		Locale:    language.Get(language.Default).Locale,
 		CreatedAt: time.Now().UTC(),
 		UpdatedAt: time.Now().UTC(),
 	}
@@ -83,6 +86,11 @@ func (f *fixtures) CreateArticle(title, body string, thumbnail int, isPub bool,
 
 	readTime := utils.CalculateReadTime(body)
 
+	status := models.ArticleStatusDraft
+	if isPub {
+		status = models.ArticleStatusPublished
+	}
+
 	return &models.Article{
 		Title:           title,
 		Slug:            slug.Make(title),
@@ -90,6 +98,7 @@ func (f *fixtures) CreateArticle(title, body string, thumbnail int, isPub bool,
 		Thumbnail:       &thumbnail,
 		PublicationDate: time.Now().UTC(),
 		IsPublished:     isPub,
+		Status:          status,
 		AuthorId:        authorId,
 		CategoryId:      categoryId,
 		DomainId:        domainId,
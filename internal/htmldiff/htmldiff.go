@@ -0,0 +1,270 @@
+package htmldiff
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// maxCells bounds the size of the table a comparison of two token lists takes.
+// Longer lists are not compared, the old one is shown as removed and the new
+// one as added.
+const maxCells = 4_000_000
+
+var (
+	tokenPattern    = regexp.MustCompile(`<[^>]*>|\s+|[^<\s]+`)
+	blockEndPattern = regexp.MustCompile(`(?i)^</(p|h[1-6]|li|ul|ol|table|tr|blockquote|div|section|figure|pre)\s*>$`)
+)
+
+type operation int
+
+const (
+	equal operation = iota
+	insert
+	remove
+)
+
+type edit struct {
+	operation operation
+	token     string
+}
+
+// Result is the comparison of two HTML documents.
+type Result struct {
+	// Html is the newer document with the removed text wrapped in <del> and the
+	// added text wrapped in <ins>. Removed tags are left out.
+	Html string
+	// Insertions and Deletions are the numbers of words added and removed.
+	Insertions int
+	Deletions  int
+}
+
+// Diff compares two HTML documents word by word, keeping the tags intact. The
+// documents are first matched block by block, paragraphs, headings and list
+// items, and only the blocks that differ are compared word by word.
+func Diff(from string, to string) Result {
+	fromBlocks := blocks(tokenize(from))
+	toBlocks := blocks(tokenize(to))
+
+	var edits []edit
+	var removed, inserted []string
+
+	fromIndex, toIndex := 0, 0
+	for _, match := range lcs(keys(fromBlocks), keys(toBlocks)) {
+		for ; fromIndex < match[0]; fromIndex++ {
+			removed = append(removed, fromBlocks[fromIndex]...)
+		}
+		for ; toIndex < match[1]; toIndex++ {
+			inserted = append(inserted, toBlocks[toIndex]...)
+		}
+
+		edits = append(edits, diffTokens(removed, inserted)...)
+		removed, inserted = nil, nil
+
+		for _, token := range toBlocks[toIndex] {
+			edits = append(edits, edit{operation: equal, token: token})
+		}
+		fromIndex++
+		toIndex++
+	}
+
+	for ; fromIndex < len(fromBlocks); fromIndex++ {
+		removed = append(removed, fromBlocks[fromIndex]...)
+	}
+	for ; toIndex < len(toBlocks); toIndex++ {
+		inserted = append(inserted, toBlocks[toIndex]...)
+	}
+	edits = append(edits, diffTokens(removed, inserted)...)
+
+	return render(edits)
+}
+
+// tokenize splits html into tags, whitespace and words.
+func tokenize(html string) []string {
+	return tokenPattern.FindAllString(html, -1)
+}
+
+// blocks groups the tokens into blocks, each ending with the closing tag of a
+// block element.
+func blocks(tokens []string) [][]string {
+	var result [][]string
+	var block []string
+
+	for _, token := range tokens {
+		block = append(block, token)
+		if blockEndPattern.MatchString(token) {
+			result = append(result, block)
+			block = nil
+		}
+	}
+
+	if len(block) > 0 {
+		result = append(result, block)
+	}
+
+	return result
+}
+
+func keys(blocks [][]string) []string {
+	result := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		result = append(result, strings.Join(block, ""))
+	}
+
+	return result
+}
+
+// diffTokens returns the edits turning from into to.
+func diffTokens(from []string, to []string) []edit {
+	edits := make([]edit, 0, len(from)+len(to))
+
+	fromIndex, toIndex := 0, 0
+	for _, match := range lcs(from, to) {
+		for ; fromIndex < match[0]; fromIndex++ {
+			edits = append(edits, edit{operation: remove, token: from[fromIndex]})
+		}
+		for ; toIndex < match[1]; toIndex++ {
+			edits = append(edits, edit{operation: insert, token: to[toIndex]})
+		}
+
+		edits = append(edits, edit{operation: equal, token: to[toIndex]})
+		fromIndex++
+		toIndex++
+	}
+
+	for ; fromIndex < len(from); fromIndex++ {
+		edits = append(edits, edit{operation: remove, token: from[fromIndex]})
+	}
+	for ; toIndex < len(to); toIndex++ {
+		edits = append(edits, edit{operation: insert, token: to[toIndex]})
+	}
+
+	return edits
+}
+
+// lcs returns the index pairs of the longest common subsequence of a and b.
+// The common prefix and suffix are matched first, the rest is compared only
+// when it fits in maxCells.
+func lcs(a []string, b []string) [][2]int {
+	var pairs [][2]int
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		pairs = append(pairs, [2]int{prefix, prefix})
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	middleA := a[prefix : len(a)-suffix]
+	middleB := b[prefix : len(b)-suffix]
+	rows, columns := len(middleA)+1, len(middleB)+1
+
+	if len(middleA) > 0 && len(middleB) > 0 && rows*columns <= maxCells {
+		// lengths[i*columns+j] is the length of the longest common subsequence
+		// of middleA[i:] and middleB[j:].
+		lengths := make([]int32, rows*columns)
+		for i := len(middleA) - 1; i >= 0; i-- {
+			for j := len(middleB) - 1; j >= 0; j-- {
+				if middleA[i] == middleB[j] {
+					lengths[i*columns+j] = lengths[(i+1)*columns+j+1] + 1
+				} else if lengths[(i+1)*columns+j] >= lengths[i*columns+j+1] {
+					lengths[i*columns+j] = lengths[(i+1)*columns+j]
+				} else {
+					lengths[i*columns+j] = lengths[i*columns+j+1]
+				}
+			}
+		}
+
+		i, j := 0, 0
+		for i < len(middleA) && j < len(middleB) {
+			switch {
+			case middleA[i] == middleB[j]:
+				pairs = append(pairs, [2]int{prefix + i, prefix + j})
+				i++
+				j++
+			case lengths[(i+1)*columns+j] >= lengths[i*columns+j+1]:
+				i++
+			default:
+				j++
+			}
+		}
+	}
+
+	for k := suffix; k > 0; k-- {
+		pairs = append(pairs, [2]int{len(a) - k, len(b) - k})
+	}
+
+	return pairs
+}
+
+// render writes the edits as HTML, wrapping the runs of added and removed text
+// in <ins> and <del>.
+func render(edits []edit) Result {
+	var result Result
+	var html strings.Builder
+
+	open := equal
+	closeRun := func() {
+		switch open {
+		case insert:
+			html.WriteString("</ins>")
+		case remove:
+			html.WriteString("</del>")
+		}
+		open = equal
+	}
+
+	for _, e := range edits {
+		if e.operation == equal {
+			closeRun()
+			html.WriteString(e.token)
+			continue
+		}
+
+		if strings.HasPrefix(e.token, "<") {
+			if e.operation == insert {
+				closeRun()
+				html.WriteString(e.token)
+			}
+			continue
+		}
+
+		if isSpace(e.token) {
+			if open != e.operation {
+				closeRun()
+			}
+			html.WriteString(e.token)
+			continue
+		}
+
+		if open != e.operation {
+			closeRun()
+			if e.operation == insert {
+				html.WriteString("<ins>")
+			} else {
+				html.WriteString("<del>")
+			}
+			open = e.operation
+		}
+
+		html.WriteString(e.token)
+		if e.operation == insert {
+			result.Insertions++
+		} else {
+			result.Deletions++
+		}
+	}
+
+	closeRun()
+	result.Html = html.String()
+
+	return result
+}
+
+func isSpace(token string) bool {
+	return strings.TrimFunc(token, unicode.IsSpace) == ""
+}
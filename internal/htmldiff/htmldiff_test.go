@@ -0,0 +1,116 @@
+package htmldiff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name           string
+		from           string
+		to             string
+		want           string
+		wantInsertions int
+		wantDeletions  int
+	}{
+		{
+			name: "same document",
+			from: "<p>Clean the roof.</p>",
+			to:   "<p>Clean the roof.</p>",
+			want: "<p>Clean the roof.</p>",
+		},
+		{
+			name:           "changed word",
+			from:           "<p>Clean the roof twice a year.</p>",
+			to:             "<p>Clean the roof once a year.</p>",
+			want:           "<p>Clean the roof <del>twice</del><ins>once</ins> a year.</p>",
+			wantInsertions: 1,
+			wantDeletions:  1,
+		},
+		{
+			name:           "added words are grouped",
+			from:           "<p>Clean the roof.</p>",
+			to:             "<p>Clean the whole flat roof.</p>",
+			want:           "<p>Clean the <ins>whole flat </ins>roof.</p>",
+			wantInsertions: 2,
+		},
+		{
+			name:           "added paragraph",
+			from:           "<h2>Roof</h2><p>Clean it.</p>",
+			to:             "<h2>Roof</h2><p>Check the gutters.</p><p>Clean it.</p>",
+			want:           "<h2>Roof</h2><p><ins>Check the gutters.</ins></p><p>Clean it.</p>",
+			wantInsertions: 3,
+		},
+		{
+			name:          "removed paragraph keeps no tags",
+			from:          "<p>Check the gutters.</p><p>Clean it.</p>",
+			to:            "<p>Clean it.</p>",
+			want:          "<del>Check the gutters.</del><p>Clean it.</p>",
+			wantDeletions: 3,
+		},
+		{
+			name:           "changed tag",
+			from:           "<p>Clean the <em>roof</em>.</p>",
+			to:             "<p>Clean the <strong>roof</strong>.</p>",
+			want:           "<p>Clean the <strong>roof</strong>.</p>",
+			wantInsertions: 0,
+		},
+		{
+			name:           "plain text",
+			from:           "Roof cleaning",
+			to:             "Roof painting",
+			want:           "Roof <del>cleaning</del><ins>painting</ins>",
+			wantInsertions: 1,
+			wantDeletions:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Diff(tt.from, tt.to)
+
+			if result.Html != tt.want {
+				t.Errorf("Html =\n%s\nwant\n%s", result.Html, tt.want)
+			}
+
+			if result.Insertions != tt.wantInsertions || result.Deletions != tt.wantDeletions {
+				t.Errorf("insertions, deletions = %d, %d, want %d, %d", result.Insertions, result.Deletions, tt.wantInsertions, tt.wantDeletions)
+			}
+		})
+	}
+}
+
+func TestDiffUnchangedBlocks(t *testing.T) {
+	// Only the changed paragraph is compared word by word, the others are kept
+	// as they are even when they share words with it.
+	paragraphs := make([]string, 0, 200)
+	for i := 0; i < 200; i++ {
+		paragraphs = append(paragraphs, "<p>Clean the roof and the gutters every spring.</p>")
+	}
+
+	from := strings.Join(paragraphs, "")
+	paragraphs[100] = "<p>Clean the roof and the gutters every autumn.</p>"
+	to := strings.Join(paragraphs, "")
+
+	result := Diff(from, to)
+
+	if result.Insertions != 1 || result.Deletions != 1 {
+		t.Errorf("insertions, deletions = %d, %d, want 1, 1", result.Insertions, result.Deletions)
+	}
+	if !strings.Contains(result.Html, "<del>spring.</del><ins>autumn.</ins>") {
+		t.Errorf("the changed word is not marked:\n%s", result.Html)
+	}
+}
+
+func TestDiffTooLong(t *testing.T) {
+	// Lists too long to compare are shown as replaced.
+	from := strings.Repeat("old ", 3000)
+	to := strings.Repeat("new ", 3000)
+
+	result := Diff(from, to)
+
+	if result.Insertions != 3000 || result.Deletions != 3000 {
+		t.Errorf("insertions, deletions = %d, %d, want 3000, 3000", result.Insertions, result.Deletions)
+	}
+}
@@ -0,0 +1,169 @@
+package language
+
+import (
+	"embed"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// profileSize is the number of the most frequent trigrams kept for a language
+// and for the text being detected. Larger profiles tell languages apart more
+// surely but need longer samples to be ranked reliably.
+const profileSize = 400
+
+// minDetectLetters is the number of letters below which a text is too short
+// for its language to be told.
+const minDetectLetters = 50
+
+// Profiles are built from sample texts, one per supported language, named
+// after the language code. Each sample covers many topics and kinds of text,
+// from news and letters to recipes and stories, so the profile does not lean
+// towards the vocabulary of a single subject.
+//
+//go:embed profiles/*.txt
+var samples embed.FS
+
+// profiles maps the language codes to the ranks of their most frequent
+// trigrams.
+var profiles = loadProfiles()
+
+func loadProfiles() map[string]map[string]int {
+	result := make(map[string]map[string]int)
+
+	for code := range languages {
+		sample, err := samples.ReadFile("profiles/" + code + ".txt")
+		if err != nil {
+			continue
+		}
+
+		result[code] = rank(trigrams(string(sample)))
+	}
+
+	return result
+}
+
+// Detection is the language a text is written in.
+type Detection struct {
+	// Code is the detected language, empty when the text is too short.
+	Code string
+	// Confidence ranges from 0, when the text is as close to another language,
+	// to 1.
+	Confidence float64
+}
+
+// Detect tells which of the supported languages text is written in, comparing
+// its character trigrams with the profile of each language.
+func Detect(text string) Detection {
+	counts := trigrams(text)
+
+	letters := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+
+	if letters < minDetectLetters || len(counts) == 0 {
+		return Detection{}
+	}
+
+	ranks := rank(counts)
+
+	type distance struct {
+		code     string
+		distance int
+	}
+
+	distances := make([]distance, 0, len(profiles))
+	for code, profile := range profiles {
+		distances = append(distances, distance{code: code, distance: outOfPlace(ranks, profile)})
+	}
+
+	if len(distances) == 0 {
+		return Detection{}
+	}
+
+	sort.Slice(distances, func(i, j int) bool {
+		if distances[i].distance == distances[j].distance {
+			return distances[i].code < distances[j].code
+		}
+		return distances[i].distance < distances[j].distance
+	})
+
+	detection := Detection{Code: distances[0].code, Confidence: 1}
+	if len(distances) > 1 && distances[1].distance > 0 {
+		detection.Confidence = float64(distances[1].distance-distances[0].distance) / float64(distances[1].distance)
+	}
+
+	return detection
+}
+
+// outOfPlace sums how far each trigram of the text is from its rank in the
+// language profile. Trigrams missing from the profile count as the largest
+// distance.
+func outOfPlace(ranks map[string]int, profile map[string]int) int {
+	total := 0
+
+	for trigram, position := range ranks {
+		profilePosition, ok := profile[trigram]
+		if !ok {
+			total += profileSize
+			continue
+		}
+
+		if profilePosition > position {
+			total += profilePosition - position
+		} else {
+			total += position - profilePosition
+		}
+	}
+
+	return total
+}
+
+// trigrams counts the character trigrams of the lower-case words of text. Words
+// are padded with a space, so the first and last letters form trigrams too.
+func trigrams(text string) map[string]int {
+	counts := make(map[string]int)
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	for _, word := range words {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			counts[string(runes[i:i+3])]++
+		}
+	}
+
+	return counts
+}
+
+// rank orders the trigrams from the most frequent and keeps profileSize of
+// them.
+func rank(counts map[string]int) map[string]int {
+	ordered := make([]string, 0, len(counts))
+	for trigram := range counts {
+		ordered = append(ordered, trigram)
+	}
+
+	sort.Slice(ordered, func(i, j int) bool {
+		if counts[ordered[i]] == counts[ordered[j]] {
+			return ordered[i] < ordered[j]
+		}
+		return counts[ordered[i]] > counts[ordered[j]]
+	})
+
+	if len(ordered) > profileSize {
+		ordered = ordered[:profileSize]
+	}
+
+	ranks := make(map[string]int, len(ordered))
+	for position, trigram := range ordered {
+		ranks[trigram] = position
+	}
+
+	return ranks
+}
@@ -0,0 +1,117 @@
+package language
+
+import (
+	"strings"
+	"testing"
+)
+
+// The snippets are on topics the profiles are not built from, so the tests do
+// not pass just because the profile has seen the text.
+var snippets = map[string][]string{
+	"en": {
+		"The tenant must give the landlord at least one month's notice in writing before moving out. If the flat is returned in the same condition as at the start of the tenancy, apart from normal wear and tear, the deposit has to be paid back within thirty days.",
+		"On a clear night away from city lights you can see the Milky Way stretching across the sky. Binoculars are enough to spot the moons of Jupiter, and a small telescope will show the rings of Saturn and the craters along the edge of the Moon's shadow.",
+		"Most job interviews start with the interviewer asking you to say a few words about yourself. Prepare a short answer that covers your experience, the skills that matter for the role and the reason you applied, and practise it until it sounds natural rather than learned by heart.",
+		"Antibiotics do not work against viruses, so they will not help with a cold or the flu. Taking them when they are not needed only makes bacteria more resistant, which means the drugs may fail when you really need them.",
+	},
+	"pl": {
+		"Najemca musi wypowiedzieć umowę na piśmie z co najmniej miesięcznym wyprzedzeniem. Jeżeli mieszkanie zostanie zwrócone w takim stanie, w jakim było na początku najmu, z wyjątkiem zwykłego zużycia, kaucja powinna zostać oddana w ciągu trzydziestu dni.",
+		"W pogodną noc, z dala od miejskich świateł, można zobaczyć Drogę Mleczną rozciągającą się przez całe niebo. Do obserwacji księżyców Jowisza wystarczy lornetka, a mały teleskop pokaże pierścienie Saturna i kratery na granicy cienia Księżyca.",
+		"Większość rozmów kwalifikacyjnych zaczyna się od prośby, by kandydat opowiedział o sobie. Przygotuj krótką odpowiedź o swoim doświadczeniu, umiejętnościach ważnych na danym stanowisku i powodach, dla których aplikujesz, i przećwicz ją, aż zabrzmi naturalnie.",
+		"Antybiotyki nie działają na wirusy, więc nie pomogą przy przeziębieniu ani grypie. Przyjmowanie ich bez potrzeby sprawia jedynie, że bakterie stają się bardziej oporne, a leki mogą zawieść wtedy, gdy naprawdę będą potrzebne.",
+	},
+	"de": {
+		"Der Mieter muss dem Vermieter mindestens einen Monat vor dem Auszug schriftlich kündigen. Wird die Wohnung im gleichen Zustand wie zu Beginn des Mietverhältnisses zurückgegeben, abgesehen von normaler Abnutzung, ist die Kaution innerhalb von dreißig Tagen zurückzuzahlen.",
+		"In einer klaren Nacht fern der Lichter der Stadt sieht man die Milchstraße über den ganzen Himmel ziehen. Ein Fernglas genügt, um die Monde des Jupiter zu entdecken, und ein kleines Teleskop zeigt die Ringe des Saturn und die Krater am Rand des Mondschattens.",
+		"Die meisten Vorstellungsgespräche beginnen mit der Bitte, etwas über sich zu erzählen. Bereiten Sie eine kurze Antwort über Ihre Erfahrung, die für die Stelle wichtigen Fähigkeiten und den Grund Ihrer Bewerbung vor und üben Sie sie, bis sie natürlich klingt.",
+		"Antibiotika wirken nicht gegen Viren und helfen deshalb weder bei einer Erkältung noch bei der Grippe. Wer sie ohne Not einnimmt, macht Bakterien nur widerstandsfähiger, sodass die Medikamente versagen können, wenn man sie wirklich braucht.",
+	},
+}
+
+// minConfidence is the least confidence Detect has to reach on the snippets. It
+// stays well above sourcecheck.MinLanguageConfidence, so a source written in
+// another language is rejected.
+const minConfidence = 0.25
+
+func TestDetect(t *testing.T) {
+	for code, texts := range snippets {
+		for _, text := range texts {
+			detection := Detect(text)
+
+			if detection.Code != code {
+				t.Errorf("Detect(%.40q...) = %s, want %s", text, detection.Code, code)
+				continue
+			}
+
+			if detection.Confidence < minConfidence {
+				t.Errorf("Detect(%.40q...) confidence = %.2f, want at least %.2f", text, detection.Confidence, minConfidence)
+			}
+		}
+	}
+}
+
+func TestDetectFirstSentence(t *testing.T) {
+	// A single sentence of a page, e.g. its lead, is enough to tell the language.
+	for code, texts := range snippets {
+		for _, text := range texts {
+			sentence := text[:strings.Index(text, ".")+1]
+
+			if detection := Detect(sentence); detection.Code != code {
+				t.Errorf("Detect(%q) = %s (confidence %.2f), want %s", sentence, detection.Code, detection.Confidence, code)
+			}
+		}
+	}
+}
+
+func TestDetectForeignWords(t *testing.T) {
+	// Polish pages about technology are full of English names.
+	text := "Nowy smartfon ma ekran OLED, procesor Snapdragon i obsługuje ładowanie bezprzewodowe. Aplikacja Google Maps działa w trybie offline, a w ustawieniach można włączyć dark mode i funkcję smart home, która steruje oświetleniem w całym mieszkaniu."
+
+	if detection := Detect(text); detection.Code != "pl" {
+		t.Errorf("Detect = %s (confidence %.2f), want pl", detection.Code, detection.Confidence)
+	}
+}
+
+func TestDetectMixed(t *testing.T) {
+	// A page half in one language and half in another is as close to both,
+	// below sourcecheck.MinLanguageConfidence, so it is not rejected.
+	const maxConfidence = 0.07
+
+	for _, pair := range [][2]string{{"en", "pl"}, {"pl", "en"}, {"en", "de"}, {"pl", "de"}} {
+		for i := range snippets[pair[0]] {
+			text := snippets[pair[0]][i] + " " + snippets[pair[1]][i]
+
+			if detection := Detect(text); detection.Confidence > maxConfidence {
+				t.Errorf("Detect(%s+%s snippet %d) = %s with confidence %.2f, want at most %.2f", pair[0], pair[1], i, detection.Code, detection.Confidence, maxConfidence)
+			}
+		}
+	}
+}
+
+func TestDetectTooShort(t *testing.T) {
+	for _, text := range []string{"", "Kontakt", "Home | About us | Contact", "1234 5678 !!!"} {
+		if detection := Detect(text); detection.Code != "" {
+			t.Errorf("Detect(%q) = %s, want no language", text, detection.Code)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"pl":        "pl",
+		"PL":        "pl",
+		"pl_PL":     "pl",
+		"de-DE":     "de",
+		"polski":    "pl",
+		"German":    "de",
+		" english ": "en",
+		"fr":        "",
+		"":          "",
+	}
+
+	for value, want := range tests {
+		if got := Normalize(value); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
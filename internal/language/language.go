@@ -0,0 +1,110 @@
+package language
+
+import (
+	"sort"
+	"strings"
+)
+
+// Default is the language used when a domain or a question category does not
+// specify one. Octo-pulse started as a Polish-only generator.
+const Default = "pl"
+
+// Language describes a language articles can be generated in.
+type Language struct {
+	// Code is the ISO 639-1 code used for slugs, prompts and the html lang attribute.
+	Code string
+	// Name is the English name of the language, used inside prompts.
+	Name string
+	// Locale is the default locale for domains in this language.
+	Locale string
+	// SummaryLabel prefixes the source summary passed to the model.
+	SummaryLabel string
+	// PendingContent is the body of an article whose description is not generated yet.
+	PendingContent string
+	// aliases are lower-case names the language may be stored under.
+	aliases []string
+}
+
+var languages = map[string]Language{
+	"pl": {
+		Code:           "pl",
+		Name:           "Polish",
+		Locale:         "pl_PL",
+		SummaryLabel:   "Streszczenie: ",
+		PendingContent: "Treść w przygotowaniu",
+		aliases:        []string{"pol", "polish", "polski"},
+	},
+	"en": {
+		Code:           "en",
+		Name:           "English",
+		Locale:         "en_US",
+		SummaryLabel:   "Summary: ",
+		PendingContent: "Content in preparation",
+		aliases:        []string{"eng", "english", "angielski"},
+	},
+	"de": {
+		Code:           "de",
+		Name:           "German",
+		Locale:         "de_DE",
+		SummaryLabel:   "Zusammenfassung: ",
+		PendingContent: "Inhalt in Vorbereitung",
+		aliases:        []string{"deu", "ger", "german", "deutsch", "niemiecki"},
+	},
+}
+
+// Get returns the language for code, falling back to Default when the code
+// is not supported.
+func Get(code string) Language {
+	if lang, ok := languages[Normalize(code)]; ok {
+		return lang
+	}
+
+	return languages[Default]
+}
+
+// IsSupported reports whether code is one of the supported language codes.
+func IsSupported(code string) bool {
+	_, ok := languages[code]
+	return ok
+}
+
+// Codes returns the supported language codes in alphabetical order.
+func Codes() []string {
+	codes := make([]string, 0, len(languages))
+	for code := range languages {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	return codes
+}
+
+// Normalize maps a language code, locale (pl_PL, de-DE) or language name
+// (polski, German) to a supported ISO 639-1 code. It returns an empty string
+// when value does not match any supported language.
+func Normalize(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return ""
+	}
+
+	if _, ok := languages[value]; ok {
+		return value
+	}
+
+	if i := strings.IndexAny(value, "_-"); i > 0 {
+		if _, ok := languages[value[:i]]; ok {
+			return value[:i]
+		}
+	}
+
+	for code, lang := range languages {
+		for _, alias := range lang.aliases {
+			if alias == value {
+				return code
+			}
+		}
+	}
+
+	return ""
+}
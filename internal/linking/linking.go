@@ -0,0 +1,181 @@
+package linking
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultLimit is the number of links added to a single article.
+const DefaultLimit = 3
+
+// minPhraseLength skips phrases so short they would match almost anywhere.
+const minPhraseLength = 4
+
+// Target is an article other articles can link to.
+type Target struct {
+	ArticleId int
+	Path      string
+	// Phrases are linked when found in the text, e.g. the title and the focus
+	// keyword of the article.
+	Phrases []string
+}
+
+// Link is a link inserted into an article.
+type Link struct {
+	ArticleId int
+	Anchor    string
+}
+
+var (
+	htmlTag = regexp.MustCompile(`<[^>]*>`)
+	// tagName matches the name of an opening or closing tag.
+	tagName = regexp.MustCompile(`^</?\s*([a-zA-Z0-9]+)`)
+	// trailingPunctuation is dropped from titles, most of them are questions.
+	trailingPunctuation = regexp.MustCompile(`[\s?!.:;,]+$`)
+)
+
+// Path returns the path of the article with the given slug.
+func Path(slug string) string {
+	return "/" + slug
+}
+
+// Insert links the first occurrence of a phrase of each target in body, up to
+// limit links. Text inside links and headings is left alone. Links added by an
+// earlier call are removed first, so body can be linked again. Phrases are
+// matched case-insensitively on word boundaries and longer phrases win.
+func Insert(body string, targets []Target, limit int) (string, []Link) {
+	body = RemoveAll(body)
+
+	type candidate struct {
+		target  Target
+		phrase  string
+		pattern *regexp.Regexp
+	}
+
+	var candidates []candidate
+	for _, target := range targets {
+		for _, phrase := range target.Phrases {
+			phrase = strings.TrimSpace(trailingPunctuation.ReplaceAllString(phrase, ""))
+			if utf8.RuneCountInString(phrase) < minPhraseLength {
+				continue
+			}
+
+			candidates = append(candidates, candidate{
+				target:  target,
+				phrase:  phrase,
+				pattern: regexp.MustCompile(`(?i)(?:^|[^\p{L}\p{N}])(` + regexp.QuoteMeta(phrase) + `)(?:[^\p{L}\p{N}]|$)`),
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return utf8.RuneCountInString(candidates[i].phrase) > utf8.RuneCountInString(candidates[j].phrase)
+	})
+
+	var links []Link
+	linked := make(map[int]bool)
+
+	for _, candidate := range candidates {
+		if len(links) >= limit {
+			break
+		}
+
+		if linked[candidate.target.ArticleId] {
+			continue
+		}
+
+		var anchor string
+		body, anchor = linkFirst(body, candidate.pattern, candidate.target)
+		if anchor == "" {
+			continue
+		}
+
+		linked[candidate.target.ArticleId] = true
+		links = append(links, Link{ArticleId: candidate.target.ArticleId, Anchor: anchor})
+	}
+
+	return body, links
+}
+
+// linkFirst wraps the first match of pattern outside links and headings. It
+// returns the matched text, empty when nothing was linked.
+func linkFirst(body string, pattern *regexp.Regexp, target Target) (string, string) {
+	skipDepth := 0
+	position := 0
+
+	// The sentinel tag appended to body makes the loop visit the text after
+	// the last real tag.
+	for _, tag := range htmlTag.FindAllStringIndex(body+"<>", -1) {
+		text := body[position:min(tag[0], len(body))]
+
+		if skipDepth == 0 {
+			if match := pattern.FindStringSubmatchIndex(text); match != nil {
+				start, end := position+match[2], position+match[3]
+				anchor := body[start:end]
+				link := fmt.Sprintf(`<a href="%s" data-article-id="%d">%s</a>`, target.Path, target.ArticleId, anchor)
+
+				return body[:start] + link + body[end:], anchor
+			}
+		}
+
+		if tag[0] >= len(body) {
+			break
+		}
+
+		if name := tagName.FindStringSubmatch(body[tag[0]:tag[1]]); name != nil && skipsLinking(name[1]) {
+			if strings.HasPrefix(body[tag[0]:tag[1]], "</") {
+				if skipDepth > 0 {
+					skipDepth--
+				}
+			} else {
+				skipDepth++
+			}
+		}
+
+		position = tag[1]
+	}
+
+	return body, ""
+}
+
+func skipsLinking(tag string) bool {
+	switch strings.ToLower(tag) {
+	case "a", "h1", "h2", "h3", "h4", "h5", "h6":
+		return true
+	}
+
+	return false
+}
+
+// Remove unwraps the links to the article added by Insert, keeping their text.
+func Remove(body string, articleId int) string {
+	return linkTo(articleId).ReplaceAllString(body, "$1")
+}
+
+// RemoveAll unwraps every link added by Insert.
+func RemoveAll(body string) string {
+	return linkTo(0).ReplaceAllString(body, "$1")
+}
+
+// linkTo matches the links to the article added by Insert, or to any article
+// when articleId is 0.
+func linkTo(articleId int) *regexp.Regexp {
+	id := `\d+`
+	if articleId != 0 {
+		id = strconv.Itoa(articleId)
+	}
+
+	return regexp.MustCompile(`<a href="[^"]*" data-article-id="` + id + `">(.*?)</a>`)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,148 @@
+package linking
+
+import (
+	"strings"
+	"testing"
+)
+
+var roofTarget = Target{ArticleId: 7, Path: Path("jak-wyczyscic-dach"), Phrases: []string{"Jak wyczyścić dach?", "czyszczenie dachu"}}
+
+func TestInsert(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		targets   []Target
+		limit     int
+		want      string
+		wantLinks int
+	}{
+		{
+			name:      "links the first occurrence",
+			body:      "<p>Regularne czyszczenie dachu przedłuża jego życie. Czyszczenie dachu warto zlecić firmie.</p>",
+			targets:   []Target{roofTarget},
+			limit:     DefaultLimit,
+			want:      `<p>Regularne <a href="/jak-wyczyscic-dach" data-article-id="7">czyszczenie dachu</a> przedłuża jego życie. Czyszczenie dachu warto zlecić firmie.</p>`,
+			wantLinks: 1,
+		},
+		{
+			name:      "keeps the case of the text and ignores the question mark",
+			body:      "<p>Wiele osób pyta: jak wyczyścić dach bez myjki?</p>",
+			targets:   []Target{roofTarget},
+			limit:     DefaultLimit,
+			want:      `<p>Wiele osób pyta: <a href="/jak-wyczyscic-dach" data-article-id="7">jak wyczyścić dach</a> bez myjki?</p>`,
+			wantLinks: 1,
+		},
+		{
+			name:      "skips headings",
+			body:      "<h2>Czyszczenie dachu</h2><p>O czym pamiętać, gdy planujemy czyszczenie dachu?</p>",
+			targets:   []Target{roofTarget},
+			limit:     DefaultLimit,
+			want:      `<h2>Czyszczenie dachu</h2><p>O czym pamiętać, gdy planujemy <a href="/jak-wyczyscic-dach" data-article-id="7">czyszczenie dachu</a>?</p>`,
+			wantLinks: 1,
+		},
+		{
+			name:      "skips headings with attributes and nested tags",
+			body:      `<h3 class="faq"><strong>Czyszczenie dachu</strong> krok po kroku</h3>`,
+			targets:   []Target{roofTarget},
+			limit:     DefaultLimit,
+			want:      `<h3 class="faq"><strong>Czyszczenie dachu</strong> krok po kroku</h3>`,
+			wantLinks: 0,
+		},
+		{
+			name:      "skips existing links",
+			body:      `<p>Zobacz <a href="https://example.com">czyszczenie dachu</a> u producenta.</p>`,
+			targets:   []Target{roofTarget},
+			limit:     DefaultLimit,
+			want:      `<p>Zobacz <a href="https://example.com">czyszczenie dachu</a> u producenta.</p>`,
+			wantLinks: 0,
+		},
+		{
+			name:      "matches whole words only",
+			body:      "<p>Przeczyszczenie dachuwki nie jest potrzebne.</p>",
+			targets:   []Target{roofTarget},
+			limit:     DefaultLimit,
+			want:      "<p>Przeczyszczenie dachuwki nie jest potrzebne.</p>",
+			wantLinks: 0,
+		},
+		{
+			name: "longer phrases win",
+			body: "<p>Malowanie dachu blaszanego wymaga podkładu.</p>",
+			targets: []Target{
+				{ArticleId: 1, Path: "/malowanie-dachu", Phrases: []string{"malowanie dachu"}},
+				{ArticleId: 2, Path: "/malowanie-dachu-blaszanego", Phrases: []string{"malowanie dachu blaszanego"}},
+			},
+			limit:     DefaultLimit,
+			want:      `<p><a href="/malowanie-dachu-blaszanego" data-article-id="2">Malowanie dachu blaszanego</a> wymaga podkładu.</p>`,
+			wantLinks: 1,
+		},
+		{
+			name: "stops at the limit, longer phrases first",
+			body: "<p>Rynny, kominy i okna dachowe.</p>",
+			targets: []Target{
+				{ArticleId: 1, Path: "/rynny", Phrases: []string{"rynny"}},
+				{ArticleId: 2, Path: "/kominy", Phrases: []string{"kominy"}},
+				{ArticleId: 3, Path: "/okna", Phrases: []string{"okna dachowe"}},
+			},
+			limit:     2,
+			want:      `<p>Rynny, <a href="/kominy" data-article-id="2">kominy</a> i <a href="/okna" data-article-id="3">okna dachowe</a>.</p>`,
+			wantLinks: 2,
+		},
+		{
+			name:      "ignores short phrases",
+			body:      "<p>Dom i ogród.</p>",
+			targets:   []Target{{ArticleId: 1, Path: "/dom", Phrases: []string{"Dom?"}}},
+			limit:     DefaultLimit,
+			want:      "<p>Dom i ogród.</p>",
+			wantLinks: 0,
+		},
+		{
+			name:      "links text after the last tag",
+			body:      "<p>Wstęp</p>Na koniec czyszczenie dachu",
+			targets:   []Target{roofTarget},
+			limit:     DefaultLimit,
+			want:      `<p>Wstęp</p>Na koniec <a href="/jak-wyczyscic-dach" data-article-id="7">czyszczenie dachu</a>`,
+			wantLinks: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, links := Insert(tt.body, tt.targets, tt.limit)
+
+			if got != tt.want {
+				t.Errorf("Insert =\n%s\nwant\n%s", got, tt.want)
+			}
+
+			if len(links) != tt.wantLinks {
+				t.Errorf("got %d links, want %d", len(links), tt.wantLinks)
+			}
+		})
+	}
+}
+
+func TestInsertAgain(t *testing.T) {
+	body := "<p>Czyszczenie dachu i malowanie dachu.</p>"
+	paint := Target{ArticleId: 8, Path: "/malowanie-dachu", Phrases: []string{"malowanie dachu"}}
+
+	linked, _ := Insert(body, []Target{roofTarget}, DefaultLimit)
+	relinked, links := Insert(linked, []Target{paint}, DefaultLimit)
+
+	if strings.Contains(relinked, `data-article-id="7"`) {
+		t.Error("the links of the earlier call were kept")
+	}
+	if len(links) != 1 || links[0].ArticleId != 8 || links[0].Anchor != "malowanie dachu" {
+		t.Errorf("links = %+v, want the link to article 8", links)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	body := `<p><a href="/a" data-article-id="1">Rynny</a>, <a href="/b" data-article-id="2">kominy</a> i <a href="https://example.com">okna</a>.</p>`
+
+	if got, want := Remove(body, 1), `<p>Rynny, <a href="/b" data-article-id="2">kominy</a> i <a href="https://example.com">okna</a>.</p>`; got != want {
+		t.Errorf("Remove =\n%s\nwant\n%s", got, want)
+	}
+
+	if got, want := RemoveAll(body), `<p>Rynny, kominy i <a href="https://example.com">okna</a>.</p>`; got != want {
+		t.Errorf("RemoveAll =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
 
 type Article struct {
 	ID              int       `json:"id"`
@@ -18,4 +22,37 @@ type Article struct {
 	IsSponsored     bool      `json:"isSponsored"`
 	CreatedAt       time.Time `json:"createdAt"`
 	UpdatedAt       time.Time `json:"updatedAt"`
+	MetaTitle       string    `json:"metaTitle"`
+	MetaDescription string    `json:"metaDescription"`
+	FocusKeyword    string    `json:"focusKeyword"`
+	Excerpt         string    `json:"excerpt"`
+	// SourceOverlap is the share of the body copied from the sources it was
+	// generated from, nil when the body was not checked. It is read only, the
+	// value of a request body is dropped.
+	SourceOverlap *float64 `json:"sourceOverlap"`
+	// QuestionId is the scrapper question the article answers, nil for articles
+	// not generated from a question.
+	QuestionId *int `json:"questionId"`
+	// Status is the step of the editorial workflow the article is at. It is
+	// changed with the status transitions only, IsPublished follows it.
+	Status string `json:"status"`
+}
+
+// UnmarshalJSON decodes the article like the default decoder, rejecting unknown
+// fields, and drops SourceOverlap.
+func (a *Article) UnmarshalJSON(data []byte) error {
+	type article Article
+
+	var decoded article
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&decoded); err != nil {
+		return err
+	}
+
+	decoded.SourceOverlap = nil
+	*a = Article(decoded)
+
+	return nil
 }
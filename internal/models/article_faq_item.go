@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// ArticleFaqItem is a question answered in the FAQ section of an article.
+type ArticleFaqItem struct {
+	ArticleId int       `json:"articleId"`
+	Position  int       `json:"position"`
+	Question  string    `json:"question"`
+	Answer    string    `json:"answer"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// ArticleGenerationStep is the output of one step of the article generation,
+// e.g. the agenda or a single section. Steps are kept until the article body is
+// saved, so a failed generation resumes where it stopped.
+type ArticleGenerationStep struct {
+	ArticleId int       `json:"articleId"`
+	Step      string    `json:"step"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
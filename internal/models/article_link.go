@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// ArticleLink is an internal link from one article body to another article.
+type ArticleLink struct {
+	SourceArticleId int       `json:"sourceArticleId"`
+	TargetArticleId int       `json:"targetArticleId"`
+	Anchor          string    `json:"anchor"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
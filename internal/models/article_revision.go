@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Sources of article revisions not made by a task. Revisions made by a task
+// are recorded under its task type, e.g. "article:generateDescription".
+const (
+	// ArticleRevisionSourceInitial is the content articles had when revisions
+	// started to be recorded.
+	ArticleRevisionSourceInitial          = "initial"
+	ArticleRevisionSourceCreate           = "create"
+	ArticleRevisionSourceDashboard        = "dashboard"
+	ArticleRevisionSourceRestore          = "restore"
+	ArticleRevisionSourceRemoveDuplicates = "remove_duplicates"
+	// ArticleRevisionSourceUnlink is the removal of the links to a deleted
+	// article.
+	ArticleRevisionSourceUnlink = "unlink"
+)
+
+// ArticleRevision is the content of an article after a change. The latest
+// revision matches the current content of the article.
+type ArticleRevision struct {
+	ID              int    `json:"id"`
+	ArticleId       int    `json:"articleId"`
+	Title           string `json:"title"`
+	Slug            string `json:"slug"`
+	Body            string `json:"body"`
+	MetaTitle       string `json:"metaTitle"`
+	MetaDescription string `json:"metaDescription"`
+	FocusKeyword    string `json:"focusKeyword"`
+	Excerpt         string `json:"excerpt"`
+	ArticleRevisionAuthor
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ArticleRevisionAuthor tells who or what changed an article.
+type ArticleRevisionAuthor struct {
+	// Source is the task type or one of the ArticleRevisionSource constants.
+	Source string `json:"source"`
+	// UserId is the dashboard user who made the change, nil for the workers.
+	UserId *int `json:"userId"`
+	// RestoredFromId is the revision brought back by a restore.
+	RestoredFromId *int `json:"restoredFromId"`
+	// Refresh marks the rewrites of the scheduled refresh of stale articles.
+	// They count towards the daily refresh quota of the domain.
+	Refresh bool `json:"refresh"`
+}
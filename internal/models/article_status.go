@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Statuses of the editorial workflow of an article. Only published articles
+// are shown on the domain.
+const (
+	ArticleStatusDraft       = "draft"
+	ArticleStatusGenerating  = "generating"
+	ArticleStatusNeedsReview = "needs_review"
+	ArticleStatusApproved    = "approved"
+	ArticleStatusScheduled   = "scheduled"
+	ArticleStatusPublished   = "published"
+	ArticleStatusArchived    = "archived"
+)
+
+// ArticleStatusTransition records a change of the status of an article. UserId
+// is nil for changes made by the workers, e.g. when the generation finishes.
+type ArticleStatusTransition struct {
+	ID         int       `json:"id"`
+	ArticleId  int       `json:"articleId"`
+	FromStatus string    `json:"fromStatus"`
+	ToStatus   string    `json:"toStatus"`
+	UserId     *int      `json:"userId"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// ArticleTranslation links an article to the article it was translated from.
+// SourceArticleId is the original article of the group, also for translations
+// of translations, so every translation of an article shares it.
+type ArticleTranslation struct {
+	ArticleId       int       `json:"articleId"`
+	SourceArticleId int       `json:"sourceArticleId"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
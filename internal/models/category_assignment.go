@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// How a category was chosen for a question.
+const (
+	// CategoryAssignmentMethodEmbedding picked the most similar category.
+	CategoryAssignmentMethodEmbedding = "embedding"
+	// CategoryAssignmentMethodLLM asked the model to choose between categories
+	// scored too close to each other.
+	CategoryAssignmentMethodLLM = "llm"
+	// CategoryAssignmentMethodBelowThreshold found no category similar enough.
+	CategoryAssignmentMethodBelowThreshold = "below_threshold"
+)
+
+// CategoryAssignment records the category chosen for a scrapper question and
+// how confident the choice was. CategoryId is nil when no category fits.
+type CategoryAssignment struct {
+	ID                 int       `json:"id"`
+	QuestionId         int       `json:"questionId"`
+	DomainId           int       `json:"domainId"`
+	CategoryId         *int      `json:"categoryId"`
+	Score              float64   `json:"score"`
+	RunnerUpCategoryId *int      `json:"runnerUpCategoryId"`
+	RunnerUpScore      *float64  `json:"runnerUpScore"`
+	Method             string    `json:"method"`
+	Model              string    `json:"model"`
+	CreatedAt          time.Time `json:"createdAt"`
+}
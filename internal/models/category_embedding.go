@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// CategoryEmbedding is the cached embedding of a category name. TextHash tells
+// whether the name changed since the embedding was computed.
+type CategoryEmbedding struct {
+	CategoryId int       `json:"categoryId"`
+	Model      string    `json:"model"`
+	TextHash   string    `json:"textHash"`
+	Embedding  []float32 `json:"embedding"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
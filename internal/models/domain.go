@@ -6,6 +6,14 @@ type Domain struct {
 	ID        int       `json:"id"`
 	Name      string    `json:"name" validate:"required"`
 	Email     string    `json:"email" validate:"required"`
+	Language  string    `json:"language" validate:"required,language"`
+	Locale    string    `json:"locale" validate:"required"`
 	CreatedAt time.Time `json:"createdAt" validate:"required"`
 	UpdatedAt time.Time `json:"updatedAt" validate:"required"`
+	// RefreshAfterDays is the age after which an article is rewritten, 0 to
+	// only rewrite articles that overlap their sources too much.
+	RefreshAfterDays int `json:"refreshAfterDays" validate:"min=0"`
+	// RefreshDailyQuota is the number of articles rewritten a day, 0 disables
+	// refreshing the domain.
+	RefreshDailyQuota int `json:"refreshDailyQuota" validate:"min=0"`
 }
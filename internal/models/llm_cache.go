@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// LlmCacheEntry is a completion stored under the hash of the request that
+// produced it.
+type LlmCacheEntry struct {
+	Key              string    `json:"key"`
+	Model            string    `json:"model"`
+	Content          string    `json:"content"`
+	PromptTokens     int       `json:"promptTokens"`
+	CompletionTokens int       `json:"completionTokens"`
+	TotalTokens      int       `json:"totalTokens"`
+	ExpiresAt        time.Time `json:"expiresAt"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// LlmUsage is the token usage of one model, accumulated for an article and the
+// task that generated it.
+type LlmUsage struct {
+	ID               int       `json:"id"`
+	ArticleId        *int      `json:"articleId"`
+	DomainId         int       `json:"domainId"`
+	Task             string    `json:"task"`
+	BatchId          *string   `json:"batchId"`
+	Model            string    `json:"model"`
+	PromptTokens     int       `json:"promptTokens"`
+	CompletionTokens int       `json:"completionTokens"`
+	TotalTokens      int       `json:"totalTokens"`
+	Cost             float64   `json:"cost"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// LlmModelPrice holds the price of a model in USD per 1000 tokens.
+type LlmModelPrice struct {
+	Model           string    `json:"model" validate:"required"`
+	PromptPrice     float64   `json:"promptPrice" validate:"gte=0"`
+	CompletionPrice float64   `json:"completionPrice" validate:"gte=0"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+type PromptTemplate struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name" validate:"required"`
+	Version   int       `json:"version"`
+	DomainId  *int      `json:"domainId"`
+	Language  string    `json:"language" validate:"required,language"`
+	Body      string    `json:"body" validate:"required"`
+	IsActive  bool      `json:"isActive"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type ArticlePromptVersion struct {
+	ArticleId int       `json:"articleId"`
+	Name      string    `json:"name"`
+	Version   int       `json:"version"`
+	Source    string    `json:"source"`
+	Language  string    `json:"language"`
+	CreatedAt time.Time `json:"createdAt"`
+}
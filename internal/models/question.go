@@ -7,6 +7,7 @@ type Question struct {
 	Href         string                 `json:"href"`
 	Fetched      int                    `json:"fetched"`
 	CategoryId   int                    `json:"categoryId"`
+	PhraseId     int                    `json:"phraseId"`
 	PageContents []*QuestionPageContent `json:"pageContents"`
 }
 
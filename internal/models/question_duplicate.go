@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// QuestionDuplicate records a scrapper question skipped because it duplicates
+// an article of the domain or another question of the same batch. Signal is
+// one of the dedup signals.
+type QuestionDuplicate struct {
+	ID                    int       `json:"id"`
+	QuestionId            int       `json:"questionId"`
+	DomainId              int       `json:"domainId"`
+	DuplicateOfArticleId  *int      `json:"duplicateOfArticleId"`
+	DuplicateOfQuestionId *int      `json:"duplicateOfQuestionId"`
+	Signal                string    `json:"signal"`
+	Score                 float64   `json:"score"`
+	BatchId               string    `json:"batchId"`
+	CreatedAt             time.Time `json:"createdAt"`
+}
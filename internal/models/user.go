@@ -17,3 +17,9 @@ type UserRoles struct {
 	Admin  int
 	Editor int
 }
+
+// DefaultUserRoles are the ids of the roles seeded with the database.
+var DefaultUserRoles = UserRoles{
+	Admin:  1,
+	Editor: 2,
+}
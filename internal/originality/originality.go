@@ -0,0 +1,120 @@
+package originality
+
+import (
+	"hash/fnv"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ShingleSize is the number of consecutive words in a shingle. Five words are
+// rarely repeated by chance, but a copied sentence shares several shingles.
+const ShingleSize = 5
+
+// DefaultThreshold is the share of the article shingles found in the sources
+// above which an article is not published automatically.
+const DefaultThreshold = 0.25
+
+// Report tells how much of an article overlaps with its sources. Scores range
+// from 0 (nothing in common) to 1.
+type Report struct {
+	// Overlap is the share of the article shingles that appear in any source.
+	// It is the score compared against the threshold.
+	Overlap float64
+	// MaxContainment is the highest share of the article shingles found in a
+	// single source, MaxContainmentSource is the index of that source.
+	MaxContainment       float64
+	MaxContainmentSource int
+	// MaxJaccard is the highest Jaccard similarity between the article and a
+	// single source.
+	MaxJaccard float64
+}
+
+type shingles map[uint64]struct{}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// Check compares the article text against each source. Both may contain HTML,
+// tags are ignored.
+func Check(article string, sources []string) Report {
+	report := Report{MaxContainmentSource: -1}
+
+	articleShingles := shingle(article)
+	if len(articleShingles) == 0 {
+		return report
+	}
+
+	copied := make(shingles)
+
+	for index, source := range sources {
+		sourceShingles := shingle(source)
+		if len(sourceShingles) == 0 {
+			continue
+		}
+
+		common := 0
+		for hash := range articleShingles {
+			if _, ok := sourceShingles[hash]; ok {
+				common++
+				copied[hash] = struct{}{}
+			}
+		}
+
+		containment := float64(common) / float64(len(articleShingles))
+		if containment > report.MaxContainment {
+			report.MaxContainment = containment
+			report.MaxContainmentSource = index
+		}
+
+		jaccard := float64(common) / float64(len(articleShingles)+len(sourceShingles)-common)
+		if jaccard > report.MaxJaccard {
+			report.MaxJaccard = jaccard
+		}
+	}
+
+	report.Overlap = float64(len(copied)) / float64(len(articleShingles))
+
+	return report
+}
+
+// shingle returns the hashes of every ShingleSize consecutive words of text.
+// Texts shorter than ShingleSize words form a single shingle.
+func shingle(text string) shingles {
+	words := normalize(text)
+	result := make(shingles)
+
+	if len(words) == 0 {
+		return result
+	}
+
+	if len(words) < ShingleSize {
+		result[hash(words)] = struct{}{}
+		return result
+	}
+
+	for i := 0; i+ShingleSize <= len(words); i++ {
+		result[hash(words[i:i+ShingleSize])] = struct{}{}
+	}
+
+	return result
+}
+
+// normalize strips the HTML tags and splits text into lower-case words, so
+// punctuation and formatting do not hide a copied passage.
+func normalize(text string) []string {
+	text = htmlTag.ReplaceAllString(text, " ")
+
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func hash(words []string) uint64 {
+	h := fnv.New64a()
+	for _, word := range words {
+		h.Write([]byte(word))
+		h.Write([]byte{0})
+	}
+
+	return h.Sum64()
+}
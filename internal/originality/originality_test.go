@@ -0,0 +1,95 @@
+package originality
+
+import (
+	"math"
+	"testing"
+)
+
+const source = `<p>Before painting the roof, clean it with a pressure washer and let it dry for at least two days. Moss and lichen have to be removed with a brush, otherwise the paint will peel off within a year.</p>`
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		article     string
+		sources     []string
+		wantOverlap float64
+		wantSource  int
+	}{
+		{
+			name:        "copied text, formatting and case do not hide it",
+			article:     `<p>BEFORE painting the roof, <strong>clean it</strong> with a pressure washer, and let it dry for at least two days!</p>`,
+			sources:     []string{"<p>Unrelated text about gardens and lawns in the spring.</p>", source},
+			wantOverlap: 1,
+			wantSource:  1,
+		},
+		{
+			name:        "rewritten text",
+			article:     "<p>Wash the tiles thoroughly first and wait a couple of dry days, then scrub away any moss so that the new coating lasts.</p>",
+			sources:     []string{source},
+			wantOverlap: 0,
+			wantSource:  -1,
+		},
+		{
+			name: "half copied",
+			// 10 words, 6 shingles, 3 of them copied.
+			article:     "Moss and lichen have to be removed every single week",
+			sources:     []string{source},
+			wantOverlap: 0.5,
+			wantSource:  0,
+		},
+		{
+			name:        "no sources",
+			article:     source,
+			sources:     nil,
+			wantOverlap: 0,
+			wantSource:  -1,
+		},
+		{
+			name:        "empty article",
+			article:     "<p></p>",
+			sources:     []string{source},
+			wantOverlap: 0,
+			wantSource:  -1,
+		},
+		{
+			name:        "article shorter than a shingle",
+			article:     "Moss and lichen",
+			sources:     []string{"Moss and lichen", source},
+			wantOverlap: 1,
+			wantSource:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Check(tt.article, tt.sources)
+
+			if math.Abs(report.Overlap-tt.wantOverlap) > 0.01 {
+				t.Errorf("overlap = %.2f, want %.2f", report.Overlap, tt.wantOverlap)
+			}
+
+			if report.MaxContainmentSource != tt.wantSource {
+				t.Errorf("source = %d, want %d", report.MaxContainmentSource, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestCheckAcrossSources(t *testing.T) {
+	// Each source holds half of the article: no single source is above the
+	// threshold, together they are.
+	first := "the roof has to be cleaned with a pressure washer"
+	second := "and the gutters have to be emptied before the winter"
+
+	report := Check(first+" "+second, []string{first, second})
+
+	if report.MaxContainment >= 0.5 {
+		t.Errorf("max containment = %.2f, want below 0.5", report.MaxContainment)
+	}
+	if report.Overlap <= DefaultThreshold || report.Overlap < 2*report.MaxContainment-0.01 {
+		t.Errorf("overlap = %.2f, want the shingles of both sources counted", report.Overlap)
+	}
+	if report.MaxJaccard <= 0 || report.MaxJaccard > report.MaxContainment {
+		t.Errorf("max jaccard = %.2f, want between 0 and the max containment", report.MaxJaccard)
+	}
+}
@@ -13,17 +13,19 @@ import (
 )
 
 type ApiControllers struct {
-	Auth      *controllers.AuthController
-	Article   *controllers.ArticleController
-	Task      *controllers.TaskController
-	Domain    *controllers.DomainController
-	Category  *controllers.CategoryController
-	File      *controllers.FileController
-	Image     *controllers.ImageController
-	BasicPage *controllers.BasicPageController
-	Email     *controllers.EmailController
-	Author    *controllers.AuthorController
-	Scrapper  *controllers.ScrapperController
+	Auth           *controllers.AuthController
+	Article        *controllers.ArticleController
+	Task           *controllers.TaskController
+	Domain         *controllers.DomainController
+	Category       *controllers.CategoryController
+	File           *controllers.FileController
+	Image          *controllers.ImageController
+	BasicPage      *controllers.BasicPageController
+	Email          *controllers.EmailController
+	Author         *controllers.AuthorController
+	Scrapper       *controllers.ScrapperController
+	PromptTemplate *controllers.PromptTemplateController
+	Usage          *controllers.UsageController
 }
 
 type ApiServices struct {
@@ -40,8 +42,9 @@ func NewApiRoutes(controllers ApiControllers, services ApiServices, tasks *tasks
 	r.Route("/api/v1", func(r chi.Router) {
 		r.Use(middlewares.RequireApiKey)
 
-		r.Get("/articles", api.MakeHTTPHandler(controllers.Article.HandleGetArticles))
-		r.Get("/articles/{id}", api.MakeHTTPHandler(controllers.Article.HandleGetArticle))
+		r.Get("/articles", api.MakeHTTPHandler(controllers.Article.HandleGetPublicArticles))
+		r.Get("/articles/search", api.MakeHTTPHandler(controllers.Article.HandleSearchPublicArticles))
+		r.Get("/articles/{id}", api.MakeHTTPHandler(controllers.Article.HandleGetPublicArticle))
 
 		r.Get("/domains/{id}", api.MakeHTTPHandler(controllers.Domain.HandleGetDomainPublicData))
 
@@ -74,17 +77,34 @@ func NewApiRoutes(controllers ApiControllers, services ApiServices, tasks *tasks
 
 		r.Get("/articles", api.MakeHTTPHandler(controllers.Article.HandleGetArticles))
 		r.Post("/articles", api.MakeHTTPHandler(controllers.Article.HandleCreateArticle))
+		r.Get("/articles/search", api.MakeHTTPHandler(controllers.Article.HandleSearchArticles))
+		r.Get("/articles/trash", api.MakeHTTPHandler(controllers.Article.HandleGetDeletedArticles))
+		r.Post("/articles/trash/{id}/restore", api.MakeHTTPHandler(controllers.Article.HandleRestoreArticle))
+		r.Delete("/articles/trash/{id}", api.MakeHTTPHandler(controllers.Article.HandlePurgeArticle))
 		r.Get("/articles/{id}", api.MakeHTTPHandler(controllers.Article.HandleGetArticle))
 		r.Put("/articles/{id}", api.MakeHTTPHandler(controllers.Article.HandleUpdateArticle))
 		r.Delete("/articles/{id}", api.MakeHTTPHandler(controllers.Article.HandleDeleteArticle))
 		r.Post("/articles/{id}/generate-description", api.MakeHTTPHandler(controllers.Article.HandleGenerateDescritption))
+		r.Post("/articles/{id}/generate-seo", api.MakeHTTPHandler(controllers.Article.HandleGenerateSeo))
 		r.Get("/articles/{id}/remove-duplicates", api.MakeHTTPHandler(controllers.Article.HandleRemoveDuplicatesFromArticle))
 		r.Post("/articles/generate", api.MakeHTTPHandler(controllers.Article.HandleGenerateArticles))
+		r.Get("/articles/{id}/prompt-versions", api.MakeHTTPHandler(controllers.Article.HandleGetArticlePromptVersions))
+		r.Get("/articles/{id}/generation", api.MakeHTTPHandler(controllers.Article.HandleGetArticleGenerationProgress))
+		r.Get("/articles/{id}/usage", api.MakeHTTPHandler(controllers.Usage.HandleGetArticleUsage))
+		r.Post("/articles/{id}/status", api.MakeHTTPHandler(controllers.Article.HandleChangeArticleStatus))
+		r.Get("/articles/{id}/status-transitions", api.MakeHTTPHandler(controllers.Article.HandleGetArticleStatusTransitions))
+		r.Post("/articles/{id}/translate", api.MakeHTTPHandler(controllers.Article.HandleTranslateArticle))
+		r.Get("/articles/{id}/translations", api.MakeHTTPHandler(controllers.Article.HandleGetArticleTranslations))
+		r.Get("/articles/{id}/revisions", api.MakeHTTPHandler(controllers.Article.HandleGetArticleRevisions))
+		r.Get("/articles/{id}/revisions/diff", api.MakeHTTPHandler(controllers.Article.HandleDiffArticleRevisions))
+		r.Get("/articles/{id}/revisions/{revisionId}", api.MakeHTTPHandler(controllers.Article.HandleGetArticleRevision))
+		r.Post("/articles/{id}/revisions/{revisionId}/restore", api.MakeHTTPHandler(controllers.Article.HandleRestoreArticleRevision))
 
 		r.Get("/categories", api.MakeHTTPHandler(controllers.Category.HandleGetCategories))
 		r.Post("/categories", api.MakeHTTPHandler(controllers.Category.HandleCreateCategory))
 		r.Get("/categories/{id}", api.MakeHTTPHandler(controllers.Category.HandleGetCategory))
 		r.Put("/categories/{id}", api.MakeHTTPHandler(controllers.Category.HandleUpdateCategory))
+		r.Get("/category-assignments", api.MakeHTTPHandler(controllers.Category.HandleGetCategoryAssignments))
 
 		r.Get("/question-categories", api.MakeHTTPHandler(controllers.Scrapper.HandleGetQuestionCategories))
 
@@ -112,6 +132,17 @@ func NewApiRoutes(controllers ApiControllers, services ApiServices, tasks *tasks
 		r.Post("/basic-pages", api.MakeHTTPHandler(controllers.BasicPage.HandleCreateBasicPage))
 		r.Get("/basic-pages/{id}", api.MakeHTTPHandler(controllers.BasicPage.HandleGetBasicPage))
 		r.Put("/basic-pages/{id}", api.MakeHTTPHandler(controllers.BasicPage.HandleUpdateBasicPage))
+
+		r.Get("/prompt-templates", api.MakeHTTPHandler(controllers.PromptTemplate.HandleGetPromptTemplates))
+		r.Post("/prompt-templates", api.MakeHTTPHandler(controllers.PromptTemplate.HandleCreatePromptTemplate))
+		r.Get("/prompt-templates/{id}", api.MakeHTTPHandler(controllers.PromptTemplate.HandleGetPromptTemplate))
+		r.Put("/prompt-templates/{id}/activate", api.MakeHTTPHandler(controllers.PromptTemplate.HandleActivatePromptTemplate))
+
+		r.Get("/usage/domains", api.MakeHTTPHandler(controllers.Usage.HandleGetUsagePerDomain))
+		r.Get("/usage/days", api.MakeHTTPHandler(controllers.Usage.HandleGetUsagePerDay))
+		r.Get("/usage/batches", api.MakeHTTPHandler(controllers.Usage.HandleGetUsagePerBatch))
+		r.Get("/usage/prices", api.MakeHTTPHandler(controllers.Usage.HandleGetModelPrices))
+		r.Put("/usage/prices/{model}", api.MakeHTTPHandler(controllers.Usage.HandleUpdateModelPrice))
 	})
 
 	return r
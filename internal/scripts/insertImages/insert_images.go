@@ -44,8 +44,10 @@ func main() {
 		logger.Fatal().Msg("Error loading .env file")
 	}
 
+	ctx := context.Background()
+
 	//Init DB
-	dbpool, err := pgxpool.New(context.Background(), os.Getenv("DATABASE_URL"))
+	dbpool, err := pgxpool.New(ctx, os.Getenv("DATABASE_URL"))
 
 	if err != nil {
 		logger.Fatal().Err(err).Send()
@@ -64,7 +66,7 @@ func main() {
 	logger.Info().Msg("Files renamed successfully: " + dirPath)
 
 	logger.Info().Msg("Scanning images from the directory: " + dirPath)
-	err = fileService.InsertJPGImagesFromDir(dirPath, imageCatId)
+	err = fileService.InsertJPGImagesFromDir(ctx, dirPath, imageCatId)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
@@ -30,8 +30,10 @@ func main() {
 		logger.Fatal().Msg("Error loading .env file")
 	}
 
+	ctx := context.Background()
+
 	//Init DB
-	dbpool, err := pgxpool.New(context.Background(), os.Getenv("SEED_DATABASE_URL"))
+	dbpool, err := pgxpool.New(ctx, os.Getenv("SEED_DATABASE_URL"))
 
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Unable to connect to database: %v\n", err)
@@ -51,13 +53,13 @@ func main() {
 	adminRole := fixtures.CreateRole("Admin")
 	editorRole := fixtures.CreateRole("Editor")
 
-	_, err = store.Role.InsertRole(adminRole)
+	_, err = store.Role.InsertRole(ctx, adminRole)
 
 	if err != nil {
 		panic(err)
 	}
 
-	_, err = store.Role.InsertRole(editorRole)
+	_, err = store.Role.InsertRole(ctx, editorRole)
 
 	if err != nil {
 		logger.Fatal().Err(err).Send()
@@ -66,81 +68,81 @@ func main() {
 	adminUser := fixtures.CreateUser("admin@admin.com", "admin", 1)
 	editorUser := fixtures.CreateUser("editor@editor.com", "editor", 2)
 
-	_, err = store.User.InsertUser(adminUser)
+	_, err = store.User.InsertUser(ctx, adminUser)
 
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
-	_, err = store.User.InsertUser(editorUser)
+	_, err = store.User.InsertUser(ctx, editorUser)
 
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
 	homeDesignDomain := fixtures.CreateDomain("homedesign.com", "homedesign@gmail.com")
-	homeDesignDomainId, err := store.Domain.InsertDomain(homeDesignDomain)
+	homeDesignDomainId, err := store.Domain.InsertDomain(ctx, homeDesignDomain)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
 	newsDomain := fixtures.CreateDomain("hotnews.com", "hotnews@gmail.com")
-	newsDomainId, err := store.Domain.InsertDomain(newsDomain)
+	newsDomainId, err := store.Domain.InsertDomain(ctx, newsDomain)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
 	installationOfPanelsCategory := fixtures.CreateCategory("Installation of Panels")
-	installationOfPanelsCategoryId, err := store.Category.InsertCategory(installationOfPanelsCategory)
+	installationOfPanelsCategoryId, err := store.Category.InsertCategory(ctx, installationOfPanelsCategory)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
 	materialsAndToolsCategory := fixtures.CreateCategory("Materials and Tools")
-	materialsAndToolsCategoryId, err := store.Category.InsertCategory(materialsAndToolsCategory)
+	materialsAndToolsCategoryId, err := store.Category.InsertCategory(ctx, materialsAndToolsCategory)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
 	maintenanceAndRepairCategory := fixtures.CreateCategory("Maintenance and Repair")
-	maintenanceAndRepairCategoryId, err := store.Category.InsertCategory(maintenanceAndRepairCategory)
+	maintenanceAndRepairCategoryId, err := store.Category.InsertCategory(ctx, maintenanceAndRepairCategory)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
 	underfloorHeatingCategory := fixtures.CreateCategory("Underfloor Heating")
-	underfloorHeatingCategoryId, err := store.Category.InsertCategory(underfloorHeatingCategory)
+	underfloorHeatingCategoryId, err := store.Category.InsertCategory(ctx, underfloorHeatingCategory)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
 	moistureAndWaterproofingCategory := fixtures.CreateCategory("Moisture and Waterproofing")
-	moistureAndWaterproofingCategoryId, err := store.Category.InsertCategory(moistureAndWaterproofingCategory)
+	moistureAndWaterproofingCategoryId, err := store.Category.InsertCategory(ctx, moistureAndWaterproofingCategory)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
 	diyProjectsCategory := fixtures.CreateCategory("DIY projects")
-	diyProjectsCategoryCategoryId, err := store.Category.InsertCategory(diyProjectsCategory)
+	diyProjectsCategoryCategoryId, err := store.Category.InsertCategory(ctx, diyProjectsCategory)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
 	technicalSolutionsCategory := fixtures.CreateCategory("Technical Solutions")
-	technicalSolutionsCategoryId, err := store.Category.InsertCategory(technicalSolutionsCategory)
+	technicalSolutionsCategoryId, err := store.Category.InsertCategory(ctx, technicalSolutionsCategory)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
 	designAndTrendsCategory := fixtures.CreateCategory("Design and Trends")
-	designAndTrendsCategoryId, err := store.Category.InsertCategory(designAndTrendsCategory)
+	designAndTrendsCategoryId, err := store.Category.InsertCategory(ctx, designAndTrendsCategory)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
 	john := fixtures.CreateAuthor("John", "Doe", "Lorem ipsum dolor", "/assets/images/avatars/man-avatar.png")
 
-	johnId, err := store.Author.InsertAuthor(john)
+	johnId, err := store.Author.InsertAuthor(ctx, john)
 
 	if err != nil {
 		logger.Fatal().Err(err).Send()
@@ -148,58 +150,58 @@ func main() {
 
 	jane := fixtures.CreateAuthor("Jane", "Doe", "Lorem ipsum dolor", "/assets/images/avatars/man-avatar.png")
 
-	janeId, err := store.Author.InsertAuthor(jane)
+	janeId, err := store.Author.InsertAuthor(ctx, jane)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
-	err = store.CategoriesDomains.AssignCategoryToDomain(installationOfPanelsCategoryId, homeDesignDomainId)
+	err = store.CategoriesDomains.AssignCategoryToDomain(ctx, installationOfPanelsCategoryId, homeDesignDomainId)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
-	err = store.CategoriesDomains.AssignCategoryToDomain(materialsAndToolsCategoryId, homeDesignDomainId)
+	err = store.CategoriesDomains.AssignCategoryToDomain(ctx, materialsAndToolsCategoryId, homeDesignDomainId)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
-	err = store.CategoriesDomains.AssignCategoryToDomain(maintenanceAndRepairCategoryId, homeDesignDomainId)
+	err = store.CategoriesDomains.AssignCategoryToDomain(ctx, maintenanceAndRepairCategoryId, homeDesignDomainId)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
-	err = store.CategoriesDomains.AssignCategoryToDomain(underfloorHeatingCategoryId, homeDesignDomainId)
+	err = store.CategoriesDomains.AssignCategoryToDomain(ctx, underfloorHeatingCategoryId, homeDesignDomainId)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
-	err = store.CategoriesDomains.AssignCategoryToDomain(moistureAndWaterproofingCategoryId, homeDesignDomainId)
+	err = store.CategoriesDomains.AssignCategoryToDomain(ctx, moistureAndWaterproofingCategoryId, homeDesignDomainId)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
-	err = store.CategoriesDomains.AssignCategoryToDomain(diyProjectsCategoryCategoryId, homeDesignDomainId)
+	err = store.CategoriesDomains.AssignCategoryToDomain(ctx, diyProjectsCategoryCategoryId, homeDesignDomainId)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
-	err = store.CategoriesDomains.AssignCategoryToDomain(technicalSolutionsCategoryId, homeDesignDomainId)
+	err = store.CategoriesDomains.AssignCategoryToDomain(ctx, technicalSolutionsCategoryId, homeDesignDomainId)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
-	err = store.CategoriesDomains.AssignCategoryToDomain(designAndTrendsCategoryId, homeDesignDomainId)
+	err = store.CategoriesDomains.AssignCategoryToDomain(ctx, designAndTrendsCategoryId, homeDesignDomainId)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
-	err = store.CategoriesDomains.AssignCategoryToDomain(designAndTrendsCategoryId, newsDomainId)
+	err = store.CategoriesDomains.AssignCategoryToDomain(ctx, designAndTrendsCategoryId, newsDomainId)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
 
 	panelsCategory := fixtures.CreateImageCategory("Panele")
-	panelsImageCategoryId, err := store.ImageCategory.InsertCategory(panelsCategory)
+	panelsImageCategoryId, err := store.ImageCategory.InsertCategory(ctx, panelsCategory)
 
 	if err != nil {
 		logger.Fatal().Err(err).Send()
@@ -211,7 +213,7 @@ func main() {
 	logger.Info().Msg("Files renamed successfully: " + imagesPath)
 
 	logger.Info().Msg("Scanning images from the directory: " + imagesPath)
-	err = fileService.InsertJPGImagesFromDir(imagesPath, panelsImageCategoryId)
+	err = fileService.InsertJPGImagesFromDir(ctx, imagesPath, panelsImageCategoryId)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
@@ -257,11 +259,11 @@ func main() {
 		2,
 	)
 
-	_, err = store.BasicPage.InsertBasicPage(contactPageForFirstDomain)
+	_, err = store.BasicPage.InsertBasicPage(ctx, contactPageForFirstDomain)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
-	_, err = store.BasicPage.InsertBasicPage(contactPageForSecondDomain)
+	_, err = store.BasicPage.InsertBasicPage(ctx, contactPageForSecondDomain)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
@@ -277,11 +279,11 @@ func main() {
 		2,
 	)
 
-	_, err = store.BasicPage.InsertBasicPage(aboutUsPageForFirstDomain)
+	_, err = store.BasicPage.InsertBasicPage(ctx, aboutUsPageForFirstDomain)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
-	_, err = store.BasicPage.InsertBasicPage(aboutUsPageForSecondDomain)
+	_, err = store.BasicPage.InsertBasicPage(ctx, aboutUsPageForSecondDomain)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
@@ -297,11 +299,11 @@ func main() {
 		2,
 	)
 
-	_, err = store.BasicPage.InsertBasicPage(privacypolicyPageForFirstDomain)
+	_, err = store.BasicPage.InsertBasicPage(ctx, privacypolicyPageForFirstDomain)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
-	_, err = store.BasicPage.InsertBasicPage(privacyPolicyForSecondDomain)
+	_, err = store.BasicPage.InsertBasicPage(ctx, privacyPolicyForSecondDomain)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 	}
@@ -320,7 +322,7 @@ func main() {
 		featured := false
 		article := fixtures.CreateArticle(title, body, thumbnail, isPubished, authorId, categoryId, domainId, featured)
 
-		_, err = store.Article.InsertArticle(article)
+		_, err = store.Article.InsertArticle(ctx, article)
 
 		if err != nil {
 			logger.Fatal().Err(err).Send()
@@ -341,7 +343,7 @@ func main() {
 		featured := true
 		article := fixtures.CreateArticle(title, body, thumbnail, isPubished, authorId, categoryId, domainId, featured)
 
-		_, err = store.Article.InsertArticle(article)
+		_, err = store.Article.InsertArticle(ctx, article)
 
 		if err != nil {
 			logger.Fatal().Err(err).Send()
@@ -362,7 +364,7 @@ func main() {
 		featured := false
 		article := fixtures.CreateArticle(title, body, thumbnail, isPubished, authorId, categoryId, domainId, featured)
 
-		_, err = store.Article.InsertArticle(article)
+		_, err = store.Article.InsertArticle(ctx, article)
 
 		if err != nil {
 			logger.Fatal().Err(err).Send()
@@ -383,7 +385,7 @@ func main() {
 		featured := false
 		article := fixtures.CreateArticle(title, body, thumbnail, isPubished, authorId, categoryId, domainId, featured)
 
-		_, err = store.Article.InsertArticle(article)
+		_, err = store.Article.InsertArticle(ctx, article)
 
 		if err != nil {
 			logger.Fatal().Err(err).Send()
@@ -404,7 +406,7 @@ func main() {
 		featured := false
 		article := fixtures.CreateArticle(title, body, thumbnail, isPubished, authorId, categoryId, domainId, featured)
 
-		_, err = store.Article.InsertArticle(article)
+		_, err = store.Article.InsertArticle(ctx, article)
 
 		if err != nil {
 			logger.Fatal().Err(err).Send()
@@ -425,7 +427,7 @@ func main() {
 		featured := false
 		article := fixtures.CreateArticle(title, body, thumbnail, isPubished, authorId, categoryId, domainId, featured)
 
-		_, err = store.Article.InsertArticle(article)
+		_, err = store.Article.InsertArticle(ctx, article)
 
 		if err != nil {
 			logger.Fatal().Err(err).Send()
@@ -446,7 +448,7 @@ func main() {
 		featured := false
 		article := fixtures.CreateArticle(title, body, thumbnail, isPubished, authorId, categoryId, domainId, featured)
 
-		_, err = store.Article.InsertArticle(article)
+		_, err = store.Article.InsertArticle(ctx, article)
 
 		if err != nil {
 			logger.Fatal().Err(err).Send()
@@ -467,7 +469,7 @@ func main() {
 		featured := false
 		article := fixtures.CreateArticle(title, body, thumbnail, isPubished, authorId, categoryId, domainId, featured)
 
-		_, err = store.Article.InsertArticle(article)
+		_, err = store.Article.InsertArticle(ctx, article)
 
 		if err != nil {
 			logger.Fatal().Err(err).Send()
@@ -489,7 +491,7 @@ func main() {
 		featured := false
 		article := fixtures.CreateArticle(title, body, thumbnail, isPubished, authorId, categoryId, domainId, featured)
 
-		_, err = store.Article.InsertArticle(article)
+		_, err = store.Article.InsertArticle(ctx, article)
 
 		if err != nil {
 			logger.Fatal().Err(err).Send()
@@ -1,90 +1,318 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"github.com/gosimple/slug"
 	a "github.com/rustoma/octo-pulse/internal/ai"
+	chatgpt "github.com/rustoma/octo-pulse/internal/ai/chatGPT"
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+	"github.com/rustoma/octo-pulse/internal/ai/prompts"
+	"github.com/rustoma/octo-pulse/internal/dedup"
 	"github.com/rustoma/octo-pulse/internal/dto"
+	e "github.com/rustoma/octo-pulse/internal/errors"
+	"github.com/rustoma/octo-pulse/internal/linking"
 	"github.com/rustoma/octo-pulse/internal/models"
 	"github.com/rustoma/octo-pulse/internal/storage"
 	"github.com/rustoma/octo-pulse/internal/utils"
 	"github.com/rustoma/octo-pulse/internal/validator"
 	"regexp"
 	"strings"
+	"time"
 )
 
 type ArticleService interface {
-	GenerateDescription(question *models.Question) (string, error)
-	UpdateArticle(articleId int, article *models.Article) (int, error)
-	GetArticle(id int) (*models.Article, error)
-	GetArticles(filters ...*storage.GetArticlesFilters) ([]*dto.Article, error)
-	CreateArticle(article *models.Article) (int, error)
-	DeleteArticle(id int) (int, error)
-	RemoveDuplicateHeadingsFromArticle(articleId int) error
+	GenerateDescription(ctx context.Context, articleId int, question *models.Question, domain *models.Domain, meter *llm.Meter, fresh bool) (*chatgpt.ArticleDescription, error)
+	GenerateSeoMetadata(ctx context.Context, article *models.Article, domain *models.Domain, meter *llm.Meter) error
+	AddInternalLinks(ctx context.Context, article *models.Article) ([]linking.Link, error)
+	SaveArticleLinks(ctx context.Context, articleId int, links []linking.Link) error
+	GenerateFaq(ctx context.Context, article *models.Article, questions []*models.Question, domain *models.Domain, meter *llm.Meter) ([]*models.ArticleFaqItem, error)
+	SaveArticleFaq(ctx context.Context, articleId int, items []*models.ArticleFaqItem) error
+	GetStaleArticles(ctx context.Context, domain *models.Domain, overlapAbove float64, limit int) ([]*models.Article, error)
+	CountArticleRefreshes(ctx context.Context, domainId int, since time.Time) (int, error)
+	RequestArticleRefresh(ctx context.Context, articleId int) error
+	NewQuestionDeduplicator(ctx context.Context, domain *models.Domain, questions []*models.Question, meter *llm.Meter) (*QuestionDeduplicator, error)
+	SaveQuestionDuplicate(ctx context.Context, question *models.Question, domainId int, match *dedup.Match, batchId string) error
+	UpdateArticleSourceOverlap(ctx context.Context, articleId int, overlap float64) error
+	ChangeArticleStatus(ctx context.Context, articleId int, request *dto.ArticleStatusRequest, user *models.User) (*models.Article, error)
+	GetArticleStatusTransitions(ctx context.Context, articleId int) ([]*models.ArticleStatusTransition, error)
+	GetDueScheduledArticles(ctx context.Context) ([]*models.Article, error)
+	TranslateArticle(ctx context.Context, article *models.Article, from *models.Domain, to *models.Domain, categoryId int, meter *llm.Meter) (int, error)
+	GetArticleTranslations(ctx context.Context, articleId int) ([]*models.Article, error)
+	GetArticleGenerationProgress(ctx context.Context, articleId int) (*dto.ArticleGenerationProgress, error)
+	ClearArticleGenerationSteps(ctx context.Context, articleId int) error
+	UpdateArticle(ctx context.Context, articleId int, article *models.Article, author *models.ArticleRevisionAuthor) (int, error)
+	GetArticleRevisions(ctx context.Context, articleId int) ([]*models.ArticleRevision, error)
+	GetArticleRevision(ctx context.Context, articleId int, revisionId int) (*models.ArticleRevision, error)
+	DiffArticleRevisions(ctx context.Context, articleId int, fromId int, toId int) (*dto.ArticleRevisionDiff, error)
+	RestoreArticleRevision(ctx context.Context, articleId int, revisionId int, user *models.User) (*models.Article, error)
+	GetArticle(ctx context.Context, id int) (*models.Article, error)
+	GetPublicArticle(ctx context.Context, id int) (*dto.PublicArticle, error)
+	GetArticles(ctx context.Context, filters ...*storage.GetArticlesFilters) ([]*dto.Article, error)
+	GetArticlesPage(ctx context.Context, filters *storage.GetArticlesFilters) (*dto.Page[*dto.Article], error)
+	CountArticles(ctx context.Context, filters ...*storage.GetArticlesFilters) (int, error)
+	SearchArticles(ctx context.Context, filters *storage.SearchArticlesFilters) (*dto.Page[*dto.ArticleSearchResult], error)
+	CreateArticle(ctx context.Context, article *models.Article) (int, error)
+	DeleteArticle(ctx context.Context, id int) (int, error)
+	RestoreArticle(ctx context.Context, id int) (int, error)
+	PurgeArticle(ctx context.Context, id int) (int, error)
+	DiscardArticle(ctx context.Context, id int) error
+	GetDeletedArticles(ctx context.Context, filters *storage.GetDeletedArticlesFilters) (*dto.Page[*dto.DeletedArticle], error)
+	PurgeExpiredArticles(ctx context.Context) (int, error)
+	RemoveDuplicateHeadingsFromArticle(ctx context.Context, articleId int) error
+	SaveArticlePromptVersions(ctx context.Context, articleId int, versions []prompts.Version) error
+	GetArticlePromptVersions(ctx context.Context, articleId int) ([]*models.ArticlePromptVersion, error)
 }
 
 type articleService struct {
-	articleStore     storage.ArticleStore
-	articleValidator validator.ArticleValidatorer
-	ai               *a.AI
+	articleStore            storage.ArticleStore
+	articleGenerationStore  storage.ArticleGenerationStore
+	articleLinkStore        storage.ArticleLinkStore
+	articleFaqStore         storage.ArticleFaqStore
+	articleStatusStore      storage.ArticleStatusStore
+	articleTranslationStore storage.ArticleTranslationStore
+	articleRevisionStore    storage.ArticleRevisionStore
+	questionDuplicateStore  storage.QuestionDuplicateStore
+	domainStore             storage.DomainStore
+	promptTemplateStore     storage.PromptTemplateStore
+	articleValidator        validator.ArticleValidatorer
+	ai                      *a.AI
 }
 
-func NewArticleService(articleStore storage.ArticleStore, articleValidator validator.ArticleValidatorer, ai *a.AI) ArticleService {
-	return &articleService{articleStore: articleStore, articleValidator: articleValidator, ai: ai}
+// ArticleStores are the stores the article service works with.
+type ArticleStores struct {
+	Article            storage.ArticleStore
+	ArticleGeneration  storage.ArticleGenerationStore
+	ArticleLink        storage.ArticleLinkStore
+	ArticleFaq         storage.ArticleFaqStore
+	ArticleStatus      storage.ArticleStatusStore
+	ArticleTranslation storage.ArticleTranslationStore
+	ArticleRevision    storage.ArticleRevisionStore
+	QuestionDuplicate  storage.QuestionDuplicateStore
+	Domain             storage.DomainStore
+	PromptTemplate     storage.PromptTemplateStore
 }
 
-func (s *articleService) CreateArticle(article *models.Article) (int, error) {
-	article.Slug = slug.Make(article.Title)
+func NewArticleService(stores ArticleStores, articleValidator validator.ArticleValidatorer, ai *a.AI) ArticleService {
+	return &articleService{
+		articleStore:            stores.Article,
+		articleGenerationStore:  stores.ArticleGeneration,
+		articleLinkStore:        stores.ArticleLink,
+		articleFaqStore:         stores.ArticleFaq,
+		articleStatusStore:      stores.ArticleStatus,
+		articleTranslationStore: stores.ArticleTranslation,
+		articleRevisionStore:    stores.ArticleRevision,
+		questionDuplicateStore:  stores.QuestionDuplicate,
+		domainStore:             stores.Domain,
+		promptTemplateStore:     stores.PromptTemplate,
+		articleValidator:        articleValidator,
+		ai:                      ai,
+	}
+}
+
+// makeSlug transliterates the title using the rules of the domain language,
+// e.g. "Größe" becomes "groesse" on German domains.
+func (s *articleService) makeSlug(ctx context.Context, article *models.Article) (string, error) {
+	domain, err := s.domainStore.GetDomain(ctx, article.DomainId)
+	if err != nil {
+		return "", err
+	}
+
+	if domain == nil {
+		return slug.Make(article.Title), nil
+	}
+
+	return slug.MakeLang(article.Title, domain.Language), nil
+}
+
+// CreateArticle saves a new draft article, or one about to be generated. The
+// article is published through ChangeArticleStatus later.
+func (s *articleService) CreateArticle(ctx context.Context, article *models.Article) (int, error) {
+	if article.Status == "" {
+		article.Status = models.ArticleStatusDraft
+	}
+
+	if article.Status != models.ArticleStatusDraft && article.Status != models.ArticleStatusGenerating {
+		return 0, e.BadRequest{Err: fmt.Sprintf("new articles cannot be %s", article.Status)}
+	}
+
+	article.IsPublished = false
+
+	articleSlug, err := s.makeSlug(ctx, article)
+	if err != nil {
+		return 0, err
+	}
+
+	article.Slug = articleSlug
 	readingTime := utils.CalculateReadTime(article.Body)
 	article.ReadingTime = &readingTime
 
-	err := s.articleValidator.Validate(article)
+	err = s.articleValidator.Validate(article)
 	if err != nil {
 		return 0, err
 	}
 
-	return s.articleStore.InsertArticle(article)
+	articleId, err := s.articleStore.InsertArticle(ctx, article)
+	if err != nil {
+		return 0, err
+	}
+
+	err = s.saveArticleRevision(ctx, articleId, article, &models.ArticleRevisionAuthor{Source: models.ArticleRevisionSourceCreate})
+	if err != nil {
+		logger.Err(err).Msgf("Cannot save the revision of article id: %d", articleId)
+	}
+
+	return articleId, nil
 }
 
-func (s *articleService) DeleteArticle(id int) (int, error) {
-	return s.articleStore.DeleteArticle(id)
+// DeleteArticle moves the article to the trash. The links other articles have
+// to it are removed first, so no body points to a missing page.
+func (s *articleService) DeleteArticle(ctx context.Context, id int) (int, error) {
+	err := s.removeInboundLinks(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.articleStore.DeleteArticle(ctx, id)
 }
 
-func (s *articleService) GenerateDescription(question *models.Question) (string, error) {
+// GenerateDescription writes the article body, recording the tokens spent in
+// meter. It continues from the steps saved by an earlier, failed attempt. With
+// fresh set those steps are dropped and every prompt is sent to the model, even
+// when a reply to it is cached.
+func (s *articleService) GenerateDescription(ctx context.Context, articleId int, question *models.Question, domain *models.Domain, meter *llm.Meter, fresh bool) (*chatgpt.ArticleDescription, error) {
+	chatGPT := s.ai.ChatGPT.WithMeter(meter)
+	if fresh {
+		chatGPT = chatGPT.WithoutCache()
 
-	description, err := s.ai.ChatGPT.GenerateArticleDescription(question)
+		err := s.articleGenerationStore.DeleteArticleGenerationSteps(ctx, articleId)
+		if err != nil {
+			return nil, err
+		}
+	}
 
+	steps, err := s.loadGenerationSteps(ctx, articleId)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	description, err := chatGPT.GenerateArticleDescription(ctx, question, domain, steps)
+
+	if err != nil {
+		return nil, err
 	}
 
 	return description, nil
 }
 
-func (s *articleService) UpdateArticle(articleId int, article *models.Article) (int, error) {
-	article.Slug = slug.Make(article.Title)
+// GenerateSeoMetadata fills the SEO fields of article from its title and body.
+// The article is not saved.
+func (s *articleService) GenerateSeoMetadata(ctx context.Context, article *models.Article, domain *models.Domain, meter *llm.Meter) error {
+	metadata, err := s.ai.ChatGPT.WithMeter(meter).GenerateSeoMetadata(ctx, article.Title, article.Body, domain)
+	if err != nil {
+		return err
+	}
+
+	article.MetaTitle = metadata.MetaTitle
+	article.MetaDescription = metadata.MetaDescription
+	article.FocusKeyword = metadata.FocusKeyword
+	article.Excerpt = metadata.Excerpt
+
+	return nil
+}
+
+func (s *articleService) SaveArticlePromptVersions(ctx context.Context, articleId int, versions []prompts.Version) error {
+	articlePromptVersions := make([]*models.ArticlePromptVersion, 0, len(versions))
+	for _, version := range versions {
+		articlePromptVersions = append(articlePromptVersions, &models.ArticlePromptVersion{
+			ArticleId: articleId,
+			Name:      version.Name,
+			Version:   version.Version,
+			Source:    version.Source,
+			Language:  version.Language,
+		})
+	}
+
+	return s.promptTemplateStore.InsertArticlePromptVersions(ctx, articleId, articlePromptVersions)
+}
+
+func (s *articleService) GetArticlePromptVersions(ctx context.Context, articleId int) ([]*models.ArticlePromptVersion, error) {
+	return s.promptTemplateStore.GetArticlePromptVersions(ctx, articleId)
+}
+
+// UpdateArticle saves the article and records its content as a revision made
+// by author.
+func (s *articleService) UpdateArticle(ctx context.Context, articleId int, article *models.Article, author *models.ArticleRevisionAuthor) (int, error) {
+	articleSlug, err := s.makeSlug(ctx, article)
+	if err != nil {
+		return 0, err
+	}
+
+	article.Slug = articleSlug
 	readingTime := utils.CalculateReadTime(article.Body)
 	article.ReadingTime = &readingTime
 
-	err := s.articleValidator.Validate(article)
+	err = s.articleValidator.Validate(article)
+	if err != nil {
+		return 0, err
+	}
+
+	updatedArticleId, err := s.articleStore.UpdateArticle(ctx, articleId, article)
 	if err != nil {
 		return 0, err
 	}
 
-	return s.articleStore.UpdateArticle(articleId, article)
+	err = s.saveArticleRevision(ctx, updatedArticleId, article, author)
+	if err != nil {
+		logger.Err(err).Msgf("Cannot save the revision of article id: %d", updatedArticleId)
+	}
+
+	return updatedArticleId, nil
 }
 
-func (s *articleService) GetArticle(id int) (*models.Article, error) {
-	return s.articleStore.GetArticle(id)
+func (s *articleService) GetArticle(ctx context.Context, id int) (*models.Article, error) {
+	return s.articleStore.GetArticle(ctx, id)
+}
+
+func (s *articleService) GetArticles(ctx context.Context, filters ...*storage.GetArticlesFilters) ([]*dto.Article, error) {
+	return s.articleStore.GetArticles(ctx, filters...)
+}
+
+// GetArticlesPage returns a page of the articles with the number of all the
+// articles matching the filters and, when the page is full, the cursor of the
+// next one.
+func (s *articleService) GetArticlesPage(ctx context.Context, filters *storage.GetArticlesFilters) (*dto.Page[*dto.Article], error) {
+	articles, err := s.articleStore.GetArticles(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.articleStore.CountArticles(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dto.Page[*dto.Article]{Items: articles, Total: total}
+	if page.Items == nil {
+		page.Items = []*dto.Article{}
+	}
+
+	if filters.Limit > 0 && len(articles) == filters.Limit {
+		last := articles[len(articles)-1]
+		cursor := (&storage.Cursor{Date: last.PublicationDate, Id: last.ID}).Encode()
+		page.NextCursor = &cursor
+	}
+
+	return page, nil
 }
 
-func (s *articleService) GetArticles(filters ...*storage.GetArticlesFilters) ([]*dto.Article, error) {
-	return s.articleStore.GetArticles(filters...)
+func (s *articleService) CountArticles(ctx context.Context, filters ...*storage.GetArticlesFilters) (int, error) {
+	return s.articleStore.CountArticles(ctx, filters...)
 }
 
-func (s *articleService) RemoveDuplicateHeadingsFromArticle(articleId int) error {
+func (s *articleService) RemoveDuplicateHeadingsFromArticle(ctx context.Context, articleId int) error {
 
-	article, err := s.articleStore.GetArticle(articleId)
+	article, err := s.articleStore.GetArticle(ctx, articleId)
 	if err != nil {
 		return err
 	}
@@ -121,18 +349,18 @@ func (s *articleService) RemoveDuplicateHeadingsFromArticle(articleId int) error
 					logger.Info().Interface("lastCharacters: ", lastCharacters).Send()
 					modifiedHTML = strings.Replace(modifiedHTML, matches[0], lastCharacters, 1)
 				} else {
-					fmt.Println("Input string is too short.")
+					logger.Debug().Msgf("Heading match of article id: %d is too short to remove", articleId)
 				}
 
 				logger.Info().Interface("modifiedHTML: ", modifiedHTML).Send()
 				article.Body = modifiedHTML
 
-				_, err := s.UpdateArticle(article.ID, article)
+				_, err := s.UpdateArticle(ctx, article.ID, article, &models.ArticleRevisionAuthor{Source: models.ArticleRevisionSourceRemoveDuplicates})
 				if err != nil {
 					return err
 				}
 
-				err = s.RemoveDuplicateHeadingsFromArticle(articleId)
+				err = s.RemoveDuplicateHeadingsFromArticle(ctx, articleId)
 				if err != nil {
 					return err
 				}
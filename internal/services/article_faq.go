@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+	"github.com/rustoma/octo-pulse/internal/dto"
+	e "github.com/rustoma/octo-pulse/internal/errors"
+	"github.com/rustoma/octo-pulse/internal/models"
+)
+
+// GenerateFaq answers the questions related to the article with its body. The
+// FAQ is not saved, see SaveArticleFaq.
+func (s *articleService) GenerateFaq(ctx context.Context, article *models.Article, questions []*models.Question, domain *models.Domain, meter *llm.Meter) ([]*models.ArticleFaqItem, error) {
+	texts := make([]string, 0, len(questions))
+	for _, question := range questions {
+		texts = append(texts, question.Question)
+	}
+
+	faq, err := s.ai.ChatGPT.WithMeter(meter).GenerateFaq(ctx, article.Body, texts, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*models.ArticleFaqItem, 0, len(faq))
+	for position, item := range faq {
+		items = append(items, &models.ArticleFaqItem{
+			ArticleId: article.ID,
+			Position:  position,
+			Question:  item.Question,
+			Answer:    item.Answer,
+		})
+	}
+
+	return items, nil
+}
+
+func (s *articleService) SaveArticleFaq(ctx context.Context, articleId int, items []*models.ArticleFaqItem) error {
+	return s.articleFaqStore.ReplaceArticleFaq(ctx, articleId, items)
+}
+
+// GetPublicArticle returns the article with its FAQ, the FAQPage structured
+// data of the FAQ, ready to be embedded in the page, and its hreflang
+// alternates. Only published articles are returned.
+func (s *articleService) GetPublicArticle(ctx context.Context, id int) (*dto.PublicArticle, error) {
+	article, err := s.articleStore.GetArticle(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if article == nil || article.Status != models.ArticleStatusPublished {
+		return nil, e.NotFound{Err: fmt.Sprintf("article with id %d not found", id)}
+	}
+
+	faq, err := s.articleFaqStore.GetArticleFaq(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonLd, err := faqJsonLd(faq)
+	if err != nil {
+		return nil, err
+	}
+
+	alternates, err := s.articleAlternates(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PublicArticle{Article: article, Faq: faq, FaqJsonLd: jsonLd, Alternates: alternates}, nil
+}
+
+// faqJsonLd builds the schema.org FAQPage of the items, nil when there are none.
+func faqJsonLd(items []*models.ArticleFaqItem) (json.RawMessage, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	type answer struct {
+		Type string `json:"@type"`
+		Text string `json:"text"`
+	}
+
+	type question struct {
+		Type           string `json:"@type"`
+		Name           string `json:"name"`
+		AcceptedAnswer answer `json:"acceptedAnswer"`
+	}
+
+	page := struct {
+		Context    string     `json:"@context"`
+		Type       string     `json:"@type"`
+		MainEntity []question `json:"mainEntity"`
+	}{
+		Context:    "https://schema.org",
+		Type:       "FAQPage",
+		MainEntity: make([]question, 0, len(items)),
+	}
+
+	for _, item := range items {
+		page.MainEntity = append(page.MainEntity, question{
+			Type:           "Question",
+			Name:           item.Question,
+			AcceptedAnswer: answer{Type: "Answer", Text: item.Answer},
+		})
+	}
+
+	return json.Marshal(page)
+}
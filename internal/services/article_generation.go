@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	chatgpt "github.com/rustoma/octo-pulse/internal/ai/chatGPT"
+	"github.com/rustoma/octo-pulse/internal/dto"
+	"github.com/rustoma/octo-pulse/internal/models"
+	"github.com/rustoma/octo-pulse/internal/storage"
+)
+
+// generationSteps saves the steps of one article generation in the store.
+type generationSteps struct {
+	store     storage.ArticleGenerationStore
+	articleId int
+	completed map[string]string
+}
+
+func (s *articleService) loadGenerationSteps(ctx context.Context, articleId int) (*generationSteps, error) {
+	saved, err := s.articleGenerationStore.GetArticleGenerationSteps(ctx, articleId)
+	if err != nil {
+		return nil, err
+	}
+
+	completed := make(map[string]string, len(saved))
+	for _, step := range saved {
+		completed[step.Step] = step.Content
+	}
+
+	if len(completed) > 0 {
+		logger.Info().Msgf("Resuming generation of article id: %d from %d completed steps", articleId, len(completed))
+	}
+
+	return &generationSteps{store: s.articleGenerationStore, articleId: articleId, completed: completed}, nil
+}
+
+func (g *generationSteps) Completed(step string) (string, bool) {
+	output, ok := g.completed[step]
+	return output, ok
+}
+
+func (g *generationSteps) Complete(ctx context.Context, step string, output string) error {
+	err := g.store.UpsertArticleGenerationStep(ctx, &models.ArticleGenerationStep{
+		ArticleId: g.articleId,
+		Step:      step,
+		Content:   output,
+	})
+	if err != nil {
+		return err
+	}
+
+	g.completed[step] = output
+	return nil
+}
+
+func (s *articleService) GetArticleGenerationProgress(ctx context.Context, articleId int) (*dto.ArticleGenerationProgress, error) {
+	steps, err := s.articleGenerationStore.GetArticleGenerationSteps(ctx, articleId)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &dto.ArticleGenerationProgress{ArticleId: articleId, Steps: steps}
+	completed := make(map[string]string, len(steps))
+
+	for _, step := range steps {
+		completed[step.Step] = step.Content
+
+		if chatgpt.IsSectionStep(step.Step) {
+			progress.SectionsCompleted++
+		}
+	}
+
+	agenda, ok := completed[chatgpt.StepAgenda]
+	if !ok {
+		return progress, nil
+	}
+
+	var articleAgenda chatgpt.ArticleAgenda
+	if err := json.Unmarshal([]byte(agenda), &articleAgenda); err != nil {
+		return nil, err
+	}
+
+	progress.SectionsTotal = 1
+	for section, subtitle := range articleAgenda.Subtitles {
+		progress.SectionsTotal++
+
+		// The subsections of a section the model rejected are not written.
+		if content, ok := completed[chatgpt.SectionStep(section)]; ok && content == "" {
+			continue
+		}
+		progress.SectionsTotal += len(subtitle.Subtitles)
+	}
+
+	return progress, nil
+}
+
+// ClearArticleGenerationSteps is called once the generated body is saved, so the
+// next generation of the article starts from scratch.
+func (s *articleService) ClearArticleGenerationSteps(ctx context.Context, articleId int) error {
+	return s.articleGenerationStore.DeleteArticleGenerationSteps(ctx, articleId)
+}
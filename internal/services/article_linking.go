@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/rustoma/octo-pulse/internal/linking"
+	"github.com/rustoma/octo-pulse/internal/models"
+	"github.com/rustoma/octo-pulse/internal/storage"
+)
+
+// AddInternalLinks links phrases of the article body to other published
+// articles of the same domain, matching their titles and focus keywords. The
+// article is not saved, see SaveArticleLinks.
+func (s *articleService) AddInternalLinks(ctx context.Context, article *models.Article) ([]linking.Link, error) {
+	articles, err := s.articleStore.GetArticles(ctx, &storage.GetArticlesFilters{DomainId: article.DomainId, ExcludeBody: "true"})
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]linking.Target, 0, len(articles))
+	for _, target := range articles {
+		if target.ID == article.ID || !target.IsPublished {
+			continue
+		}
+
+		targets = append(targets, linking.Target{
+			ArticleId: target.ID,
+			Path:      linking.Path(target.Slug),
+			Phrases:   []string{target.Title, target.FocusKeyword},
+		})
+	}
+
+	body, links := linking.Insert(article.Body, targets, internalLinksLimit())
+	article.Body = body
+
+	return links, nil
+}
+
+// SaveArticleLinks records the links added to the article body, replacing the
+// ones recorded before.
+func (s *articleService) SaveArticleLinks(ctx context.Context, articleId int, links []linking.Link) error {
+	articleLinks := make([]*models.ArticleLink, 0, len(links))
+	for _, link := range links {
+		articleLinks = append(articleLinks, &models.ArticleLink{
+			SourceArticleId: articleId,
+			TargetArticleId: link.ArticleId,
+			Anchor:          link.Anchor,
+		})
+	}
+
+	return s.articleLinkStore.ReplaceArticleLinks(ctx, articleId, articleLinks)
+}
+
+// removeInboundLinks unwraps the links pointing to the article from the bodies
+// of the articles that link to it. The bodies are saved and the links dropped
+// together, so no recorded link is left without its anchor in a body.
+func (s *articleService) removeInboundLinks(ctx context.Context, articleId int) error {
+	links, err := s.articleLinkStore.GetInboundArticleLinks(ctx, articleId)
+	if err != nil {
+		return err
+	}
+
+	sources := make([]*models.Article, 0, len(links))
+	for _, link := range links {
+		source, err := s.articleStore.GetArticle(ctx, link.SourceArticleId)
+		if err != nil {
+			return err
+		}
+
+		if source == nil {
+			continue
+		}
+
+		source.Body = linking.Remove(source.Body, articleId)
+		sources = append(sources, source)
+	}
+
+	err = s.articleLinkStore.RemoveInboundArticleLinks(ctx, articleId, sources)
+	if err != nil {
+		return err
+	}
+
+	for _, source := range sources {
+		err = s.saveArticleRevision(ctx, source.ID, source, &models.ArticleRevisionAuthor{Source: models.ArticleRevisionSourceUnlink})
+		if err != nil {
+			logger.Err(err).Msgf("Cannot save the revision of article id: %d", source.ID)
+		}
+	}
+
+	return nil
+}
+
+// internalLinksLimit is the number of links added to an article, read from
+// INTERNAL_LINKS_LIMIT.
+func internalLinksLimit() int {
+	limit, err := strconv.Atoi(os.Getenv("INTERNAL_LINKS_LIMIT"))
+	if err != nil {
+		return linking.DefaultLimit
+	}
+
+	return limit
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/rustoma/octo-pulse/internal/models"
+)
+
+// GetStaleArticles returns up to limit articles of the domain due for a
+// rewrite: the ones overlapping their sources more than overlapAbove and the
+// ones older than the refresh age of the domain. Only articles generated from
+// a question can be rewritten.
+func (s *articleService) GetStaleArticles(ctx context.Context, domain *models.Domain, overlapAbove float64, limit int) ([]*models.Article, error) {
+	var updatedBefore *time.Time
+	if domain.RefreshAfterDays > 0 {
+		before := time.Now().UTC().AddDate(0, 0, -domain.RefreshAfterDays)
+		updatedBefore = &before
+	}
+
+	return s.articleStore.GetStaleArticles(ctx, domain.ID, updatedBefore, overlapAbove, limit)
+}
+
+// RequestArticleRefresh reserves a refresh of the daily quota of the domain of
+// the article. It is called when the refresh is enqueued.
+func (s *articleService) RequestArticleRefresh(ctx context.Context, articleId int) error {
+	return s.articleStore.RequestArticleRefresh(ctx, articleId)
+}
+
+// CountArticleRefreshes counts the refreshes of the domain's articles that
+// completed since the given time, from the revisions they recorded, and the
+// ones requested since then that are still queued.
+func (s *articleService) CountArticleRefreshes(ctx context.Context, domainId int, since time.Time) (int, error) {
+	return s.articleRevisionStore.CountDomainArticleRefreshes(ctx, domainId, since)
+}
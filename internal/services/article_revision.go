@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rustoma/octo-pulse/internal/dto"
+	e "github.com/rustoma/octo-pulse/internal/errors"
+	"github.com/rustoma/octo-pulse/internal/htmldiff"
+	"github.com/rustoma/octo-pulse/internal/models"
+)
+
+// saveArticleRevision records the current content of the article as a revision
+// made by author. Nothing is recorded when the content matches the latest
+// revision.
+func (s *articleService) saveArticleRevision(ctx context.Context, articleId int, article *models.Article, author *models.ArticleRevisionAuthor) error {
+	revision := &models.ArticleRevision{
+		ArticleId:             articleId,
+		Title:                 article.Title,
+		Slug:                  article.Slug,
+		Body:                  article.Body,
+		MetaTitle:             article.MetaTitle,
+		MetaDescription:       article.MetaDescription,
+		FocusKeyword:          article.FocusKeyword,
+		Excerpt:               article.Excerpt,
+		ArticleRevisionAuthor: *author,
+	}
+
+	latest, err := s.articleRevisionStore.GetLatestArticleRevision(ctx, articleId)
+	if err != nil {
+		return err
+	}
+
+	if latest != nil && sameRevisionContent(latest, revision) {
+		return nil
+	}
+
+	_, err = s.articleRevisionStore.InsertArticleRevision(ctx, revision)
+	return err
+}
+
+func sameRevisionContent(a *models.ArticleRevision, b *models.ArticleRevision) bool {
+	return a.Title == b.Title &&
+		a.Slug == b.Slug &&
+		a.Body == b.Body &&
+		a.MetaTitle == b.MetaTitle &&
+		a.MetaDescription == b.MetaDescription &&
+		a.FocusKeyword == b.FocusKeyword &&
+		a.Excerpt == b.Excerpt
+}
+
+// GetArticleRevisions returns the revisions of the article, the newest first.
+// The body is not selected.
+func (s *articleService) GetArticleRevisions(ctx context.Context, articleId int) ([]*models.ArticleRevision, error) {
+	return s.articleRevisionStore.GetArticleRevisions(ctx, articleId)
+}
+
+func (s *articleService) GetArticleRevision(ctx context.Context, articleId int, revisionId int) (*models.ArticleRevision, error) {
+	revision, err := s.articleRevisionStore.GetArticleRevision(ctx, revisionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if revision == nil || revision.ArticleId != articleId {
+		return nil, e.NotFound{Err: fmt.Sprintf("revision with id %d of article with id %d not found", revisionId, articleId)}
+	}
+
+	return revision, nil
+}
+
+// DiffArticleRevisions compares the from revision of the article with the to
+// revision.
+func (s *articleService) DiffArticleRevisions(ctx context.Context, articleId int, fromId int, toId int) (*dto.ArticleRevisionDiff, error) {
+	from, err := s.GetArticleRevision(ctx, articleId, fromId)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.GetArticleRevision(ctx, articleId, toId)
+	if err != nil {
+		return nil, err
+	}
+
+	body := htmldiff.Diff(from.Body, to.Body)
+
+	diff := &dto.ArticleRevisionDiff{
+		FromRevisionId: from.ID,
+		ToRevisionId:   to.ID,
+		Title:          htmldiff.Diff(from.Title, to.Title).Html,
+		Body:           body.Html,
+		Insertions:     body.Insertions,
+		Deletions:      body.Deletions,
+		Fields:         make([]*dto.ArticleFieldChange, 0),
+	}
+
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"slug", from.Slug, to.Slug},
+		{"metaTitle", from.MetaTitle, to.MetaTitle},
+		{"metaDescription", from.MetaDescription, to.MetaDescription},
+		{"focusKeyword", from.FocusKeyword, to.FocusKeyword},
+		{"excerpt", from.Excerpt, to.Excerpt},
+	}
+
+	for _, field := range fields {
+		if field.from != field.to {
+			diff.Fields = append(diff.Fields, &dto.ArticleFieldChange{Field: field.name, From: field.from, To: field.to})
+		}
+	}
+
+	return diff, nil
+}
+
+// RestoreArticleRevision brings the title, body and SEO metadata of the
+// revision back to the article. The restore is recorded as a new revision, so
+// it can be undone the same way.
+func (s *articleService) RestoreArticleRevision(ctx context.Context, articleId int, revisionId int, user *models.User) (*models.Article, error) {
+	revision, err := s.GetArticleRevision(ctx, articleId, revisionId)
+	if err != nil {
+		return nil, err
+	}
+
+	article, err := s.articleStore.GetArticle(ctx, articleId)
+	if err != nil {
+		return nil, err
+	}
+
+	if article == nil {
+		return nil, e.NotFound{Err: fmt.Sprintf("article with id %d not found", articleId)}
+	}
+
+	article.Title = revision.Title
+	article.Body = revision.Body
+	article.MetaTitle = revision.MetaTitle
+	article.MetaDescription = revision.MetaDescription
+	article.FocusKeyword = revision.FocusKeyword
+	article.Excerpt = revision.Excerpt
+
+	_, err = s.UpdateArticle(ctx, articleId, article, &models.ArticleRevisionAuthor{
+		Source:         models.ArticleRevisionSourceRestore,
+		UserId:         &user.ID,
+		RestoredFromId: &revision.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.articleStore.GetArticle(ctx, articleId)
+}
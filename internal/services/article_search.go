@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rustoma/octo-pulse/internal/dto"
+	e "github.com/rustoma/octo-pulse/internal/errors"
+	"github.com/rustoma/octo-pulse/internal/language"
+	"github.com/rustoma/octo-pulse/internal/storage"
+)
+
+// defaultSearchLimit is the page size of a search that does not set one.
+const defaultSearchLimit = 20
+
+// SearchArticles returns a page of the articles of the domain matching the
+// query, best ranked first, with the number of all the matching articles. The
+// query is parsed with the text search configuration of the domain language
+// and supports quoted phrases, "or" and "-" for excluded words. Search results
+// are paged by limit and offset only, so NextCursor is always null.
+func (s *articleService) SearchArticles(ctx context.Context, filters *storage.SearchArticlesFilters) (*dto.Page[*dto.ArticleSearchResult], error) {
+	filters.Query = strings.TrimSpace(filters.Query)
+	if filters.Query == "" {
+		return nil, e.BadRequest{Err: "search query is required"}
+	}
+
+	if filters.DomainId == 0 {
+		return nil, e.BadRequest{Err: "domainId is required"}
+	}
+
+	domain, err := s.domainStore.GetDomain(ctx, filters.DomainId)
+	if err != nil {
+		return nil, err
+	}
+
+	if domain == nil {
+		return nil, e.NotFound{Err: fmt.Sprintf("domain with id %d not found", filters.DomainId)}
+	}
+
+	filters.Language = language.Get(domain.Language).Code
+
+	if filters.Limit == 0 {
+		filters.Limit = defaultSearchLimit
+	}
+
+	results, err := s.articleStore.SearchArticles(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.articleStore.CountSearchArticles(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dto.Page[*dto.ArticleSearchResult]{Items: results, Total: total}
+	if page.Items == nil {
+		page.Items = []*dto.ArticleSearchResult{}
+	}
+
+	return page, nil
+}
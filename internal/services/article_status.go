@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rustoma/octo-pulse/internal/dto"
+	e "github.com/rustoma/octo-pulse/internal/errors"
+	"github.com/rustoma/octo-pulse/internal/models"
+	"github.com/rustoma/octo-pulse/internal/originality"
+)
+
+// articleStatusTransitions lists the statuses an article can be moved to from
+// each status.
+var articleStatusTransitions = map[string][]string{
+	models.ArticleStatusDraft:       {models.ArticleStatusGenerating, models.ArticleStatusNeedsReview, models.ArticleStatusArchived},
+	models.ArticleStatusGenerating:  {models.ArticleStatusNeedsReview, models.ArticleStatusDraft},
+	models.ArticleStatusNeedsReview: {models.ArticleStatusApproved, models.ArticleStatusGenerating, models.ArticleStatusDraft, models.ArticleStatusArchived},
+	models.ArticleStatusApproved:    {models.ArticleStatusPublished, models.ArticleStatusScheduled, models.ArticleStatusNeedsReview, models.ArticleStatusGenerating, models.ArticleStatusArchived},
+	models.ArticleStatusScheduled:   {models.ArticleStatusPublished, models.ArticleStatusApproved, models.ArticleStatusArchived},
+	models.ArticleStatusPublished:   {models.ArticleStatusNeedsReview, models.ArticleStatusGenerating, models.ArticleStatusArchived},
+	models.ArticleStatusArchived:    {models.ArticleStatusDraft},
+}
+
+func canChangeArticleStatus(from string, to string) bool {
+	for _, status := range articleStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isPublicStatus reports whether the article is, or is going to be, shown on
+// the domain without another review.
+func isPublicStatus(status string) bool {
+	return status == models.ArticleStatusPublished || status == models.ArticleStatusScheduled
+}
+
+// OriginalityThreshold is the source overlap above which only admins can
+// approve a generated article and a refreshed one is taken down for review,
+// read from ORIGINALITY_THRESHOLD.
+func OriginalityThreshold() float64 {
+	value := os.Getenv("ORIGINALITY_THRESHOLD")
+	if value == "" {
+		return originality.DefaultThreshold
+	}
+
+	threshold, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		logger.Err(err).Msgf("Invalid ORIGINALITY_THRESHOLD, using %.2f", originality.DefaultThreshold)
+		return originality.DefaultThreshold
+	}
+
+	return threshold
+}
+
+// overlapsSources reports whether the article copies its sources more than
+// OriginalityThreshold allows.
+func overlapsSources(article *models.Article) bool {
+	return article.SourceOverlap != nil && *article.SourceOverlap > OriginalityThreshold()
+}
+
+// UpdateArticleSourceOverlap saves the result of the originality check of the
+// article body. UpdateArticle never changes it, so an edit cannot clear the
+// score an approval is checked against.
+func (s *articleService) UpdateArticleSourceOverlap(ctx context.Context, articleId int, overlap float64) error {
+	return s.articleStore.UpdateArticleSourceOverlap(ctx, articleId, overlap)
+}
+
+// ChangeArticleStatus moves the article through the editorial workflow on
+// behalf of user, or of the workers when user is nil. Only admins can publish,
+// schedule or take down a published article, and approve one overlapping its
+// sources above OriginalityThreshold. A published article gets the
+// current publication date unless it was scheduled.
+func (s *articleService) ChangeArticleStatus(ctx context.Context, articleId int, request *dto.ArticleStatusRequest, user *models.User) (*models.Article, error) {
+	article, err := s.articleStore.GetArticle(ctx, articleId)
+	if err != nil {
+		return nil, err
+	}
+
+	if article == nil {
+		return nil, e.NotFound{Err: fmt.Sprintf("article with id %d not found", articleId)}
+	}
+
+	if _, ok := articleStatusTransitions[request.Status]; !ok {
+		return nil, e.BadRequest{Err: fmt.Sprintf("unknown article status %q", request.Status)}
+	}
+
+	if !canChangeArticleStatus(article.Status, request.Status) {
+		return nil, e.BadRequest{Err: fmt.Sprintf("article cannot be moved from %s to %s", article.Status, request.Status)}
+	}
+
+	if user != nil && user.RoleId != models.DefaultUserRoles.Admin && (isPublicStatus(article.Status) || isPublicStatus(request.Status)) {
+		return nil, e.Forbidden{Err: "only admins can publish or unpublish articles"}
+	}
+
+	if user != nil && user.RoleId != models.DefaultUserRoles.Admin && request.Status == models.ArticleStatusApproved && overlapsSources(article) {
+		return nil, e.Forbidden{Err: "only admins can approve articles overlapping their sources"}
+	}
+
+	var publicationDate *time.Time
+
+	switch request.Status {
+	case models.ArticleStatusScheduled:
+		if request.PublishAt == nil || !request.PublishAt.After(time.Now()) {
+			return nil, e.BadRequest{Err: "scheduled articles need a publication date in the future"}
+		}
+		publishAt := request.PublishAt.UTC()
+		publicationDate = &publishAt
+	case models.ArticleStatusPublished:
+		if article.Status != models.ArticleStatusScheduled {
+			now := time.Now().UTC()
+			publicationDate = &now
+		}
+	}
+
+	transition := &models.ArticleStatusTransition{
+		ArticleId:  articleId,
+		FromStatus: article.Status,
+		ToStatus:   request.Status,
+	}
+
+	if user != nil {
+		transition.UserId = &user.ID
+	}
+
+	updatedArticleId, err := s.articleStatusStore.UpdateArticleStatus(ctx, transition, publicationDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if updatedArticleId == 0 {
+		return nil, e.BadRequest{Err: "article status was changed in the meantime, try again"}
+	}
+
+	article.Status = request.Status
+	article.IsPublished = request.Status == models.ArticleStatusPublished
+	if publicationDate != nil {
+		article.PublicationDate = *publicationDate
+	}
+
+	return article, nil
+}
+
+func (s *articleService) GetArticleStatusTransitions(ctx context.Context, articleId int) ([]*models.ArticleStatusTransition, error) {
+	return s.articleStatusStore.GetArticleStatusTransitions(ctx, articleId)
+}
+
+// GetDueScheduledArticles returns the scheduled articles whose publication date
+// has come.
+func (s *articleService) GetDueScheduledArticles(ctx context.Context) ([]*models.Article, error) {
+	return s.articleStatusStore.GetDueScheduledArticles(ctx, time.Now().UTC())
+}
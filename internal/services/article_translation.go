@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+	"github.com/rustoma/octo-pulse/internal/dto"
+	"github.com/rustoma/octo-pulse/internal/language"
+	"github.com/rustoma/octo-pulse/internal/linking"
+	"github.com/rustoma/octo-pulse/internal/models"
+)
+
+// TranslateArticle creates a draft in the to domain with the article translated
+// into the language of the domain and filed under categoryId, and links it to
+// the article as its translation. The internal links of the article are
+// replaced with links to the articles of the to domain. It returns the id of
+// the draft.
+func (s *articleService) TranslateArticle(ctx context.Context, article *models.Article, from *models.Domain, to *models.Domain, categoryId int, meter *llm.Meter) (int, error) {
+	source := *article
+	source.Body = linking.RemoveAll(article.Body)
+
+	translation, err := s.ai.ChatGPT.WithMeter(meter).TranslateArticle(ctx, &source, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	draft := &models.Article{
+		Title:           translation.Title,
+		Body:            translation.Body,
+		Thumbnail:       article.Thumbnail,
+		PublicationDate: time.Now().UTC(),
+		AuthorId:        article.AuthorId,
+		CategoryId:      categoryId,
+		DomainId:        to.ID,
+		IsSponsored:     article.IsSponsored,
+		CreatedAt:       time.Now().UTC(),
+		UpdatedAt:       time.Now().UTC(),
+		MetaTitle:       translation.MetaTitle,
+		MetaDescription: translation.MetaDescription,
+		FocusKeyword:    translation.FocusKeyword,
+		Excerpt:         translation.Excerpt,
+		Status:          models.ArticleStatusDraft,
+	}
+
+	links, err := s.AddInternalLinks(ctx, draft)
+	if err != nil {
+		logger.Err(err).Msgf("Cannot add internal links to the translation of article id: %d", article.ID)
+	}
+
+	draftId, err := s.CreateArticle(ctx, draft)
+	if err != nil {
+		return 0, err
+	}
+
+	err = s.articleTranslationStore.InsertArticleTranslation(ctx, draftId, article.ID)
+	if err != nil {
+		_ = s.DiscardArticle(ctx, draftId)
+		return 0, err
+	}
+
+	err = s.SaveArticleLinks(ctx, draftId, links)
+	if err != nil {
+		logger.Err(err).Msgf("Cannot save internal links of article id: %d", draftId)
+	}
+
+	return draftId, nil
+}
+
+// GetArticleTranslations returns the article and every translation of it, in
+// any status. The body is not selected.
+func (s *articleService) GetArticleTranslations(ctx context.Context, articleId int) ([]*models.Article, error) {
+	return s.articleTranslationStore.GetTranslatedArticles(ctx, articleId)
+}
+
+// articleAlternates returns the published translations of the article as
+// hreflang alternates, the article included. It returns nil when the article
+// has no published translation.
+func (s *articleService) articleAlternates(ctx context.Context, articleId int) ([]*dto.ArticleAlternate, error) {
+	articles, err := s.articleTranslationStore.GetTranslatedArticles(ctx, articleId)
+	if err != nil {
+		return nil, err
+	}
+
+	domains := make(map[int]*models.Domain)
+	var alternates []*dto.ArticleAlternate
+
+	for _, article := range articles {
+		if article.Status != models.ArticleStatusPublished {
+			continue
+		}
+
+		domain, ok := domains[article.DomainId]
+		if !ok {
+			domain, err = s.domainStore.GetDomain(ctx, article.DomainId)
+			if err != nil {
+				return nil, err
+			}
+			domains[article.DomainId] = domain
+		}
+
+		if domain == nil {
+			continue
+		}
+
+		alternates = append(alternates, &dto.ArticleAlternate{
+			ArticleId: article.ID,
+			DomainId:  domain.ID,
+			HrefLang:  hrefLang(domain),
+			Href:      "https://" + domain.Name + linking.Path(article.Slug),
+		})
+	}
+
+	if len(alternates) < 2 {
+		return nil, nil
+	}
+
+	return alternates, nil
+}
+
+// hrefLang returns the hreflang value of the domain, e.g. "pl-PL" for the
+// pl_PL locale, or its language code when it has no locale.
+func hrefLang(domain *models.Domain) string {
+	if domain.Locale != "" {
+		return strings.ReplaceAll(domain.Locale, "_", "-")
+	}
+
+	return language.Get(domain.Language).Code
+}
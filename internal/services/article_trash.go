@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rustoma/octo-pulse/internal/dto"
+	e "github.com/rustoma/octo-pulse/internal/errors"
+	"github.com/rustoma/octo-pulse/internal/storage"
+)
+
+// defaultTrashRetentionDays is the number of days a deleted article is kept in
+// the trash before it is purged.
+const defaultTrashRetentionDays = 30
+
+// RestoreArticle takes the article out of the trash. The links other articles
+// had to it are not restored.
+func (s *articleService) RestoreArticle(ctx context.Context, id int) (int, error) {
+	article, err := s.articleStore.GetDeletedArticle(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if article == nil {
+		return 0, e.NotFound{Err: fmt.Sprintf("article with id %d not found in the trash", id)}
+	}
+
+	sameSlug, err := s.articleStore.CountArticles(ctx, &storage.GetArticlesFilters{DomainId: article.DomainId, Slug: article.Slug})
+	if err != nil {
+		return 0, err
+	}
+
+	if sameSlug > 0 {
+		return 0, e.BadRequest{Err: fmt.Sprintf("another article of the domain already uses the slug %s", article.Slug)}
+	}
+
+	return s.articleStore.RestoreArticle(ctx, id)
+}
+
+// PurgeArticle deletes an article in the trash permanently.
+func (s *articleService) PurgeArticle(ctx context.Context, id int) (int, error) {
+	article, err := s.articleStore.GetDeletedArticle(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	if article == nil {
+		return 0, e.NotFound{Err: fmt.Sprintf("article with id %d not found in the trash", id)}
+	}
+
+	return s.articleStore.PurgeArticle(ctx, id)
+}
+
+// DiscardArticle deletes an article permanently without moving it to the
+// trash. It undoes the creation of an article whose setup failed, before any
+// other article links to it.
+func (s *articleService) DiscardArticle(ctx context.Context, id int) error {
+	_, err := s.articleStore.DiscardArticle(ctx, id)
+	return err
+}
+
+// GetDeletedArticles returns a page of the articles in the trash, the most
+// recently deleted first, with the time each of them is purged at.
+func (s *articleService) GetDeletedArticles(ctx context.Context, filters *storage.GetDeletedArticlesFilters) (*dto.Page[*dto.DeletedArticle], error) {
+	articles, err := s.articleStore.GetDeletedArticles(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.articleStore.CountDeletedArticles(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	retention := trashRetention()
+	for _, article := range articles {
+		article.PurgeAt = article.DeletedAt.Add(retention)
+	}
+
+	page := &dto.Page[*dto.DeletedArticle]{Items: articles, Total: total}
+	if page.Items == nil {
+		page.Items = []*dto.DeletedArticle{}
+	}
+
+	return page, nil
+}
+
+// PurgeExpiredArticles permanently deletes the articles kept in the trash for
+// longer than the retention period and returns their number.
+func (s *articleService) PurgeExpiredArticles(ctx context.Context) (int, error) {
+	return s.articleStore.PurgeDeletedArticles(ctx, time.Now().UTC().Add(-trashRetention()))
+}
+
+// trashRetention is read from ARTICLE_TRASH_RETENTION_DAYS.
+func trashRetention() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("ARTICLE_TRASH_RETENTION_DAYS"))
+	if err != nil || days < 0 {
+		days = defaultTrashRetentionDays
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
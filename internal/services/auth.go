@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -18,13 +19,14 @@ import (
 )
 
 type AuthService interface {
-	Login(*dto.AuthLogin) (*dto.AuthUser, *http.Cookie, error)
-	Logout(*dto.LogoutRequest) (*http.Cookie, error)
-	RefreshToken(refreshTokenRequest *dto.RefreshTokenRequest) (string, error)
+	Login(ctx context.Context, userCredentials *dto.AuthLogin) (*dto.AuthUser, *http.Cookie, error)
+	Logout(ctx context.Context, logoutRequest *dto.LogoutRequest) (*http.Cookie, error)
+	RefreshToken(ctx context.Context, refreshTokenRequest *dto.RefreshTokenRequest) (string, error)
 	CheckPassword(password string, hashedPassword string) error
 	HashPassword(password string) (string, error)
 	BearerToken(r *http.Request, header string) (string, error)
 	IsJWTTokenValid(tokenString string, validRoles ...int) error
+	GetRequestUser(ctx context.Context, r *http.Request) (*models.User, error)
 	validateUserRole(userRoles int, validRoles []int) error
 	parseToken(jwtString string) (*jwt.Token, error)
 	generateJWTToken(claims JWTClaims) (string, error)
@@ -44,14 +46,11 @@ type JWTClaims struct {
 }
 
 func NewAuthService(userStore storage.UserStore) AuthService {
-	return &authService{userStore: userStore, userRoles: models.UserRoles{
-		Admin:  1,
-		Editor: 2,
-	}}
+	return &authService{userStore: userStore, userRoles: models.DefaultUserRoles}
 }
 
-func (a *authService) Login(userCredentials *dto.AuthLogin) (*dto.AuthUser, *http.Cookie, error) {
-	user, err := a.userStore.GetUserByEmail(userCredentials.Email)
+func (a *authService) Login(ctx context.Context, userCredentials *dto.AuthLogin) (*dto.AuthUser, *http.Cookie, error) {
+	user, err := a.userStore.GetUserByEmail(ctx, userCredentials.Email)
 
 	if err != nil {
 		return nil, nil, e.NotFound{Err: "user not found"}
@@ -90,7 +89,7 @@ func (a *authService) Login(userCredentials *dto.AuthLogin) (*dto.AuthUser, *htt
 	encodedJWT, _ := a.generateJWTToken(JWTTokenClaims)
 	encodedRefreshToken, _ := a.generateJWTToken(refreshTokenClaims)
 
-	_, err = a.userStore.UpdateRefreshToken(user.ID, encodedRefreshToken)
+	_, err = a.userStore.UpdateRefreshToken(ctx, user.ID, encodedRefreshToken)
 
 	if err != nil {
 		return nil, nil, err
@@ -111,7 +110,7 @@ func (a *authService) Login(userCredentials *dto.AuthLogin) (*dto.AuthUser, *htt
 	return &dto.AuthUser{User: user, AccessToken: encodedJWT}, &cookie, nil
 }
 
-func (a *authService) Logout(logoutRequest *dto.LogoutRequest) (*http.Cookie, error) {
+func (a *authService) Logout(ctx context.Context, logoutRequest *dto.LogoutRequest) (*http.Cookie, error) {
 
 	cookie := &http.Cookie{
 		Name:     "jwt",
@@ -123,13 +122,13 @@ func (a *authService) Logout(logoutRequest *dto.LogoutRequest) (*http.Cookie, er
 		SameSite: 4,
 	}
 
-	user, err := a.userStore.SelectUserByRefreshToken(logoutRequest.RefreshToken)
+	user, err := a.userStore.SelectUserByRefreshToken(ctx, logoutRequest.RefreshToken)
 
 	if err != nil {
 		return cookie, e.NotFound{Err: "user not found"}
 	}
 
-	_, err = a.userStore.UpdateRefreshToken(user.ID, "")
+	_, err = a.userStore.UpdateRefreshToken(ctx, user.ID, "")
 
 	if err != nil {
 		return nil, err
@@ -138,9 +137,9 @@ func (a *authService) Logout(logoutRequest *dto.LogoutRequest) (*http.Cookie, er
 	return cookie, nil
 }
 
-func (a *authService) RefreshToken(refreshTokenRequest *dto.RefreshTokenRequest) (string, error) {
+func (a *authService) RefreshToken(ctx context.Context, refreshTokenRequest *dto.RefreshTokenRequest) (string, error) {
 
-	user, err := a.userStore.SelectUserByRefreshToken(refreshTokenRequest.RefreshToken)
+	user, err := a.userStore.SelectUserByRefreshToken(ctx, refreshTokenRequest.RefreshToken)
 	if err != nil {
 		return "", e.NotFound{Err: err.Error()}
 	}
@@ -263,6 +262,35 @@ func (a *authService) IsJWTTokenValid(tokenString string, validRoles ...int) err
 	}
 }
 
+// GetRequestUser returns the user of the JWT token sent with the request.
+func (a *authService) GetRequestUser(ctx context.Context, r *http.Request) (*models.User, error) {
+	tokenString, err := a.BearerToken(r, "Authorization")
+	if err != nil {
+		return nil, e.Unauthorized{Err: err.Error()}
+	}
+
+	token, err := a.parseToken(tokenString)
+	if err != nil {
+		return nil, e.Unauthorized{Err: err.Error()}
+	}
+
+	claims, ok := token.Claims.(*JWTClaims)
+	if !ok || !token.Valid {
+		return nil, e.Unauthorized{Err: "JWT Claims are not correct"}
+	}
+
+	user, err := a.userStore.GetUserByEmail(ctx, claims.Email)
+	if err != nil || user == nil {
+		return nil, e.Unauthorized{Err: "user not found"}
+	}
+
+	if !user.IsEnabled {
+		return nil, e.Unauthorized{Err: "user is disabled"}
+	}
+
+	return user, nil
+}
+
 func (a *authService) BearerToken(r *http.Request, header string) (string, error) {
 	rawToken := r.Header.Get(header)
 	pieces := strings.SplitN(rawToken, " ", 2)
@@ -1,16 +1,17 @@
 package services
 
 import (
+	"context"
 	"github.com/rustoma/octo-pulse/internal/models"
 	"github.com/rustoma/octo-pulse/internal/storage"
 	"github.com/rustoma/octo-pulse/internal/validator"
 )
 
 type AuthorService interface {
-	GetAuthor(id int) (*models.Author, error)
-	GetAuthors() ([]*models.Author, error)
-	CreateAuthor(author *models.Author) (int, error)
-	UpdateAuthor(id int, author *models.Author) (int, error)
+	GetAuthor(ctx context.Context, id int) (*models.Author, error)
+	GetAuthors(ctx context.Context) ([]*models.Author, error)
+	CreateAuthor(ctx context.Context, author *models.Author) (int, error)
+	UpdateAuthor(ctx context.Context, id int, author *models.Author) (int, error)
 }
 
 type authorService struct {
@@ -22,29 +23,29 @@ func NewAuthorService(authorStore storage.AuthorStore, authorValidator validator
 	return &authorService{authorStore: authorStore, authorValidator: authorValidator}
 }
 
-func (s *authorService) GetAuthor(id int) (*models.Author, error) {
-	return s.authorStore.GetAuthor(id)
+func (s *authorService) GetAuthor(ctx context.Context, id int) (*models.Author, error) {
+	return s.authorStore.GetAuthor(ctx, id)
 }
 
-func (s *authorService) GetAuthors() ([]*models.Author, error) {
-	return s.authorStore.GetAuthors()
+func (s *authorService) GetAuthors(ctx context.Context) ([]*models.Author, error) {
+	return s.authorStore.GetAuthors(ctx)
 }
 
-func (s *authorService) CreateAuthor(author *models.Author) (int, error) {
+func (s *authorService) CreateAuthor(ctx context.Context, author *models.Author) (int, error) {
 	err := s.authorValidator.Validate(author)
 	if err != nil {
 		logger.Err(err).Send()
 		return 0, err
 	}
 
-	return s.authorStore.InsertAuthor(author)
+	return s.authorStore.InsertAuthor(ctx, author)
 }
 
-func (s *authorService) UpdateAuthor(id int, author *models.Author) (int, error) {
+func (s *authorService) UpdateAuthor(ctx context.Context, id int, author *models.Author) (int, error) {
 	err := s.authorValidator.Validate(author)
 	if err != nil {
 		return 0, err
 	}
 
-	return s.authorStore.UpdateAuthor(id, author)
+	return s.authorStore.UpdateAuthor(ctx, id, author)
 }
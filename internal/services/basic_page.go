@@ -1,60 +1,115 @@
 package services
 
 import (
+	"context"
 	"github.com/gosimple/slug"
+	"github.com/rustoma/octo-pulse/internal/dto"
 	"github.com/rustoma/octo-pulse/internal/models"
 	"github.com/rustoma/octo-pulse/internal/storage"
 	"github.com/rustoma/octo-pulse/internal/validator"
 )
 
 type BasicPageService interface {
-	CreateBasicPage(page *models.BasicPage) (int, error)
-	GetBasicPage(id int) (*models.BasicPage, error)
-	GetBasicPageBySlug(slug string, filters ...*storage.GetBasicPageBySlugFilters) (*models.BasicPage, error)
-	GetBasicPages(filters ...*storage.GetBasicPagesFilters) ([]*models.BasicPage, error)
-	UpdateBasicPage(id int, basicPage *models.BasicPage) (int, error)
+	CreateBasicPage(ctx context.Context, page *models.BasicPage) (int, error)
+	GetBasicPage(ctx context.Context, id int) (*models.BasicPage, error)
+	GetBasicPageBySlug(ctx context.Context, slug string, filters ...*storage.GetBasicPageBySlugFilters) (*models.BasicPage, error)
+	GetBasicPages(ctx context.Context, filters ...*storage.GetBasicPagesFilters) ([]*models.BasicPage, error)
+	GetBasicPagesPage(ctx context.Context, filters *storage.GetBasicPagesFilters) (*dto.Page[*models.BasicPage], error)
+	UpdateBasicPage(ctx context.Context, id int, basicPage *models.BasicPage) (int, error)
 }
 
 type basicPageService struct {
 	basicPageStore     storage.BasicPageStore
+	domainStore        storage.DomainStore
 	basicPageValidator validator.BasicPageValidatorer
 }
 
-func NewBasicPageService(basicPageStore storage.BasicPageStore, basicPageValidator validator.BasicPageValidatorer) BasicPageService {
-	return &basicPageService{basicPageStore: basicPageStore, basicPageValidator: basicPageValidator}
+func NewBasicPageService(basicPageStore storage.BasicPageStore, domainStore storage.DomainStore, basicPageValidator validator.BasicPageValidatorer) BasicPageService {
+	return &basicPageService{basicPageStore: basicPageStore, domainStore: domainStore, basicPageValidator: basicPageValidator}
 }
 
-func (s *basicPageService) GetBasicPages(filters ...*storage.GetBasicPagesFilters) ([]*models.BasicPage, error) {
-	return s.basicPageStore.GetBasicPages(filters...)
+func (s *basicPageService) makeSlug(ctx context.Context, page *models.BasicPage) (string, error) {
+	domain, err := s.domainStore.GetDomain(ctx, page.Domain)
+	if err != nil {
+		return "", err
+	}
+
+	if domain == nil {
+		return slug.Make(page.Title), nil
+	}
+
+	return slug.MakeLang(page.Title, domain.Language), nil
 }
 
-func (s *basicPageService) GetBasicPage(id int) (*models.BasicPage, error) {
-	return s.basicPageStore.GetBasicPage(id)
+func (s *basicPageService) GetBasicPages(ctx context.Context, filters ...*storage.GetBasicPagesFilters) ([]*models.BasicPage, error) {
+	return s.basicPageStore.GetBasicPages(ctx, filters...)
 }
 
-func (s *basicPageService) GetBasicPageBySlug(slug string, filters ...*storage.GetBasicPageBySlugFilters) (*models.BasicPage, error) {
-	return s.basicPageStore.GetBasicPageBySlug(slug, filters...)
+// GetBasicPagesPage returns a page of the basic pages with the number of all
+// the basic pages matching the filters and, when the page is full, the cursor
+// of the next one.
+func (s *basicPageService) GetBasicPagesPage(ctx context.Context, filters *storage.GetBasicPagesFilters) (*dto.Page[*models.BasicPage], error) {
+	basicPages, err := s.basicPageStore.GetBasicPages(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.basicPageStore.CountBasicPages(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dto.Page[*models.BasicPage]{Items: basicPages, Total: total}
+	if page.Items == nil {
+		page.Items = []*models.BasicPage{}
+	}
+
+	if filters.Limit > 0 && len(basicPages) == filters.Limit {
+		last := basicPages[len(basicPages)-1]
+		cursor := (&storage.Cursor{Date: last.CreatedAt, Id: last.ID}).Encode()
+		page.NextCursor = &cursor
+	}
+
+	return page, nil
+}
+
+func (s *basicPageService) GetBasicPage(ctx context.Context, id int) (*models.BasicPage, error) {
+	return s.basicPageStore.GetBasicPage(ctx, id)
 }
 
-func (s *basicPageService) CreateBasicPage(page *models.BasicPage) (int, error) {
-	page.Slug = slug.Make(page.Title)
+func (s *basicPageService) GetBasicPageBySlug(ctx context.Context, slug string, filters ...*storage.GetBasicPageBySlugFilters) (*models.BasicPage, error) {
+	return s.basicPageStore.GetBasicPageBySlug(ctx, slug, filters...)
+}
 
-	err := s.basicPageValidator.Validate(page)
+func (s *basicPageService) CreateBasicPage(ctx context.Context, page *models.BasicPage) (int, error) {
+	pageSlug, err := s.makeSlug(ctx, page)
+	if err != nil {
+		return 0, err
+	}
+
+	page.Slug = pageSlug
+
+	err = s.basicPageValidator.Validate(page)
 	if err != nil {
 		logger.Err(err).Send()
 		return 0, err
 	}
 
-	return s.basicPageStore.InsertBasicPage(page)
+	return s.basicPageStore.InsertBasicPage(ctx, page)
 }
 
-func (s *basicPageService) UpdateBasicPage(id int, basicPage *models.BasicPage) (int, error) {
-	basicPage.Slug = slug.Make(basicPage.Title)
+func (s *basicPageService) UpdateBasicPage(ctx context.Context, id int, basicPage *models.BasicPage) (int, error) {
+	pageSlug, err := s.makeSlug(ctx, basicPage)
+	if err != nil {
+		return 0, err
+	}
+
+	basicPage.Slug = pageSlug
 
-	err := s.basicPageValidator.Validate(basicPage)
+	err = s.basicPageValidator.Validate(basicPage)
 	if err != nil {
 		return 0, err
 	}
 
-	return s.basicPageStore.UpdateBasicPage(id, basicPage)
+	return s.basicPageStore.UpdateBasicPage(ctx, id, basicPage)
 }
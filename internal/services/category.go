@@ -1,48 +1,57 @@
 package services
 
 import (
+	"context"
 	"github.com/gosimple/slug"
+	a "github.com/rustoma/octo-pulse/internal/ai"
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
 	"github.com/rustoma/octo-pulse/internal/models"
 	"github.com/rustoma/octo-pulse/internal/storage"
 	"github.com/rustoma/octo-pulse/internal/validator"
 )
 
 type CategoryService interface {
-	GetCategories(filters ...*storage.GetCategoriesFilters) ([]*models.Category, error)
-	GetCategory(id int) (*models.Category, error)
-	GetDomainCategories(domainId int) ([]*models.Category, error)
-	CreateCategory(category *models.Category) (int, error)
-	AssignCategoryToDomain(categoryId int, domainId int) error
-	UpdateCategory(id int, category *models.Category) (int, error)
+	GetCategories(ctx context.Context, filters ...*storage.GetCategoriesFilters) ([]*models.Category, error)
+	GetCategory(ctx context.Context, id int) (*models.Category, error)
+	GetDomainCategories(ctx context.Context, domainId int) ([]*models.Category, error)
+	CreateCategory(ctx context.Context, category *models.Category) (int, error)
+	AssignCategoryToDomain(ctx context.Context, categoryId int, domainId int) error
+	UpdateCategory(ctx context.Context, id int, category *models.Category) (int, error)
+	AssignQuestionToCategory(ctx context.Context, categories []*models.Category, question *models.Question, domain *models.Domain, meter *llm.Meter) (*models.CategoryAssignment, error)
+	GetCategoryAssignments(ctx context.Context, filters ...*storage.GetCategoryAssignmentsFilters) ([]*models.CategoryAssignment, error)
+	MapCategoryToDomain(ctx context.Context, categoryId int, title string, domain *models.Domain, meter *llm.Meter) (*models.Category, error)
 }
 
 type categoryService struct {
-	categoryStore          storage.CategoryStore
-	categoriesDomainsStore storage.CategoriesDomainsStore
-	categoryValidator      validator.CategoryValidatorer
+	categoryStore           storage.CategoryStore
+	categoriesDomainsStore  storage.CategoriesDomainsStore
+	categoryEmbeddingStore  storage.CategoryEmbeddingStore
+	categoryAssignmentStore storage.CategoryAssignmentStore
+	categoryValidator       validator.CategoryValidatorer
+	ai                      *a.AI
 }
 
-func NewCategoryService(categoryStore storage.CategoryStore, categoriesDomainsStore storage.CategoriesDomainsStore, categoryValidator validator.CategoryValidatorer) CategoryService {
-	return &categoryService{categoryStore: categoryStore, categoriesDomainsStore: categoriesDomainsStore, categoryValidator: categoryValidator}
+func NewCategoryService(categoryStore storage.CategoryStore, categoriesDomainsStore storage.CategoriesDomainsStore, categoryEmbeddingStore storage.CategoryEmbeddingStore, categoryAssignmentStore storage.CategoryAssignmentStore, categoryValidator validator.CategoryValidatorer, ai *a.AI) CategoryService {
+	return &categoryService{categoryStore: categoryStore, categoriesDomainsStore: categoriesDomainsStore, categoryEmbeddingStore: categoryEmbeddingStore, categoryAssignmentStore: categoryAssignmentStore, categoryValidator: categoryValidator, ai: ai}
 }
 
-func (s *categoryService) GetCategories(filters ...*storage.GetCategoriesFilters) ([]*models.Category, error) {
-	return s.categoryStore.GetCategories(filters...)
+func (s *categoryService) GetCategories(ctx context.Context, filters ...*storage.GetCategoriesFilters) ([]*models.Category, error) {
+	return s.categoryStore.GetCategories(ctx, filters...)
 }
 
-func (s *categoryService) GetCategory(id int) (*models.Category, error) {
-	return s.categoryStore.GetCategory(id)
+func (s *categoryService) GetCategory(ctx context.Context, id int) (*models.Category, error) {
+	return s.categoryStore.GetCategory(ctx, id)
 }
 
-func (s *categoryService) GetDomainCategories(domainId int) ([]*models.Category, error) {
-	categoryIds, err := s.categoriesDomainsStore.GetDomainCategories(domainId)
+func (s *categoryService) GetDomainCategories(ctx context.Context, domainId int) ([]*models.Category, error) {
+	categoryIds, err := s.categoriesDomainsStore.GetDomainCategories(ctx, domainId)
 	if err != nil {
 		return nil, err
 	}
 
 	var categories []*models.Category
 	for _, categoryId := range categoryIds {
-		category, err := s.categoryStore.GetCategory(categoryId)
+		category, err := s.categoryStore.GetCategory(ctx, categoryId)
 		if err != nil {
 			return nil, err
 		}
@@ -52,7 +61,7 @@ func (s *categoryService) GetDomainCategories(domainId int) ([]*models.Category,
 	return categories, nil
 }
 
-func (s *categoryService) CreateCategory(category *models.Category) (int, error) {
+func (s *categoryService) CreateCategory(ctx context.Context, category *models.Category) (int, error) {
 	category.Slug = slug.Make(category.Name)
 
 	err := s.categoryValidator.Validate(category)
@@ -61,14 +70,14 @@ func (s *categoryService) CreateCategory(category *models.Category) (int, error)
 		return 0, err
 	}
 
-	return s.categoryStore.InsertCategory(category)
+	return s.categoryStore.InsertCategory(ctx, category)
 }
 
-func (s *categoryService) AssignCategoryToDomain(categoryId int, domainId int) error {
-	return s.categoriesDomainsStore.AssignCategoryToDomain(categoryId, domainId)
+func (s *categoryService) AssignCategoryToDomain(ctx context.Context, categoryId int, domainId int) error {
+	return s.categoriesDomainsStore.AssignCategoryToDomain(ctx, categoryId, domainId)
 }
 
-func (s *categoryService) UpdateCategory(id int, category *models.Category) (int, error) {
+func (s *categoryService) UpdateCategory(ctx context.Context, id int, category *models.Category) (int, error) {
 	category.Slug = slug.Make(category.Name)
 
 	err := s.categoryValidator.Validate(category)
@@ -76,5 +85,5 @@ func (s *categoryService) UpdateCategory(id int, category *models.Category) (int
 		return 0, err
 	}
 
-	return s.categoryStore.UpdateCategory(id, category)
+	return s.categoryStore.UpdateCategory(ctx, id, category)
 }
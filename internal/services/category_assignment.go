@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	chatgpt "github.com/rustoma/octo-pulse/internal/ai/chatGPT"
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+	e "github.com/rustoma/octo-pulse/internal/errors"
+	"github.com/rustoma/octo-pulse/internal/models"
+	"github.com/rustoma/octo-pulse/internal/storage"
+)
+
+const (
+	// defaultCategoryMinSimilarity is the similarity below which no category
+	// fits a question. Embeddings of ada-002 rarely score below 0.7 even for
+	// unrelated texts.
+	defaultCategoryMinSimilarity = 0.78
+	// defaultCategoryTieMargin is the score difference under which categories
+	// are too close to choose between without asking the model.
+	defaultCategoryTieMargin = 0.01
+	// tieBreakerCandidates is the number of categories the model chooses from.
+	tieBreakerCandidates = 3
+	// questionTextLimit is the number of characters of the question and its
+	// answer that are embedded.
+	questionTextLimit = 2000
+)
+
+type scoredCategory struct {
+	category *models.Category
+	score    float64
+}
+
+// AssignQuestionToCategory picks the category most similar to the question by
+// their embeddings. Categories scored within the tie margin of the best one are
+// left for the model to choose from. The assignment is recorded with its score
+// whether a category was found or not, its CategoryId is nil when none fits.
+func (s *categoryService) AssignQuestionToCategory(ctx context.Context, categories []*models.Category, question *models.Question, domain *models.Domain, meter *llm.Meter) (*models.CategoryAssignment, error) {
+	ai := s.ai.ChatGPT.WithMeter(meter)
+
+	assignment := &models.CategoryAssignment{
+		QuestionId: question.Id,
+		DomainId:   domain.ID,
+		Method:     models.CategoryAssignmentMethodBelowThreshold,
+		Model:      ai.EmbeddingModel(),
+	}
+
+	scored, err := s.scoreCategories(ctx, ai, categories, question)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(scored) > 0 {
+		assignment.Score = scored[0].score
+	}
+
+	if len(scored) > 1 {
+		assignment.RunnerUpCategoryId = &scored[1].category.ID
+		assignment.RunnerUpScore = &scored[1].score
+	}
+
+	if len(scored) > 0 && scored[0].score >= categoryMinSimilarity() {
+		tied := []*models.Category{scored[0].category}
+		for _, candidate := range scored[1:] {
+			if len(tied) == tieBreakerCandidates || scored[0].score-candidate.score > categoryTieMargin() {
+				break
+			}
+			tied = append(tied, candidate.category)
+		}
+
+		if len(tied) == 1 {
+			assignment.Method = models.CategoryAssignmentMethodEmbedding
+			assignment.CategoryId = &scored[0].category.ID
+		} else {
+			categoryId, err := ai.AssignToCategory(ctx, tied, question, domain)
+			if err != nil {
+				return nil, err
+			}
+
+			assignment.Method = models.CategoryAssignmentMethodLLM
+			for _, candidate := range scored {
+				if candidate.category.ID == categoryId {
+					assignment.CategoryId = &candidate.category.ID
+					assignment.Score = candidate.score
+				}
+			}
+		}
+	}
+
+	assignment.ID, err = s.categoryAssignmentStore.InsertCategoryAssignment(ctx, assignment)
+	if err != nil {
+		return nil, err
+	}
+
+	return assignment, nil
+}
+
+// MapCategoryToDomain returns the category of the domain an article of the
+// category, titled title, is filed under in the domain. The category is kept
+// when it is assigned to the domain, otherwise the category of the domain most
+// similar by name is chosen. When none is similar enough the model chooses
+// one for the title.
+func (s *categoryService) MapCategoryToDomain(ctx context.Context, categoryId int, title string, domain *models.Domain, meter *llm.Meter) (*models.Category, error) {
+	ai := s.ai.ChatGPT.WithMeter(meter)
+
+	categories, err := s.GetDomainCategories(ctx, domain.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(categories) == 0 {
+		return nil, e.BadRequest{Err: fmt.Sprintf("domain %s has no categories", domain.Name)}
+	}
+
+	for _, category := range categories {
+		if category.ID == categoryId {
+			return category, nil
+		}
+	}
+
+	category, err := s.categoryStore.GetCategory(ctx, categoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings, err := s.categoryEmbeddings(ctx, ai, append([]*models.Category{category}, categories...))
+	if err != nil {
+		return nil, err
+	}
+
+	var best *models.Category
+	bestScore := 0.0
+	for _, candidate := range categories {
+		score := llm.CosineSimilarity(embeddings[category.ID], embeddings[candidate.ID])
+		if best == nil || score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+
+	if bestScore >= categoryMinSimilarity() {
+		return best, nil
+	}
+
+	chosenId, err := ai.AssignToCategory(ctx, categories, &models.Question{Question: title}, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range categories {
+		if candidate.ID == chosenId {
+			return candidate, nil
+		}
+	}
+
+	return nil, e.BadRequest{Err: fmt.Sprintf("no category of domain %s fits category %s", domain.Name, category.Name)}
+}
+
+func (s *categoryService) GetCategoryAssignments(ctx context.Context, filters ...*storage.GetCategoryAssignmentsFilters) ([]*models.CategoryAssignment, error) {
+	return s.categoryAssignmentStore.GetCategoryAssignments(ctx, filters...)
+}
+
+// scoreCategories returns the categories sorted by their similarity to the
+// question, the most similar first.
+func (s *categoryService) scoreCategories(ctx context.Context, ai chatgpt.ChatGPTer, categories []*models.Category, question *models.Question) ([]scoredCategory, error) {
+	if len(categories) == 0 {
+		return nil, nil
+	}
+
+	categoryEmbeddings, err := s.categoryEmbeddings(ctx, ai, categories)
+	if err != nil {
+		return nil, err
+	}
+
+	questionEmbeddings, err := ai.Embed(ctx, []string{questionText(question)})
+	if err != nil {
+		return nil, err
+	}
+
+	scored := make([]scoredCategory, 0, len(categories))
+	for _, category := range categories {
+		scored = append(scored, scoredCategory{
+			category: category,
+			score:    llm.CosineSimilarity(questionEmbeddings[0], categoryEmbeddings[category.ID]),
+		})
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+
+	return scored, nil
+}
+
+// categoryEmbeddings returns the embeddings of the categories by their id. The
+// ones not cached yet, or cached for an old name, are computed in one request
+// and cached.
+func (s *categoryService) categoryEmbeddings(ctx context.Context, ai chatgpt.ChatGPTer, categories []*models.Category) (map[int][]float32, error) {
+	categoryIds := make([]int, 0, len(categories))
+	for _, category := range categories {
+		categoryIds = append(categoryIds, category.ID)
+	}
+
+	cached, err := s.categoryEmbeddingStore.GetCategoryEmbeddings(ctx, ai.EmbeddingModel(), categoryIds)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedByCategory := make(map[int]*models.CategoryEmbedding, len(cached))
+	for _, embedding := range cached {
+		cachedByCategory[embedding.CategoryId] = embedding
+	}
+
+	embeddings := make(map[int][]float32, len(categories))
+	var missing []*models.Category
+	var texts []string
+
+	for _, category := range categories {
+		embedding, ok := cachedByCategory[category.ID]
+		if ok && embedding.TextHash == textHash(categoryText(category)) {
+			embeddings[category.ID] = embedding.Embedding
+			continue
+		}
+
+		missing = append(missing, category)
+		texts = append(texts, categoryText(category))
+	}
+
+	computed, err := ai.Embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, category := range missing {
+		embeddings[category.ID] = computed[i]
+
+		// Losing the embedding only means it is computed again next time.
+		err = s.categoryEmbeddingStore.UpsertCategoryEmbedding(ctx, &models.CategoryEmbedding{
+			CategoryId: category.ID,
+			Model:      ai.EmbeddingModel(),
+			TextHash:   textHash(texts[i]),
+			Embedding:  computed[i],
+		})
+		if err != nil {
+			logger.Err(err).Msgf("Cannot cache embedding of category id: %d", category.ID)
+		}
+	}
+
+	return embeddings, nil
+}
+
+func categoryText(category *models.Category) string {
+	return category.Name
+}
+
+func questionText(question *models.Question) string {
+	text := []rune(strings.TrimSpace(question.Question + "\n" + question.Answer))
+	if len(text) > questionTextLimit {
+		text = text[:questionTextLimit]
+	}
+
+	return string(text)
+}
+
+func textHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// categoryMinSimilarity is read from CATEGORY_MIN_SIMILARITY.
+func categoryMinSimilarity() float64 {
+	return getEnvFloat("CATEGORY_MIN_SIMILARITY", defaultCategoryMinSimilarity)
+}
+
+// categoryTieMargin is read from CATEGORY_TIE_MARGIN.
+func categoryTieMargin() float64 {
+	return getEnvFloat("CATEGORY_TIE_MARGIN", defaultCategoryTieMargin)
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
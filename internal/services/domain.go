@@ -1,18 +1,20 @@
 package services
 
 import (
+	"context"
 	"github.com/rustoma/octo-pulse/internal/dto"
+	"github.com/rustoma/octo-pulse/internal/language"
 	"github.com/rustoma/octo-pulse/internal/models"
 	"github.com/rustoma/octo-pulse/internal/storage"
 	"github.com/rustoma/octo-pulse/internal/validator"
 )
 
 type DomainService interface {
-	GetDomains() ([]*models.Domain, error)
-	GetDomain(id int) (*models.Domain, error)
-	GetDomainPublicData(id int) (*dto.DomainPublicData, error)
-	CreateDomain(domain *models.Domain) (int, error)
-	UpdateDomain(id int, domain *models.Domain) (int, error)
+	GetDomains(ctx context.Context) ([]*models.Domain, error)
+	GetDomain(ctx context.Context, id int) (*models.Domain, error)
+	GetDomainPublicData(ctx context.Context, id int) (*dto.DomainPublicData, error)
+	CreateDomain(ctx context.Context, domain *models.Domain) (int, error)
+	UpdateDomain(ctx context.Context, id int, domain *models.Domain) (int, error)
 }
 
 type domainService struct {
@@ -24,33 +26,49 @@ func NewDomainService(domainStore storage.DomainStore, domainValidator validator
 	return &domainService{domainStore: domainStore, domainValidator: domainValidator}
 }
 
-func (s *domainService) GetDomains() ([]*models.Domain, error) {
-	return s.domainStore.GetDomains()
+func (s *domainService) GetDomains(ctx context.Context) ([]*models.Domain, error) {
+	return s.domainStore.GetDomains(ctx)
 }
 
-func (s *domainService) GetDomain(id int) (*models.Domain, error) {
-	return s.domainStore.GetDomain(id)
+func (s *domainService) GetDomain(ctx context.Context, id int) (*models.Domain, error) {
+	return s.domainStore.GetDomain(ctx, id)
 }
 
-func (s *domainService) CreateDomain(domain *models.Domain) (int, error) {
+func (s *domainService) CreateDomain(ctx context.Context, domain *models.Domain) (int, error) {
+	setDomainLanguageDefaults(domain)
+
 	err := s.domainValidator.Validate(domain)
 	if err != nil {
 		logger.Err(err).Send()
 		return 0, err
 	}
 
-	return s.domainStore.InsertDomain(domain)
+	return s.domainStore.InsertDomain(ctx, domain)
 }
 
-func (s *domainService) GetDomainPublicData(id int) (*dto.DomainPublicData, error) {
-	return s.domainStore.GetDomainPublicData(id)
+func (s *domainService) GetDomainPublicData(ctx context.Context, id int) (*dto.DomainPublicData, error) {
+	return s.domainStore.GetDomainPublicData(ctx, id)
 }
 
-func (s *domainService) UpdateDomain(id int, domain *models.Domain) (int, error) {
+func (s *domainService) UpdateDomain(ctx context.Context, id int, domain *models.Domain) (int, error) {
+	setDomainLanguageDefaults(domain)
+
 	err := s.domainValidator.Validate(domain)
 	if err != nil {
 		return 0, err
 	}
 
-	return s.domainStore.UpdateDomain(id, domain)
+	return s.domainStore.UpdateDomain(ctx, id, domain)
+}
+
+// setDomainLanguageDefaults keeps domains created before languages were
+// introduced Polish and fills in the default locale of the chosen language.
+func setDomainLanguageDefaults(domain *models.Domain) {
+	if domain.Language == "" {
+		domain.Language = language.Default
+	}
+
+	if domain.Locale == "" {
+		domain.Locale = language.Get(domain.Language).Locale
+	}
 }
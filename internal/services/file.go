@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"github.com/gosimple/slug"
 	"github.com/rustoma/octo-pulse/internal/models"
@@ -15,8 +16,8 @@ import (
 )
 
 type FileService interface {
-	CreateArticles(ids []int) error
-	InsertJPGImagesFromDir(dirPath string, imageCategoryId int) error
+	CreateArticles(ctx context.Context, ids []int) error
+	InsertJPGImagesFromDir(ctx context.Context, dirPath string, imageCategoryId int) error
 	RenameFilesUsingSlug(dirPath string)
 }
 
@@ -40,14 +41,14 @@ func (s *fileService) ConvertHTMLToDocx(htmlPath, docxPath string) error {
 	return nil
 }
 
-func (s *fileService) CreateHtmlFile(htmlFilePath string, content string) error {
+func (s *fileService) CreateHtmlFile(htmlFilePath string, content string, lang string) error {
 	f, err := os.Create(htmlFilePath)
 	defer f.Close()
 	if err != nil {
 		return err
 	}
 
-	htmlEntry := "<!DOCTYPE html><html lang=\"pl\"><head><meta charset=\"UTF-8\">\n\t<meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\"></head><body>\n"
+	htmlEntry := "<!DOCTYPE html><html lang=\"" + lang + "\"><head><meta charset=\"UTF-8\">\n\t<meta name=\"viewport\" content=\"width=device-width, initial-scale=1.0\"></head><body>\n"
 	htmlEnd := "\n</body>\n\n</html>"
 	htmlContent := fmt.Sprintf("%s%s%s", htmlEntry, content, htmlEnd)
 
@@ -59,20 +60,20 @@ func (s *fileService) CreateHtmlFile(htmlFilePath string, content string) error
 	return nil
 }
 
-func (s *fileService) CreateArticles(ids []int) error {
+func (s *fileService) CreateArticles(ctx context.Context, ids []int) error {
 
 	for _, id := range ids {
-		article, err := s.articleStore.GetArticle(id)
+		article, err := s.articleStore.GetArticle(ctx, id)
 		if err != nil {
 			return err
 		}
 
-		domain, err := s.domainStore.GetDomain(article.DomainId)
+		domain, err := s.domainStore.GetDomain(ctx, article.DomainId)
 		if err != nil {
 			return err
 		}
 
-		category, err := s.categoryStore.GetCategory(article.CategoryId)
+		category, err := s.categoryStore.GetCategory(ctx, article.CategoryId)
 		if err != nil {
 			return err
 		}
@@ -83,9 +84,9 @@ func (s *fileService) CreateArticles(ids []int) error {
 		}
 
 		htmlFilePath := "temp.html"
-		docxFilePath := filepath.Join("assets", "articles", slug.Make(domain.Name), slug.Make(category.Name), fmt.Sprintf("%s.docx", slug.Make(article.Title)))
+		docxFilePath := filepath.Join("assets", "articles", slug.Make(domain.Name), slug.Make(category.Name), fmt.Sprintf("%s.docx", slug.MakeLang(article.Title, domain.Language)))
 
-		err = s.CreateHtmlFile(htmlFilePath, article.Body)
+		err = s.CreateHtmlFile(htmlFilePath, article.Body, domain.Language)
 		if err != nil {
 			logger.Err(err).Send()
 			return err
@@ -106,13 +107,13 @@ func (s *fileService) CreateArticles(ids []int) error {
 	return nil
 }
 
-func (s *fileService) InsertJPGImagesFromDir(dirPath string, imageCategoryId int) error {
+func (s *fileService) InsertJPGImagesFromDir(ctx context.Context, dirPath string, imageCategoryId int) error {
 	files, _ := os.ReadDir(dirPath)
 	for _, imgFile := range files {
 
 		fileName := imgFile.Name()
 
-		imagesWithTheSamePath, err := s.imageStore.GetImages(&storage.GetImagesFilters{Path: filepath.Join("/", dirPath, fileName)})
+		imagesWithTheSamePath, err := s.imageStore.GetImages(ctx, &storage.GetImagesFilters{Path: filepath.Join("/", dirPath, fileName)})
 		if err != nil {
 			logger.Err(err).Msg("File name: " + fileName)
 		}
@@ -153,7 +154,7 @@ func (s *fileService) InsertJPGImagesFromDir(dirPath string, imageCategoryId int
 				UpdatedAt:  time.Now().UTC(),
 			}
 
-			_, err = s.imageStore.InsertImage(&img)
+			_, err = s.imageStore.InsertImage(ctx, &img)
 			if err != nil {
 				return err
 			}
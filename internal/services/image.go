@@ -1,15 +1,20 @@
 package services
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"github.com/gosimple/slug"
+	"github.com/rustoma/octo-pulse/internal/dto"
 	e "github.com/rustoma/octo-pulse/internal/errors"
 	"github.com/rustoma/octo-pulse/internal/models"
 	"github.com/rustoma/octo-pulse/internal/storage"
 	"github.com/rustoma/octo-pulse/internal/validator"
 	"image"
+	_ "image/png"
 	"io"
 	"mime/multipart"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -17,13 +22,15 @@ import (
 )
 
 type ImageService interface {
-	GetImages(filters ...*storage.GetImagesFilters) ([]*models.Image, error)
-	GetImage(id int) (*models.Image, error)
-	GetImageCategories() ([]*models.ImageCategory, error)
-	UploadImage(image multipart.File, handler *multipart.FileHeader, imageCategory int) (int, error)
-	CreateImageCategory(category *models.ImageCategory) (int, error)
-	UpdateImageCategory(id int, category *models.ImageCategory) (int, error)
-	GetImageCategory(id int) (*models.ImageCategory, error)
+	GetImages(ctx context.Context, filters ...*storage.GetImagesFilters) ([]*models.Image, error)
+	GetImagesPage(ctx context.Context, filters *storage.GetImagesFilters) (*dto.Page[*models.Image], error)
+	GetImage(ctx context.Context, id int) (*models.Image, error)
+	GetImageCategories(ctx context.Context) ([]*models.ImageCategory, error)
+	UploadImage(ctx context.Context, image multipart.File, handler *multipart.FileHeader, imageCategory int) (int, error)
+	SaveGeneratedImage(ctx context.Context, content []byte, name string, alt string, imageCategory int) (int, error)
+	CreateImageCategory(ctx context.Context, category *models.ImageCategory) (int, error)
+	UpdateImageCategory(ctx context.Context, id int, category *models.ImageCategory) (int, error)
+	GetImageCategory(ctx context.Context, id int) (*models.ImageCategory, error)
 }
 
 type imageService struct {
@@ -36,33 +43,60 @@ func NewImageService(imageStorageStore storage.ImageStorageStore, imageCategoryS
 	return &imageService{imageStore: imageStorageStore, imageCategoryStore: imageCategoryStore, imageCategoryValidator: imageCategoryValidator}
 }
 
-func (s *imageService) GetImages(filters ...*storage.GetImagesFilters) ([]*models.Image, error) {
-	return s.imageStore.GetImages(filters...)
+func (s *imageService) GetImages(ctx context.Context, filters ...*storage.GetImagesFilters) ([]*models.Image, error) {
+	return s.imageStore.GetImages(ctx, filters...)
 }
 
-func (s *imageService) GetImage(id int) (*models.Image, error) {
-	return s.imageStore.GetImage(id)
+// GetImagesPage returns a page of the images with the number of all the images
+// matching the filters and, when the page is full, the cursor of the next one.
+func (s *imageService) GetImagesPage(ctx context.Context, filters *storage.GetImagesFilters) (*dto.Page[*models.Image], error) {
+	images, err := s.imageStore.GetImages(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.imageStore.CountImages(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dto.Page[*models.Image]{Items: images, Total: total}
+	if page.Items == nil {
+		page.Items = []*models.Image{}
+	}
+
+	if filters.Limit > 0 && len(images) == filters.Limit {
+		last := images[len(images)-1]
+		cursor := (&storage.Cursor{Date: last.CreatedAt, Id: last.ID}).Encode()
+		page.NextCursor = &cursor
+	}
+
+	return page, nil
+}
+
+func (s *imageService) GetImage(ctx context.Context, id int) (*models.Image, error) {
+	return s.imageStore.GetImage(ctx, id)
 }
 
-func (s *imageService) GetImageCategory(id int) (*models.ImageCategory, error) {
-	return s.imageCategoryStore.GetCategory(id)
+func (s *imageService) GetImageCategory(ctx context.Context, id int) (*models.ImageCategory, error) {
+	return s.imageCategoryStore.GetCategory(ctx, id)
 }
 
-func (s *imageService) GetImageCategories() ([]*models.ImageCategory, error) {
-	return s.imageCategoryStore.GetCategories()
+func (s *imageService) GetImageCategories(ctx context.Context) ([]*models.ImageCategory, error) {
+	return s.imageCategoryStore.GetCategories(ctx)
 }
 
-func (s *imageService) CreateImageCategory(category *models.ImageCategory) (int, error) {
+func (s *imageService) CreateImageCategory(ctx context.Context, category *models.ImageCategory) (int, error) {
 	err := s.imageCategoryValidator.Validate(category)
 	if err != nil {
 		logger.Err(err).Send()
 		return 0, err
 	}
 
-	return s.imageCategoryStore.InsertCategory(category)
+	return s.imageCategoryStore.InsertCategory(ctx, category)
 }
 
-func (s *imageService) UploadImage(file multipart.File, handler *multipart.FileHeader, imageCategory int) (int, error) {
+func (s *imageService) UploadImage(ctx context.Context, file multipart.File, handler *multipart.FileHeader, imageCategory int) (int, error) {
 
 	tempFile, err := os.CreateTemp(filepath.Join(os.Getenv("PATH_TO_ASSETS"), "images", "uploaded"), "*-"+handler.Filename)
 	if err != nil {
@@ -113,14 +147,66 @@ func (s *imageService) UploadImage(file multipart.File, handler *multipart.FileH
 		UpdatedAt:  time.Now().UTC(),
 	}
 
-	return s.imageStore.InsertImage(&img)
+	return s.imageStore.InsertImage(ctx, &img)
+}
+
+// SaveGeneratedImage stores an image created by the image model next to the
+// uploaded ones. name is turned into a slug and used as the file name.
+func (s *imageService) SaveGeneratedImage(ctx context.Context, content []byte, name string, alt string, imageCategory int) (int, error) {
+	imgCfg, format, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, errors.New("generated image has an unknown format")
+	}
+
+	dir := filepath.Join(os.Getenv("PATH_TO_ASSETS"), "images", "generated")
+	err = os.MkdirAll(dir, 0o755)
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, errors.New("cannot create directory for generated images")
+	}
+
+	imageFile, err := os.CreateTemp(dir, slug.Make(name)+"-*."+format)
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, errors.New("cannot create file for generated image")
+	}
+	defer imageFile.Close()
+
+	_, err = imageFile.Write(content)
+	if err != nil {
+		logger.Err(err).Send()
+		os.Remove(imageFile.Name())
+		return 0, errors.New("cannot save generated image")
+	}
+
+	img := models.Image{
+		Name:       slug.Make(name),
+		Path:       filepath.Join("/", imageFile.Name()),
+		Size:       len(content),
+		Type:       http.DetectContentType(content),
+		Width:      imgCfg.Width,
+		Height:     imgCfg.Height,
+		Alt:        alt,
+		CategoryId: imageCategory,
+		CreatedAt:  time.Now().UTC(),
+		UpdatedAt:  time.Now().UTC(),
+	}
+
+	imageId, err := s.imageStore.InsertImage(ctx, &img)
+	if err != nil {
+		os.Remove(imageFile.Name())
+		return 0, err
+	}
+
+	return imageId, nil
 }
 
-func (s *imageService) UpdateImageCategory(id int, category *models.ImageCategory) (int, error) {
+func (s *imageService) UpdateImageCategory(ctx context.Context, id int, category *models.ImageCategory) (int, error) {
 	err := s.imageCategoryValidator.Validate(category)
 	if err != nil {
 		return 0, err
 	}
 
-	return s.imageCategoryStore.UpdateImageCategory(id, category)
+	return s.imageCategoryStore.UpdateImageCategory(ctx, id, category)
 }
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"github.com/rustoma/octo-pulse/internal/ai/prompts"
+	e "github.com/rustoma/octo-pulse/internal/errors"
+	"github.com/rustoma/octo-pulse/internal/language"
+	"github.com/rustoma/octo-pulse/internal/models"
+	"github.com/rustoma/octo-pulse/internal/storage"
+	"github.com/rustoma/octo-pulse/internal/validator"
+)
+
+type PromptTemplateService interface {
+	GetPromptTemplates(ctx context.Context, filters ...*storage.GetPromptTemplatesFilters) ([]*models.PromptTemplate, error)
+	GetPromptTemplate(ctx context.Context, id int) (*models.PromptTemplate, error)
+	CreatePromptTemplate(ctx context.Context, template *models.PromptTemplate) (int, error)
+	ActivatePromptTemplate(ctx context.Context, id int) error
+}
+
+type promptTemplateService struct {
+	promptTemplateStore     storage.PromptTemplateStore
+	domainStore             storage.DomainStore
+	promptTemplateValidator validator.PromptTemplateValidatorer
+}
+
+func NewPromptTemplateService(promptTemplateStore storage.PromptTemplateStore, domainStore storage.DomainStore, promptTemplateValidator validator.PromptTemplateValidatorer) PromptTemplateService {
+	return &promptTemplateService{promptTemplateStore: promptTemplateStore, domainStore: domainStore, promptTemplateValidator: promptTemplateValidator}
+}
+
+func (s *promptTemplateService) GetPromptTemplates(ctx context.Context, filters ...*storage.GetPromptTemplatesFilters) ([]*models.PromptTemplate, error) {
+	return s.promptTemplateStore.GetPromptTemplates(ctx, filters...)
+}
+
+func (s *promptTemplateService) GetPromptTemplate(ctx context.Context, id int) (*models.PromptTemplate, error) {
+	return s.promptTemplateStore.GetPromptTemplate(ctx, id)
+}
+
+// CreatePromptTemplate stores the template as the next version for its name, domain
+// and language. Editing a prompt always creates a new version, so a bad edit can be
+// rolled back by activating the previous one. Domain overrides are always written in
+// the language of their domain.
+func (s *promptTemplateService) CreatePromptTemplate(ctx context.Context, template *models.PromptTemplate) (int, error) {
+	if template.DomainId != nil {
+		domain, err := s.domainStore.GetDomain(ctx, *template.DomainId)
+		if err != nil {
+			return 0, err
+		}
+
+		if domain == nil {
+			return 0, e.NotFound{Err: "domain not found"}
+		}
+
+		template.Language = domain.Language
+	}
+
+	if template.Language == "" {
+		template.Language = language.Default
+	}
+
+	err := s.promptTemplateValidator.Validate(template)
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	if err := prompts.Validate(template.Name, template.Body); err != nil {
+		return 0, e.BadRequest{Err: err.Error()}
+	}
+
+	latestVersion, err := s.promptTemplateStore.GetLatestPromptTemplateVersion(ctx, template.Name, template.DomainId, template.Language)
+	if err != nil {
+		return 0, err
+	}
+
+	activate := template.IsActive
+	template.Version = latestVersion + 1
+	template.IsActive = false
+
+	templateId, err := s.promptTemplateStore.InsertPromptTemplate(ctx, template)
+	if err != nil {
+		return 0, err
+	}
+
+	if activate {
+		return templateId, s.promptTemplateStore.ActivatePromptTemplate(ctx, templateId)
+	}
+
+	return templateId, nil
+}
+
+func (s *promptTemplateService) ActivatePromptTemplate(ctx context.Context, id int) error {
+	template, err := s.promptTemplateStore.GetPromptTemplate(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if template == nil {
+		return e.NotFound{Err: "prompt template not found"}
+	}
+
+	return s.promptTemplateStore.ActivatePromptTemplate(ctx, id)
+}
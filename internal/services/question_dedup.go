@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+	"github.com/rustoma/octo-pulse/internal/dedup"
+	"github.com/rustoma/octo-pulse/internal/models"
+	"github.com/rustoma/octo-pulse/internal/storage"
+)
+
+// Kinds of the texts questions are compared against.
+const (
+	DuplicateOfArticle  = "article"
+	DuplicateOfQuestion = "question"
+)
+
+// embeddingBatchSize keeps embedding requests under the input limit of the
+// provider.
+const embeddingBatchSize = 1000
+
+// QuestionDeduplicator tells whether a question duplicates an article of the
+// domain or a question accepted earlier in the same batch.
+type QuestionDeduplicator struct {
+	index      *dedup.Index
+	embeddings map[int][]float32
+}
+
+// Check returns what the question duplicates, nil when it is new.
+func (d *QuestionDeduplicator) Check(question *models.Question) *dedup.Match {
+	return d.index.Find(question.Question, d.embeddings[question.Id])
+}
+
+// Accept makes later questions of the batch be compared against question.
+func (d *QuestionDeduplicator) Accept(question *models.Question) {
+	d.index.Add(dedup.Entry{
+		Kind:      DuplicateOfQuestion,
+		Id:        question.Id,
+		Text:      question.Question,
+		Embedding: d.embeddings[question.Id],
+	})
+}
+
+// NewQuestionDeduplicator embeds the titles of the articles of the domain and
+// the questions of the batch, so checking a question costs nothing more.
+func (s *articleService) NewQuestionDeduplicator(ctx context.Context, domain *models.Domain, questions []*models.Question, meter *llm.Meter) (*QuestionDeduplicator, error) {
+	articles, err := s.articleStore.GetArticles(ctx, &storage.GetArticlesFilters{DomainId: domain.ID, ExcludeBody: "true"})
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make([]string, 0, len(articles)+len(questions))
+	for _, article := range articles {
+		texts = append(texts, article.Title)
+	}
+	for _, question := range questions {
+		texts = append(texts, question.Question)
+	}
+
+	embeddings, err := s.embedAll(ctx, texts, meter)
+	if err != nil {
+		return nil, err
+	}
+
+	deduplicator := &QuestionDeduplicator{
+		index:      dedup.NewIndex(domain.Language, questionDuplicateLexicalThreshold(), questionDuplicateSemanticThreshold()),
+		embeddings: make(map[int][]float32, len(questions)),
+	}
+
+	for i, article := range articles {
+		deduplicator.index.Add(dedup.Entry{
+			Kind:      DuplicateOfArticle,
+			Id:        article.ID,
+			Text:      article.Title,
+			Embedding: embeddings[i],
+		})
+	}
+
+	for i, question := range questions {
+		deduplicator.embeddings[question.Id] = embeddings[len(articles)+i]
+	}
+
+	return deduplicator, nil
+}
+
+// SaveQuestionDuplicate records why the question was skipped.
+func (s *articleService) SaveQuestionDuplicate(ctx context.Context, question *models.Question, domainId int, match *dedup.Match, batchId string) error {
+	duplicate := &models.QuestionDuplicate{
+		QuestionId: question.Id,
+		DomainId:   domainId,
+		Signal:     match.Signal,
+		Score:      match.Score,
+		BatchId:    batchId,
+	}
+
+	switch match.Entry.Kind {
+	case DuplicateOfArticle:
+		duplicate.DuplicateOfArticleId = &match.Entry.Id
+	case DuplicateOfQuestion:
+		duplicate.DuplicateOfQuestionId = &match.Entry.Id
+	}
+
+	_, err := s.questionDuplicateStore.InsertQuestionDuplicate(ctx, duplicate)
+	return err
+}
+
+// embedAll embeds texts in as many requests as the provider needs.
+func (s *articleService) embedAll(ctx context.Context, texts []string, meter *llm.Meter) ([][]float32, error) {
+	embeddings := make([][]float32, 0, len(texts))
+
+	for start := 0; start < len(texts); start += embeddingBatchSize {
+		end := start + embeddingBatchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+
+		batch, err := s.ai.ChatGPT.WithMeter(meter).Embed(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		embeddings = append(embeddings, batch...)
+	}
+
+	return embeddings, nil
+}
+
+// questionDuplicateLexicalThreshold is read from QUESTION_DUPLICATE_LEXICAL_THRESHOLD.
+func questionDuplicateLexicalThreshold() float64 {
+	return getEnvFloat("QUESTION_DUPLICATE_LEXICAL_THRESHOLD", dedup.DefaultLexicalThreshold)
+}
+
+// questionDuplicateSemanticThreshold is read from QUESTION_DUPLICATE_SEMANTIC_THRESHOLD.
+func questionDuplicateSemanticThreshold() float64 {
+	return getEnvFloat("QUESTION_DUPLICATE_SEMANTIC_THRESHOLD", dedup.DefaultSemanticThreshold)
+}
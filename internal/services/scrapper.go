@@ -1,16 +1,18 @@
 package services
 
 import (
+	"context"
 	"github.com/rustoma/octo-pulse/internal/models"
 	"github.com/rustoma/octo-pulse/internal/storage"
 	"github.com/rustoma/octo-pulse/internal/validator"
 )
 
 type ScrapperService interface {
-	GetQuestion(id int) (*models.Question, error)
-	GetQuestions(filters ...*storage.GetQuestionsFilters) ([]*models.Question, error)
-	UpdateQuestion(id int, question *models.Question) error
-	GetQuestionCategories() ([]*models.QuestionCategory, error)
+	GetQuestion(ctx context.Context, id int) (*models.Question, error)
+	GetQuestions(ctx context.Context, filters ...*storage.GetQuestionsFilters) ([]*models.Question, error)
+	GetSiblingQuestions(ctx context.Context, question *models.Question, limit int) ([]*models.Question, error)
+	UpdateQuestion(ctx context.Context, id int, question *models.Question) error
+	GetQuestionCategories(ctx context.Context) ([]*models.QuestionCategory, error)
 }
 
 type scrapperService struct {
@@ -32,27 +34,31 @@ func NewScrapperService(scrapperStore storage.ScrapperStore, scrapperValidator v
 	}
 }
 
-func (s *scrapperService) GetQuestion(id int) (*models.Question, error) {
-	question, err := s.scrapperStore.GetQuestion(id)
+func (s *scrapperService) GetQuestion(ctx context.Context, id int) (*models.Question, error) {
+	question, err := s.scrapperStore.GetQuestion(ctx, id)
 	return question, err
 }
 
-func (s *scrapperService) GetQuestions(filters ...*storage.GetQuestionsFilters) ([]*models.Question, error) {
-	questions, err := s.scrapperStore.GetQuestions(filters...)
+func (s *scrapperService) GetQuestions(ctx context.Context, filters ...*storage.GetQuestionsFilters) ([]*models.Question, error) {
+	questions, err := s.scrapperStore.GetQuestions(ctx, filters...)
 	return questions, err
 }
 
-func (s *scrapperService) UpdateQuestion(id int, question *models.Question) error {
+func (s *scrapperService) GetSiblingQuestions(ctx context.Context, question *models.Question, limit int) ([]*models.Question, error) {
+	return s.scrapperStore.GetSiblingQuestions(ctx, question, limit)
+}
+
+func (s *scrapperService) UpdateQuestion(ctx context.Context, id int, question *models.Question) error {
 	err := s.scrapperValidator.Validate(question)
 
 	if err != nil {
 		return err
 	}
 
-	return s.scrapperStore.UpdateQuestion(id, question)
+	return s.scrapperStore.UpdateQuestion(ctx, id, question)
 }
 
-func (s *scrapperService) GetQuestionCategories() ([]*models.QuestionCategory, error) {
-	questions, err := s.scrapperStore.GetQuestionCategories()
+func (s *scrapperService) GetQuestionCategories(ctx context.Context) ([]*models.QuestionCategory, error) {
+	questions, err := s.scrapperStore.GetQuestionCategories(ctx)
 	return questions, err
 }
@@ -1,9 +1,11 @@
 package services
 
 import (
+	"errors"
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog"
 	lr "github.com/rustoma/octo-pulse/internal/logger"
+	"io/fs"
 	"os"
 	"path/filepath"
 )
@@ -22,7 +24,8 @@ func init() {
 		logger.Fatal().Msg("Error loading .env file")
 	}
 
-	if err := godotenv.Load(filepath.Join(dir, ".env")); err != nil {
+	// Without a .env file the settings come from the environment alone
+	if err := godotenv.Load(filepath.Join(dir, ".env")); err != nil && !errors.Is(err, fs.ErrNotExist) {
 		logger.Fatal().Msg("Error loading .env file")
 	}
 }
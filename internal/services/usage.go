@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+	"github.com/rustoma/octo-pulse/internal/dto"
+	"github.com/rustoma/octo-pulse/internal/models"
+	"github.com/rustoma/octo-pulse/internal/storage"
+	"github.com/rustoma/octo-pulse/internal/validator"
+)
+
+// UsageSource identifies the work the recorded tokens were spent on.
+type UsageSource struct {
+	ArticleId *int
+	DomainId  int
+	Task      string
+	BatchId   string
+}
+
+type UsageService interface {
+	RecordUsage(ctx context.Context, source UsageSource, usage []llm.ModelUsage) error
+	GetArticleUsage(ctx context.Context, articleId int) ([]*models.LlmUsage, error)
+	GetUsagePerDomain(ctx context.Context, filters ...*storage.GetUsageFilters) ([]*dto.DomainUsage, error)
+	GetUsagePerDay(ctx context.Context, filters ...*storage.GetUsageFilters) ([]*dto.DailyUsage, error)
+	GetUsagePerBatch(ctx context.Context, filters ...*storage.GetUsageFilters) ([]*dto.BatchUsage, error)
+	GetModelPrices(ctx context.Context) ([]*models.LlmModelPrice, error)
+	UpdateModelPrice(ctx context.Context, price *models.LlmModelPrice) error
+}
+
+type usageService struct {
+	usageStore             storage.UsageStore
+	llmModelPriceValidator validator.LlmModelPriceValidatorer
+}
+
+func NewUsageService(usageStore storage.UsageStore, llmModelPriceValidator validator.LlmModelPriceValidatorer) UsageService {
+	return &usageService{usageStore: usageStore, llmModelPriceValidator: llmModelPriceValidator}
+}
+
+// RecordUsage stores the usage of every model together with its cost at the
+// current price. Models without a price are stored with zero cost.
+func (s *usageService) RecordUsage(ctx context.Context, source UsageSource, usage []llm.ModelUsage) error {
+	var batchId *string
+	if source.BatchId != "" {
+		batchId = &source.BatchId
+	}
+
+	usages := make([]*models.LlmUsage, 0, len(usage))
+	for _, modelUsage := range usage {
+		price, err := s.usageStore.GetModelPrice(ctx, modelUsage.Model)
+		if err != nil {
+			return err
+		}
+
+		var cost float64
+		if price != nil {
+			cost = float64(modelUsage.PromptTokens)/1000*price.PromptPrice + float64(modelUsage.CompletionTokens)/1000*price.CompletionPrice
+		} else {
+			logger.Warn().Msgf("There is no price for model %s, its usage is recorded without cost", modelUsage.Model)
+		}
+
+		usages = append(usages, &models.LlmUsage{
+			ArticleId:        source.ArticleId,
+			DomainId:         source.DomainId,
+			Task:             source.Task,
+			BatchId:          batchId,
+			Model:            modelUsage.Model,
+			PromptTokens:     modelUsage.PromptTokens,
+			CompletionTokens: modelUsage.CompletionTokens,
+			TotalTokens:      modelUsage.TotalTokens,
+			Cost:             cost,
+		})
+	}
+
+	return s.usageStore.InsertUsage(ctx, usages)
+}
+
+func (s *usageService) GetArticleUsage(ctx context.Context, articleId int) ([]*models.LlmUsage, error) {
+	return s.usageStore.GetArticleUsage(ctx, articleId)
+}
+
+func (s *usageService) GetUsagePerDomain(ctx context.Context, filters ...*storage.GetUsageFilters) ([]*dto.DomainUsage, error) {
+	return s.usageStore.GetUsagePerDomain(ctx, filters...)
+}
+
+func (s *usageService) GetUsagePerDay(ctx context.Context, filters ...*storage.GetUsageFilters) ([]*dto.DailyUsage, error) {
+	return s.usageStore.GetUsagePerDay(ctx, filters...)
+}
+
+func (s *usageService) GetUsagePerBatch(ctx context.Context, filters ...*storage.GetUsageFilters) ([]*dto.BatchUsage, error) {
+	return s.usageStore.GetUsagePerBatch(ctx, filters...)
+}
+
+func (s *usageService) GetModelPrices(ctx context.Context) ([]*models.LlmModelPrice, error) {
+	return s.usageStore.GetModelPrices(ctx)
+}
+
+func (s *usageService) UpdateModelPrice(ctx context.Context, price *models.LlmModelPrice) error {
+	err := s.llmModelPriceValidator.Validate(price)
+	if err != nil {
+		return err
+	}
+
+	return s.usageStore.UpsertModelPrice(ctx, price)
+}
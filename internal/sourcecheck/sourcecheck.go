@@ -0,0 +1,129 @@
+package sourcecheck
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rustoma/octo-pulse/internal/language"
+)
+
+// MinLength is the number of characters below which a source is too short to
+// be summarized.
+const MinLength = 1000
+
+// MaxBoilerplateRatio is the share of the text in short or repeated lines, like
+// menus and footers, above which a source is rejected.
+const MaxBoilerplateRatio = 0.5
+
+// MaxNoticeRatio is the share of the text in sentences about cookies and
+// privacy above which a source is rejected.
+const MaxNoticeRatio = 0.3
+
+// MinLanguageConfidence is the confidence of the detected language needed to
+// reject a source written in another language than expected. Texts written in
+// a single language are detected with a confidence of 0.25 or more, while
+// texts mixing two languages stay below 0.07.
+const MinLanguageConfidence = 0.1
+
+// boilerplateWords is the number of words below which a line is counted as
+// boilerplate.
+const boilerplateWords = 5
+
+// Reasons a source is rejected for.
+const (
+	ReasonTooShort    = "too_short"
+	ReasonLanguage    = "language"
+	ReasonBoilerplate = "boilerplate"
+	ReasonNotice      = "cookie_notice"
+)
+
+// Rejection tells why a source is not used.
+type Rejection struct {
+	Reason string
+	Detail string
+}
+
+func (r Rejection) String() string {
+	return fmt.Sprintf("%s (%s)", r.Reason, r.Detail)
+}
+
+var (
+	sentenceEnd = regexp.MustCompile(`[.!?\n]+`)
+	notice      = regexp.MustCompile(`(?i)\b(cookies?|ciasteczk\w*|prywatno\S*|privacy|rodo\b|gdpr|dsgvo|datenschutz\w*|consent|einwilligung\w*|danych osobowych|dane osobowe|personal data|personenbezogene\w*)`)
+)
+
+// Check returns why text is not a usable source for articles in lang, nil when
+// it is.
+func Check(text string, lang string) *Rejection {
+	length := utf8.RuneCountInString(strings.Join(strings.Fields(text), " "))
+	if length < MinLength {
+		return &Rejection{Reason: ReasonTooShort, Detail: fmt.Sprintf("%d characters, at least %d needed", length, MinLength)}
+	}
+
+	if ratio := noticeRatio(text); ratio > MaxNoticeRatio {
+		return &Rejection{Reason: ReasonNotice, Detail: fmt.Sprintf("%.2f of the text is about cookies or privacy", ratio)}
+	}
+
+	if ratio := boilerplateRatio(text); ratio > MaxBoilerplateRatio {
+		return &Rejection{Reason: ReasonBoilerplate, Detail: fmt.Sprintf("%.2f of the text is in short or repeated lines", ratio)}
+	}
+
+	detection := language.Detect(text)
+	if detection.Code != "" && detection.Code != lang && detection.Confidence >= MinLanguageConfidence {
+		return &Rejection{Reason: ReasonLanguage, Detail: fmt.Sprintf("written in %s (confidence %.2f), expected %s", detection.Code, detection.Confidence, lang)}
+	}
+
+	return nil
+}
+
+// boilerplateRatio is the share of the characters of text in lines of less
+// than boilerplateWords words or in lines seen before.
+func boilerplateRatio(text string) float64 {
+	seen := make(map[string]bool)
+	total, boilerplate := 0, 0
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			continue
+		}
+
+		length := utf8.RuneCountInString(line)
+		total += length
+
+		key := strings.ToLower(line)
+		if len(strings.Fields(line)) < boilerplateWords || seen[key] {
+			boilerplate += length
+		}
+		seen[key] = true
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return float64(boilerplate) / float64(total)
+}
+
+// noticeRatio is the share of the characters of text in sentences mentioning
+// cookies, consent or privacy.
+func noticeRatio(text string) float64 {
+	total, notices := 0, 0
+
+	for _, sentence := range sentenceEnd.Split(text, -1) {
+		length := utf8.RuneCountInString(strings.TrimSpace(sentence))
+		total += length
+
+		if notice.MatchString(sentence) {
+			notices += length
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return float64(notices) / float64(total)
+}
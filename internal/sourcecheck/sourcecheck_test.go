@@ -0,0 +1,91 @@
+package sourcecheck
+
+import (
+	"strings"
+	"testing"
+)
+
+// pages are sources about cycling, a topic the language profiles are not built
+// from.
+var pages = map[string]string{
+	"en": `Cycling to work is one of the easiest ways to build exercise into a busy week. A ride of twenty minutes each way adds up to more than three hours of activity, and on crowded city streets it is often faster than the bus or the car.
+Before you start, check that the tyres are pumped up, the brakes work and the chain is clean and oiled. A bright front light and a red rear light are essential in the dark months, and a helmet and reflective clothing make you easier to see.
+Plan a route that avoids the busiest roads, even if it is a little longer. Many cities now have separate cycle lanes along rivers and through parks, and the map on your phone can show them. Try the route on a weekend first, when there is less traffic and you are not in a hurry.
+If your workplace has no showers, leave early enough to ride at a relaxed pace and keep a change of clothes in a drawer. Some employers offer secure bike parking or help with the cost of a new bike, so it is worth asking your manager what is on offer.`,
+	"pl": `Dojazd do pracy rowerem to jeden z najprostszych sposobów, by wpleść ruch w zabiegany tydzień. Dwadzieścia minut jazdy w każdą stronę daje ponad trzy godziny aktywności tygodniowo, a w zatłoczonym mieście rower często jest szybszy niż autobus czy samochód.
+Zanim ruszysz, sprawdź ciśnienie w oponach, działanie hamulców i to, czy łańcuch jest czysty i nasmarowany. W ciemnych miesiącach niezbędne są mocna lampka z przodu i czerwona z tyłu, a kask i odblaskowa kamizelka sprawią, że będziesz lepiej widoczny.
+Zaplanuj trasę omijającą najbardziej ruchliwe ulice, nawet jeśli będzie nieco dłuższa. W wielu miastach są już wydzielone drogi rowerowe wzdłuż rzek i przez parki, a mapa w telefonie potrafi je wskazać. Najpierw przejedź trasę w weekend, gdy ruch jest mniejszy i nigdzie się nie spieszysz.
+Jeśli w pracy nie ma prysznica, wyjedź na tyle wcześnie, by jechać spokojnym tempem, i trzymaj w szufladzie ubranie na zmianę. Niektórzy pracodawcy oferują bezpieczny parking dla rowerów albo dopłatę do zakupu nowego, więc warto o to zapytać.`,
+	"de": `Mit dem Fahrrad zur Arbeit zu fahren ist eine der einfachsten Möglichkeiten, Bewegung in eine volle Woche einzubauen. Zwanzig Minuten pro Strecke ergeben mehr als drei Stunden Aktivität pro Woche, und auf verstopften Straßen ist man mit dem Rad oft schneller als mit Bus oder Auto.
+Prüfen Sie vor dem Start, ob die Reifen aufgepumpt sind, die Bremsen funktionieren und die Kette sauber und geölt ist. In den dunklen Monaten sind ein helles Vorderlicht und ein rotes Rücklicht unverzichtbar, Helm und reflektierende Kleidung sorgen dafür, dass man Sie besser sieht.
+Planen Sie eine Strecke, die die belebtesten Straßen meidet, auch wenn sie etwas länger ist. Viele Städte haben inzwischen eigene Radwege entlang von Flüssen und durch Parks, und die Karte auf dem Handy kann sie anzeigen. Fahren Sie die Strecke zuerst am Wochenende ab, wenn weniger Verkehr ist und Sie keine Eile haben.
+Gibt es bei der Arbeit keine Duschen, fahren Sie früh genug los, um gemütlich zu radeln, und bewahren Sie Wechselkleidung in einer Schublade auf. Manche Arbeitgeber bieten sichere Fahrradstellplätze oder einen Zuschuss zum neuen Rad an, fragen lohnt sich also.`,
+}
+
+func TestCheckLanguage(t *testing.T) {
+	for pageLang, page := range pages {
+		for _, lang := range []string{"en", "pl", "de"} {
+			rejection := Check(page, lang)
+
+			if pageLang == lang {
+				if rejection != nil {
+					t.Errorf("%s page rejected for %s: %s", pageLang, lang, rejection)
+				}
+				continue
+			}
+
+			if rejection == nil || rejection.Reason != ReasonLanguage {
+				t.Errorf("%s page checked for %s = %v, want a %s rejection", pageLang, lang, rejection, ReasonLanguage)
+			}
+		}
+	}
+}
+
+func TestCheckMixedLanguages(t *testing.T) {
+	// Half of the page is in the expected language, the detection is too
+	// unsure to reject it.
+	enParagraphs := strings.Split(pages["en"], "\n")
+	plParagraphs := strings.Split(pages["pl"], "\n")
+	page := strings.Join(append(plParagraphs[:2], enParagraphs[2:]...), "\n")
+
+	if rejection := Check(page, "pl"); rejection != nil {
+		t.Errorf("mixed page rejected: %s", rejection)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	menu := strings.Repeat("Strona główna\nO nas\nKontakt\nSklep\n", 40)
+	cookies := strings.Repeat("Ta strona używa plików cookies w celu świadczenia usług. Korzystając ze strony wyrażasz zgodę na przetwarzanie danych osobowych. ", 8)
+	footer := strings.Repeat("Copyright 2024 Wszelkie prawa zastrzeżone przez firmę Dachy\n", 20)
+
+	tests := []struct {
+		name       string
+		text       string
+		wantReason string
+	}{
+		{"article", pages["pl"], ""},
+		{"too short", "Jak wyczyścić dach? Najlepiej myjką ciśnieniową.", ReasonTooShort},
+		{"whitespace does not count", strings.Repeat("dach   \n\n\t", 150), ReasonTooShort},
+		{"menu", menu + pages["pl"][:200], ReasonBoilerplate},
+		{"repeated footer", footer + pages["pl"][:300], ReasonBoilerplate},
+		{"cookie notice", cookies + pages["pl"][:300], ReasonNotice},
+		{"article with a cookie notice", pages["pl"] + "\nTa strona używa plików cookies.", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rejection := Check(tt.text, "pl")
+
+			if tt.wantReason == "" {
+				if rejection != nil {
+					t.Errorf("Check = %s, want nil", rejection)
+				}
+				return
+			}
+
+			if rejection == nil || rejection.Reason != tt.wantReason {
+				t.Errorf("Check = %v, want a %s rejection", rejection, tt.wantReason)
+			}
+		})
+	}
+}
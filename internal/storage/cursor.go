@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the position of the last item of a page in a listing ordered by
+// date and id, newest first. The next page starts right after it, so it stays
+// stable when items are added in front of it.
+type Cursor struct {
+	Date time.Time
+	Id   int
+}
+
+// Encode returns the cursor as an opaque string safe for a query parameter.
+func (c *Cursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.Date.UTC().Format(time.RFC3339Nano) + "|" + strconv.Itoa(c.Id)))
+}
+
+// DecodeCursor reads a cursor returned by Encode.
+func DecodeCursor(cursor string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	date, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	parsedDate, err := time.Parse(time.RFC3339Nano, date)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	parsedId, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{Date: parsedDate, Id: parsedId}, nil
+}
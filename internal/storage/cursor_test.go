@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCursor(t *testing.T) {
+	date := time.Date(2024, 3, 12, 10, 30, 15, 123456789, time.FixedZone("CET", 3600))
+	cursor := &Cursor{Date: date, Id: 42}
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !decoded.Date.Equal(date) || decoded.Id != 42 {
+		t.Errorf("decoded cursor = %v, %d, want %v, 42", decoded.Date, decoded.Id, date)
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	for _, cursor := range []string{
+		"",
+		"not base64!",
+		encode("2024-03-12T10:30:15Z"),
+		encode("yesterday|42"),
+		encode("2024-03-12T10:30:15Z|forty-two"),
+		encode("|"),
+	} {
+		if _, err := DecodeCursor(cursor); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("DecodeCursor(%q) = %v, want ErrInvalidCursor", cursor, err)
+		}
+	}
+}
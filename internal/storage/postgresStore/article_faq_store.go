@@ -0,0 +1,128 @@
+package postgresstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rustoma/octo-pulse/internal/models"
+)
+
+type PostgresArticleFaqStore struct {
+	DB        *pgxpool.Pool
+	dbTimeout time.Duration
+}
+
+func NewArticleFaqStore(DB *pgxpool.Pool) *PostgresArticleFaqStore {
+	return &PostgresArticleFaqStore{
+		DB:        DB,
+		dbTimeout: time.Second * 20,
+	}
+}
+
+// GetArticleFaq returns the FAQ items of the article in their order.
+func (s *PostgresArticleFaqStore) GetArticleFaq(ctx context.Context, articleId int) ([]*models.ArticleFaqItem, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("*").
+		From("public.article_faq_item").
+		Where(squirrel.Eq{"article_id": articleId}).
+		OrderBy("position").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	items := make([]*models.ArticleFaqItem, 0)
+
+	for rows.Next() {
+		itemFromScan, err := scanToArticleFaqItem(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		items = append(items, itemFromScan)
+	}
+
+	return items, err
+}
+
+// ReplaceArticleFaq makes items the FAQ of the article, numbered in the given order.
+func (s *PostgresArticleFaqStore) ReplaceArticleFaq(ctx context.Context, articleId int, items []*models.ArticleFaqItem) error {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	deleteStmt, args, err := pgQb().
+		Delete("public.article_faq_item").
+		Where(squirrel.Eq{"article_id": articleId}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, deleteStmt, args...); err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	if len(items) > 0 {
+		insertStmt := pgQb().
+			Insert("public.article_faq_item").
+			Columns("article_id, position, question, answer, created_at")
+
+		for position, item := range items {
+			insertStmt = insertStmt.Values(articleId, position, item.Question, item.Answer, time.Now().UTC())
+		}
+
+		stmt, args, err := insertStmt.ToSql()
+
+		if err != nil {
+			logger.Err(err).Send()
+			return err
+		}
+
+		if _, err = tx.Exec(ctx, stmt, args...); err != nil {
+			logger.Err(err).Send()
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func scanToArticleFaqItem(rows pgx.Rows) (*models.ArticleFaqItem, error) {
+	var item models.ArticleFaqItem
+	err := rows.Scan(
+		&item.ArticleId,
+		&item.Position,
+		&item.Question,
+		&item.Answer,
+		&item.CreatedAt,
+	)
+
+	return &item, err
+}
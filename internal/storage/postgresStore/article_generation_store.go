@@ -0,0 +1,121 @@
+package postgresstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rustoma/octo-pulse/internal/models"
+)
+
+type PostgresArticleGenerationStore struct {
+	DB        *pgxpool.Pool
+	dbTimeout time.Duration
+}
+
+func NewArticleGenerationStore(DB *pgxpool.Pool) *PostgresArticleGenerationStore {
+	return &PostgresArticleGenerationStore{
+		DB:        DB,
+		dbTimeout: time.Second * 20,
+	}
+}
+
+// GetArticleGenerationSteps returns the completed steps in the order they were completed.
+func (s *PostgresArticleGenerationStore) GetArticleGenerationSteps(ctx context.Context, articleId int) ([]*models.ArticleGenerationStep, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("*").
+		From("public.article_generation_step").
+		Where(squirrel.Eq{"article_id": articleId}).
+		OrderBy("created_at, step").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	steps := make([]*models.ArticleGenerationStep, 0)
+
+	for rows.Next() {
+		stepFromScan, err := scanToArticleGenerationStep(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		steps = append(steps, stepFromScan)
+	}
+
+	return steps, err
+}
+
+func (s *PostgresArticleGenerationStore) UpsertArticleGenerationStep(ctx context.Context, step *models.ArticleGenerationStep) error {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Insert("public.article_generation_step").
+		Columns("article_id, step, content, created_at, updated_at").
+		Values(
+			step.ArticleId,
+			step.Step,
+			step.Content,
+			time.Now().UTC(),
+			time.Now().UTC(),
+		).
+		Suffix("ON CONFLICT (article_id, step) DO UPDATE SET content = EXCLUDED.content, updated_at = EXCLUDED.updated_at").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	_, err = s.DB.Exec(ctx, stmt, args...)
+	return err
+}
+
+func (s *PostgresArticleGenerationStore) DeleteArticleGenerationSteps(ctx context.Context, articleId int) error {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Delete("public.article_generation_step").
+		Where(squirrel.Eq{"article_id": articleId}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	_, err = s.DB.Exec(ctx, stmt, args...)
+	return err
+}
+
+func scanToArticleGenerationStep(rows pgx.Rows) (*models.ArticleGenerationStep, error) {
+	var step models.ArticleGenerationStep
+	err := rows.Scan(
+		&step.ArticleId,
+		&step.Step,
+		&step.Content,
+		&step.CreatedAt,
+		&step.UpdatedAt,
+	)
+
+	return &step, err
+}
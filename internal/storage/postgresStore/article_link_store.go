@@ -0,0 +1,177 @@
+package postgresstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rustoma/octo-pulse/internal/models"
+)
+
+type PostgresArticleLinkStore struct {
+	DB        *pgxpool.Pool
+	dbTimeout time.Duration
+}
+
+func NewArticleLinkStore(DB *pgxpool.Pool) *PostgresArticleLinkStore {
+	return &PostgresArticleLinkStore{
+		DB:        DB,
+		dbTimeout: time.Second * 20,
+	}
+}
+
+// ReplaceArticleLinks makes links the only links going out of the article.
+func (s *PostgresArticleLinkStore) ReplaceArticleLinks(ctx context.Context, sourceArticleId int, links []*models.ArticleLink) error {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	deleteStmt, args, err := pgQb().
+		Delete("public.article_link").
+		Where(squirrel.Eq{"source_article_id": sourceArticleId}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, deleteStmt, args...); err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	if len(links) > 0 {
+		insertStmt := pgQb().
+			Insert("public.article_link").
+			Columns("source_article_id, target_article_id, anchor, created_at")
+
+		for _, link := range links {
+			insertStmt = insertStmt.Values(sourceArticleId, link.TargetArticleId, link.Anchor, time.Now().UTC())
+		}
+
+		stmt, args, err := insertStmt.ToSql()
+
+		if err != nil {
+			logger.Err(err).Send()
+			return err
+		}
+
+		if _, err = tx.Exec(ctx, stmt, args...); err != nil {
+			logger.Err(err).Send()
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// RemoveInboundArticleLinks saves the bodies of sources, the articles linking
+// to the target with those links removed, and deletes the links recorded for
+// the target, all or nothing.
+func (s *PostgresArticleLinkStore) RemoveInboundArticleLinks(ctx context.Context, targetArticleId int, sources []*models.Article) error {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, source := range sources {
+		updateStmt, args, err := pgQb().
+			Update("public.article").
+			Set("body", source.Body).
+			Set("updated_at", time.Now().UTC()).
+			Where(squirrel.Eq{"id": source.ID, "deleted_at": nil}).
+			ToSql()
+
+		if err != nil {
+			logger.Err(err).Send()
+			return err
+		}
+
+		if _, err = tx.Exec(ctx, updateStmt, args...); err != nil {
+			logger.Err(err).Send()
+			return err
+		}
+	}
+
+	deleteStmt, args, err := pgQb().
+		Delete("public.article_link").
+		Where(squirrel.Eq{"target_article_id": targetArticleId}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, deleteStmt, args...); err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetInboundArticleLinks returns the links pointing to the article.
+func (s *PostgresArticleLinkStore) GetInboundArticleLinks(ctx context.Context, targetArticleId int) ([]*models.ArticleLink, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("*").
+		From("public.article_link").
+		Where(squirrel.Eq{"target_article_id": targetArticleId}).
+		OrderBy("source_article_id").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	links := make([]*models.ArticleLink, 0)
+
+	for rows.Next() {
+		linkFromScan, err := scanToArticleLink(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		links = append(links, linkFromScan)
+	}
+
+	return links, err
+}
+
+func scanToArticleLink(rows pgx.Rows) (*models.ArticleLink, error) {
+	var link models.ArticleLink
+	err := rows.Scan(
+		&link.SourceArticleId,
+		&link.TargetArticleId,
+		&link.Anchor,
+		&link.CreatedAt,
+	)
+
+	return &link, err
+}
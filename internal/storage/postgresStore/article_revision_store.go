@@ -0,0 +1,205 @@
+package postgresstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rustoma/octo-pulse/internal/models"
+)
+
+type PostgresArticleRevisionStore struct {
+	DB        *pgxpool.Pool
+	dbTimeout time.Duration
+}
+
+func NewArticleRevisionStore(DB *pgxpool.Pool) *PostgresArticleRevisionStore {
+	return &PostgresArticleRevisionStore{
+		DB:        DB,
+		dbTimeout: time.Second * 20,
+	}
+}
+
+func (s *PostgresArticleRevisionStore) InsertArticleRevision(ctx context.Context, revision *models.ArticleRevision) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Insert("public.article_revision").
+		Columns("article_id, title, slug, body, meta_title, meta_description, focus_keyword, excerpt, source, user_id, restored_from_id, refresh, created_at").
+		Values(revision.ArticleId, revision.Title, revision.Slug, revision.Body, revision.MetaTitle, revision.MetaDescription,
+			revision.FocusKeyword, revision.Excerpt, revision.Source, revision.UserId, revision.RestoredFromId, revision.Refresh, time.Now().UTC()).
+		Suffix("RETURNING \"id\"").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	var revisionId int
+
+	err = s.DB.QueryRow(ctx, stmt, args...).Scan(&revisionId)
+	return revisionId, err
+}
+
+// GetArticleRevisions returns the revisions of the article, the newest first.
+// The body is not selected.
+func (s *PostgresArticleRevisionStore) GetArticleRevisions(ctx context.Context, articleId int) ([]*models.ArticleRevision, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("id, article_id, title, slug, '' AS body, meta_title, meta_description, focus_keyword, excerpt, source, user_id, restored_from_id, refresh, created_at").
+		From("public.article_revision").
+		Where(squirrel.Eq{"article_id": articleId}).
+		OrderBy("id DESC").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	revisions := make([]*models.ArticleRevision, 0)
+
+	for rows.Next() {
+		revisionFromScan, err := scanToArticleRevision(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		revisions = append(revisions, revisionFromScan)
+	}
+
+	return revisions, err
+}
+
+func (s *PostgresArticleRevisionStore) GetArticleRevision(ctx context.Context, id int) (*models.ArticleRevision, error) {
+	return s.getArticleRevision(ctx, squirrel.Eq{"id": id})
+}
+
+// GetLatestArticleRevision returns the revision matching the current content
+// of the article, nil when it has none.
+func (s *PostgresArticleRevisionStore) GetLatestArticleRevision(ctx context.Context, articleId int) (*models.ArticleRevision, error) {
+	return s.getArticleRevision(ctx, squirrel.Eq{"article_id": articleId})
+}
+
+// getArticleRevision returns the newest revision matching where.
+func (s *PostgresArticleRevisionStore) getArticleRevision(ctx context.Context, where squirrel.Eq) (*models.ArticleRevision, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("id, article_id, title, slug, body, meta_title, meta_description, focus_keyword, excerpt, source, user_id, restored_from_id, refresh, created_at").
+		From("public.article_revision").
+		Where(where).
+		OrderBy("id DESC").
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	var revision *models.ArticleRevision
+
+	for rows.Next() {
+		revisionFromScan, err := scanToArticleRevision(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		revision = revisionFromScan
+	}
+
+	return revision, err
+}
+
+func (s *PostgresArticleRevisionStore) CountDomainArticleRefreshes(ctx context.Context, domainId int, since time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("COUNT(*)").
+		From("public.article_revision r").
+		Join("public.article a ON a.id = r.article_id").
+		Where(squirrel.Eq{"a.domain_id": domainId, "r.refresh": true}).
+		Where(squirrel.GtOrEq{"r.created_at": since}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	var completed int
+
+	err = s.DB.QueryRow(ctx, stmt, args...).Scan(&completed)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt, args, err = pgQb().
+		Select("COUNT(*)").
+		From("public.article a").
+		Where(squirrel.Eq{"a.domain_id": domainId}).
+		Where(squirrel.GtOrEq{"a.refresh_requested_at": since}).
+		Where("NOT EXISTS (SELECT 1 FROM public.article_revision r WHERE r.article_id = a.id AND r.refresh AND r.created_at >= a.refresh_requested_at)").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	var pending int
+
+	err = s.DB.QueryRow(ctx, stmt, args...).Scan(&pending)
+	return completed + pending, err
+}
+
+func scanToArticleRevision(rows pgx.Rows) (*models.ArticleRevision, error) {
+	var revision models.ArticleRevision
+	err := rows.Scan(
+		&revision.ID,
+		&revision.ArticleId,
+		&revision.Title,
+		&revision.Slug,
+		&revision.Body,
+		&revision.MetaTitle,
+		&revision.MetaDescription,
+		&revision.FocusKeyword,
+		&revision.Excerpt,
+		&revision.Source,
+		&revision.UserId,
+		&revision.RestoredFromId,
+		&revision.Refresh,
+		&revision.CreatedAt,
+	)
+
+	return &revision, err
+}
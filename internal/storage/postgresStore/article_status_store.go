@@ -0,0 +1,189 @@
+package postgresstore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rustoma/octo-pulse/internal/models"
+)
+
+type PostgresArticleStatusStore struct {
+	DB        *pgxpool.Pool
+	dbTimeout time.Duration
+}
+
+func NewArticleStatusStore(DB *pgxpool.Pool) *PostgresArticleStatusStore {
+	return &PostgresArticleStatusStore{
+		DB:        DB,
+		dbTimeout: time.Second * 20,
+	}
+}
+
+// UpdateArticleStatus moves the article to the status of the transition and
+// records the transition. is_published is set for published articles only. The
+// publication date is changed when publicationDate is given. It returns the id
+// of the article, 0 when the article is no longer in the status the
+// transition starts from or was moved to the trash.
+func (s *PostgresArticleStatusStore) UpdateArticleStatus(ctx context.Context, transition *models.ArticleStatusTransition, publicationDate *time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	articleMap := map[string]interface{}{
+		"status":       transition.ToStatus,
+		"is_published": transition.ToStatus == models.ArticleStatusPublished,
+	}
+
+	if publicationDate != nil {
+		articleMap["publication_date"] = *publicationDate
+	}
+
+	updateStmt, args, err := pgQb().
+		Update("public.article").
+		SetMap(articleMap).
+		Where(squirrel.Eq{"id": transition.ArticleId, "status": transition.FromStatus, "deleted_at": nil}).
+		Suffix("RETURNING \"id\"").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	var articleId int
+
+	err = tx.QueryRow(ctx, updateStmt, args...).Scan(&articleId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	insertStmt, args, err := pgQb().
+		Insert("public.article_status_transition").
+		Columns("article_id, from_status, to_status, user_id, created_at").
+		Values(transition.ArticleId, transition.FromStatus, transition.ToStatus, transition.UserId, time.Now().UTC()).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	if _, err = tx.Exec(ctx, insertStmt, args...); err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	return articleId, tx.Commit(ctx)
+}
+
+// GetArticleStatusTransitions returns the status transitions of the article,
+// the oldest first.
+func (s *PostgresArticleStatusStore) GetArticleStatusTransitions(ctx context.Context, articleId int) ([]*models.ArticleStatusTransition, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("*").
+		From("public.article_status_transition").
+		Where(squirrel.Eq{"article_id": articleId}).
+		OrderBy("created_at", "id").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	transitions := make([]*models.ArticleStatusTransition, 0)
+
+	for rows.Next() {
+		transitionFromScan, err := scanToArticleStatusTransition(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		transitions = append(transitions, transitionFromScan)
+	}
+
+	return transitions, err
+}
+
+// GetDueScheduledArticles returns the scheduled articles whose publication date
+// is not after before. The body is not selected.
+func (s *PostgresArticleStatusStore) GetDueScheduledArticles(ctx context.Context, before time.Time) ([]*models.Article, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("id, title, slug, thumbnail, publication_date, is_published, author_id, category_id, domain_id, featured, reading_time, is_sponsored,created_at, updated_at, meta_title, meta_description, focus_keyword, excerpt, source_overlap, question_id, status").
+		From("public.article").
+		Where(squirrel.Eq{"status": models.ArticleStatusScheduled, "deleted_at": nil}).
+		Where(squirrel.LtOrEq{"publication_date": before}).
+		OrderBy("publication_date").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	articles := make([]*models.Article, 0)
+
+	for rows.Next() {
+		articleFromScan, err := scanToArticleWithoutBody(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		articles = append(articles, articleFromScan)
+	}
+
+	return articles, err
+}
+
+func scanToArticleStatusTransition(rows pgx.Rows) (*models.ArticleStatusTransition, error) {
+	var transition models.ArticleStatusTransition
+	err := rows.Scan(
+		&transition.ID,
+		&transition.ArticleId,
+		&transition.FromStatus,
+		&transition.ToStatus,
+		&transition.UserId,
+		&transition.CreatedAt,
+	)
+
+	return &transition, err
+}
@@ -2,6 +2,7 @@ package postgresstore
 
 import (
 	"context"
+	"fmt"
 	"github.com/rustoma/octo-pulse/internal/dto"
 	"github.com/rustoma/octo-pulse/internal/storage"
 	"time"
@@ -20,6 +21,17 @@ type PostgressArticleStore struct {
 	dbTimeout         time.Duration
 }
 
+// articleColumns are the columns scanned by scanToArticle. The search vector
+// is left out, it is only matched against.
+const articleColumns = "id, title, slug, body, thumbnail, publication_date, is_published, author_id, category_id, domain_id, featured, reading_time, is_sponsored, created_at, updated_at, meta_title, meta_description, focus_keyword, excerpt, source_overlap, question_id, status"
+
+// searchSnippetOptions wraps the matched words in <mark> tags and keeps up to
+// two fragments of the body around them.
+const searchSnippetOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=\" … \""
+
+// searchTitleOptions wraps the matched words of the whole title in <mark> tags.
+const searchTitleOptions = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+
 func NewArticleStore(DB *pgxpool.Pool, categoryStore storage.CategoryStore, imageStorageStore storage.ImageStorageStore, authorStore storage.AuthorStore) *PostgressArticleStore {
 	return &PostgressArticleStore{
 		DB:                DB,
@@ -30,15 +42,16 @@ func NewArticleStore(DB *pgxpool.Pool, categoryStore storage.CategoryStore, imag
 	}
 }
 
-func (s *PostgressArticleStore) InsertArticle(article *models.Article) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgressArticleStore) InsertArticle(ctx context.Context, article *models.Article) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
 		Insert("public.article").
-		Columns("title, slug, body, thumbnail, publication_date, is_published, author_id, category_id, domain_id, featured, reading_time, is_sponsored,created_at, updated_at").
+		Columns("title, slug, body, thumbnail, publication_date, is_published, author_id, category_id, domain_id, featured, reading_time, is_sponsored,created_at, updated_at, meta_title, meta_description, focus_keyword, excerpt, source_overlap, question_id, status").
 		Values(article.Title, article.Slug, article.Body, article.Thumbnail, article.PublicationDate, article.IsPublished,
-			article.AuthorId, article.CategoryId, article.DomainId, article.Featured, article.ReadingTime, article.IsSponsored, time.Now().UTC(), time.Now().UTC()).
+			article.AuthorId, article.CategoryId, article.DomainId, article.Featured, article.ReadingTime, article.IsSponsored, time.Now().UTC(), time.Now().UTC(),
+			article.MetaTitle, article.MetaDescription, article.FocusKeyword, article.Excerpt, article.SourceOverlap, article.QuestionId, article.Status).
 		Suffix("RETURNING \"id\"").
 		ToSql()
 
@@ -53,13 +66,61 @@ func (s *PostgressArticleStore) InsertArticle(article *models.Article) (int, err
 	return articleId, err
 }
 
-func (s *PostgressArticleStore) DeleteArticle(id int) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+// DeleteArticle moves the article to the trash. It stays there until it is
+// restored or purged.
+func (s *PostgressArticleStore) DeleteArticle(ctx context.Context, id int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Update("public.article").
+		Set("deleted_at", time.Now().UTC()).
+		Where(squirrel.Eq{"id": id, "deleted_at": nil}).
+		Suffix("RETURNING \"id\"").
+		ToSql()
+
+	if err != nil {
+		return 0, err
+	}
+
+	var articleId int
+
+	err = s.DB.QueryRow(ctx, stmt, args...).Scan(&articleId)
+	return articleId, err
+}
+
+// RestoreArticle takes the article out of the trash.
+func (s *PostgressArticleStore) RestoreArticle(ctx context.Context, id int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Update("public.article").
+		Set("deleted_at", nil).
+		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.NotEq{"deleted_at": nil}).
+		Suffix("RETURNING \"id\"").
+		ToSql()
+
+	if err != nil {
+		return 0, err
+	}
+
+	var articleId int
+
+	err = s.DB.QueryRow(ctx, stmt, args...).Scan(&articleId)
+	return articleId, err
+}
+
+// PurgeArticle deletes an article in the trash permanently.
+func (s *PostgressArticleStore) PurgeArticle(ctx context.Context, id int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
 		Delete("public.article").
 		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.NotEq{"deleted_at": nil}).
 		Suffix("RETURNING \"id\"").
 		ToSql()
 
@@ -73,64 +134,404 @@ func (s *PostgressArticleStore) DeleteArticle(id int) (int, error) {
 	return articleId, err
 }
 
-func (s *PostgressArticleStore) GetArticles(filters ...*storage.GetArticlesFilters) ([]*dto.Article, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+// DiscardArticle deletes an article that is not in the trash permanently,
+// without moving it to the trash first.
+func (s *PostgressArticleStore) DiscardArticle(ctx context.Context, id int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
-	selectStmt := "*"
+	stmt, args, err := pgQb().
+		Delete("public.article").
+		Where(squirrel.Eq{"id": id, "deleted_at": nil}).
+		Suffix("RETURNING \"id\"").
+		ToSql()
+
+	if err != nil {
+		return 0, err
+	}
+
+	var articleId int
+
+	err = s.DB.QueryRow(ctx, stmt, args...).Scan(&articleId)
+	return articleId, err
+}
+
+// PurgeDeletedArticles permanently deletes the articles moved to the trash
+// before the given time and returns their number.
+func (s *PostgressArticleStore) PurgeDeletedArticles(ctx context.Context, deletedBefore time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Delete("public.article").
+		Where(squirrel.Lt{"deleted_at": deletedBefore}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	tag, err := s.DB.Exec(ctx, stmt, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// GetDeletedArticle returns the article with the id when it is in the trash.
+func (s *PostgressArticleStore) GetDeletedArticle(ctx context.Context, id int) (*models.Article, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select(articleColumns).
+		From("public.article").
+		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.NotEq{"deleted_at": nil}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	var article *models.Article
+
+	for rows.Next() {
+		articleFromScan, err := scanToArticle(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		article = articleFromScan
+	}
+
+	return article, err
+}
+
+// GetDeletedArticles returns the articles in the trash, the most recently
+// deleted first. The body is not selected.
+func (s *PostgressArticleStore) GetDeletedArticles(ctx context.Context, filters *storage.GetDeletedArticlesFilters) ([]*dto.DeletedArticle, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	deletedStmt := pgQb().
+		Select("id, title, slug, thumbnail, publication_date, is_published, author_id, category_id, domain_id, featured, reading_time, is_sponsored,created_at, updated_at, meta_title, meta_description, focus_keyword, excerpt, source_overlap, question_id, status, deleted_at").
+		From("public.article").
+		Where(squirrel.NotEq{"deleted_at": nil}).
+		OrderBy("deleted_at DESC", "id DESC")
+
+	deletedStmt = filterDeletedArticles(deletedStmt, filters)
+
+	if filters.Limit != 0 {
+		deletedStmt = deletedStmt.Limit(uint64(filters.Limit))
+	}
+
+	if filters.Offset != 0 {
+		deletedStmt = deletedStmt.Offset(uint64(filters.Offset))
+	}
+
+	stmt, args, err := deletedStmt.ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	var articlesFromScan []*models.Article
+	var deleted []*dto.DeletedArticle
+
+	for rows.Next() {
+		var article models.Article
+		var deletedArticle dto.DeletedArticle
+
+		err := rows.Scan(
+			&article.ID,
+			&article.Title,
+			&article.Slug,
+			&article.Thumbnail,
+			&article.PublicationDate,
+			&article.IsPublished,
+			&article.AuthorId,
+			&article.CategoryId,
+			&article.DomainId,
+			&article.Featured,
+			&article.ReadingTime,
+			&article.IsSponsored,
+			&article.CreatedAt,
+			&article.UpdatedAt,
+			&article.MetaTitle,
+			&article.MetaDescription,
+			&article.FocusKeyword,
+			&article.Excerpt,
+			&article.SourceOverlap,
+			&article.QuestionId,
+			&article.Status,
+			&deletedArticle.DeletedAt,
+		)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		articlesFromScan = append(articlesFromScan, &article)
+		deleted = append(deleted, &deletedArticle)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	// The connection is released before the lookups run.
+	rows.Close()
+
+	articles, err := s.toDtoArticles(ctx, articlesFromScan)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, article := range articles {
+		deleted[i].Article = article
+	}
+
+	return deleted, nil
+}
+
+// CountDeletedArticles returns the number of articles in the trash matching
+// the filters, ignoring their limit and offset.
+func (s *PostgressArticleStore) CountDeletedArticles(ctx context.Context, filters *storage.GetDeletedArticlesFilters) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	countStmt := pgQb().
+		Select("COUNT(*)").
+		From("public.article").
+		Where(squirrel.NotEq{"deleted_at": nil})
+
+	stmt, args, err := filterDeletedArticles(countStmt, filters).ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	var count int
+
+	err = s.DB.QueryRow(ctx, stmt, args...).Scan(&count)
+	return count, err
+}
+
+func filterDeletedArticles(stmt squirrel.SelectBuilder, filter *storage.GetDeletedArticlesFilters) squirrel.SelectBuilder {
+	if filter.DomainId != 0 {
+		stmt = stmt.Where(squirrel.Eq{"domain_id": filter.DomainId})
+	}
+
+	return stmt
+}
+
+func (s *PostgressArticleStore) GetArticles(ctx context.Context, filters ...*storage.GetArticlesFilters) ([]*dto.Article, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	selectStmt := articleColumns
 
 	if len(filters) > 0 && filters[0].ExcludeBody == "true" {
-		selectStmt = "id, title, slug, thumbnail, publication_date, is_published, author_id, category_id, domain_id, featured, reading_time, is_sponsored,created_at, updated_at"
+		selectStmt = "id, title, slug, thumbnail, publication_date, is_published, author_id, category_id, domain_id, featured, reading_time, is_sponsored,created_at, updated_at, meta_title, meta_description, focus_keyword, excerpt, source_overlap, question_id, status"
 	}
 
 	articlesStmt := pgQb().
 		Select(selectStmt).
-		OrderBy("created_at DESC").
-		From("public.article")
+		OrderBy("publication_date DESC", "id DESC").
+		From("public.article").
+		Where(squirrel.Eq{"deleted_at": nil})
+
+	if len(filters) > 0 {
+		articlesStmt = filterArticles(articlesStmt, filters[0])
+	}
 
 	if len(filters) > 0 && filters[0].Limit != 0 {
 		articlesStmt = articlesStmt.Limit(uint64(filters[0].Limit))
 	}
 
-	if len(filters) > 0 && filters[0].Offset != 0 {
+	if len(filters) > 0 && filters[0].Cursor != nil {
+		articlesStmt = articlesStmt.Where("(publication_date, id) < (?, ?)", filters[0].Cursor.Date, filters[0].Cursor.Id)
+	} else if len(filters) > 0 && filters[0].Offset != 0 {
 		articlesStmt = articlesStmt.Offset(uint64(filters[0].Offset))
 	}
 
-	if len(filters) > 0 && filters[0].CategoryId != 0 {
-		articlesStmt = articlesStmt.Where(
+	stmt, args, err := articlesStmt.ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	var articlesFromScan []*models.Article
+
+	for rows.Next() {
+		var articleFromScan *models.Article
+
+		if len(filters) > 0 && filters[0].ExcludeBody == "true" {
+			article, err := scanToArticleWithoutBody(rows)
+			if err != nil {
+				logger.Err(err).Send()
+				return nil, err
+			}
+			articleFromScan = article
+		} else {
+			article, err := scanToArticle(rows)
+			if err != nil {
+				logger.Err(err).Send()
+				return nil, err
+			}
+			articleFromScan = article
+		}
+
+		articlesFromScan = append(articlesFromScan, articleFromScan)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	// The connection is released before the lookups run.
+	rows.Close()
+
+	return s.toDtoArticles(ctx, articlesFromScan)
+}
+
+// CountArticles returns the number of articles matching the filters, ignoring
+// their limit, offset and cursor.
+func (s *PostgressArticleStore) CountArticles(ctx context.Context, filters ...*storage.GetArticlesFilters) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	countStmt := pgQb().
+		Select("COUNT(*)").
+		From("public.article").
+		Where(squirrel.Eq{"deleted_at": nil})
+
+	if len(filters) > 0 {
+		countStmt = filterArticles(countStmt, filters[0])
+	}
+
+	stmt, args, err := countStmt.ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	var count int
+
+	err = s.DB.QueryRow(ctx, stmt, args...).Scan(&count)
+	return count, err
+}
+
+// filterArticles narrows stmt down to the articles matching the filter.
+func filterArticles(stmt squirrel.SelectBuilder, filter *storage.GetArticlesFilters) squirrel.SelectBuilder {
+	if filter.CategoryId != 0 {
+		stmt = stmt.Where(
 			squirrel.And{
-				squirrel.Eq{"category_id": filters[0].CategoryId},
+				squirrel.Eq{"category_id": filter.CategoryId},
 			})
 	}
 
-	if len(filters) > 0 && filters[0].DomainId != 0 {
-		articlesStmt = articlesStmt.Where(
+	if filter.DomainId != 0 {
+		stmt = stmt.Where(
 			squirrel.And{
-				squirrel.Eq{"domain_id": filters[0].DomainId},
+				squirrel.Eq{"domain_id": filter.DomainId},
 			})
 	}
 
-	if len(filters) > 0 && filters[0].Slug != "" {
-		articlesStmt = articlesStmt.Where(
+	if filter.Status != "" {
+		stmt = stmt.Where(
 			squirrel.And{
-				squirrel.Eq{"slug": filters[0].Slug},
+				squirrel.Eq{"status": filter.Status},
 			})
 	}
 
-	if len(filters) > 0 && (filters[0].Featured == "true" || filters[0].Featured == "false") {
+	if filter.Slug != "" {
+		stmt = stmt.Where(
+			squirrel.And{
+				squirrel.Eq{"slug": filter.Slug},
+			})
+	}
+
+	if filter.Featured == "true" || filter.Featured == "false" {
 		featured := false
 
-		if filters[0].Featured == "true" {
+		if filter.Featured == "true" {
 			featured = true
 		}
 
-		articlesStmt = articlesStmt.Where(
+		stmt = stmt.Where(
 			squirrel.And{
 				squirrel.Eq{"featured": featured},
 			})
 	}
 
-	stmt, args, err := articlesStmt.ToSql()
+	return stmt
+}
+
+// SearchArticles returns the articles of the domain matching the query, the
+// best ranked first. Title matches rank above body matches.
+func (s *PostgressArticleStore) SearchArticles(ctx context.Context, filters *storage.SearchArticlesFilters) ([]*dto.ArticleSearchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	searchStmt := pgQb().
+		Select("id, title, slug, thumbnail, publication_date, is_published, author_id, category_id, domain_id, featured, reading_time, is_sponsored,created_at, updated_at, meta_title, meta_description, focus_keyword, excerpt, source_overlap, question_id, status").
+		Column("ts_rank(search_vector, query)::float8 AS rank").
+		Column(squirrel.Expr("ts_headline(public.search_config(?), title, query, ?)", filters.Language, searchTitleOptions)).
+		Column(squirrel.Expr("ts_headline(public.search_config(?), regexp_replace(body, '<[^>]*>', ' ', 'g'), query, ?)", filters.Language, searchSnippetOptions)).
+		From("public.article").
+		JoinClause("CROSS JOIN websearch_to_tsquery(public.search_config(?), ?) AS query", filters.Language, filters.Query).
+		Where("search_vector @@ query").
+		OrderBy("rank DESC", "id DESC")
+
+	searchStmt = filterSearchArticles(searchStmt, filters)
+
+	if filters.Limit != 0 {
+		searchStmt = searchStmt.Limit(uint64(filters.Limit))
+	}
+
+	if filters.Offset != 0 {
+		searchStmt = searchStmt.Offset(uint64(filters.Offset))
+	}
+
+	stmt, args, err := searchStmt.ToSql()
 
 	if err != nil {
 		logger.Err(err).Send()
@@ -145,27 +546,165 @@ func (s *PostgressArticleStore) GetArticles(filters ...*storage.GetArticlesFilte
 		return nil, err
 	}
 
-	var articles []*dto.Article
+	var articlesFromScan []*models.Article
+	var results []*dto.ArticleSearchResult
 
 	for rows.Next() {
-		var articleFromScan *models.Article
+		var article models.Article
+		var result dto.ArticleSearchResult
+
+		err := rows.Scan(
+			&article.ID,
+			&article.Title,
+			&article.Slug,
+			&article.Thumbnail,
+			&article.PublicationDate,
+			&article.IsPublished,
+			&article.AuthorId,
+			&article.CategoryId,
+			&article.DomainId,
+			&article.Featured,
+			&article.ReadingTime,
+			&article.IsSponsored,
+			&article.CreatedAt,
+			&article.UpdatedAt,
+			&article.MetaTitle,
+			&article.MetaDescription,
+			&article.FocusKeyword,
+			&article.Excerpt,
+			&article.SourceOverlap,
+			&article.QuestionId,
+			&article.Status,
+			&result.Rank,
+			&result.TitleHighlight,
+			&result.Snippet,
+		)
 
-		if len(filters) > 0 && filters[0].ExcludeBody == "true" {
-			article, err := scanToArticleWithoutBody(rows)
-			if err != nil {
-				logger.Err(err).Send()
-				return nil, err
-			}
-			articleFromScan = article
-		} else {
-			article, err := scanToArticle(rows)
-			if err != nil {
-				logger.Err(err).Send()
-				return nil, err
-			}
-			articleFromScan = article
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		articlesFromScan = append(articlesFromScan, &article)
+		results = append(results, &result)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	// The connection is released before the lookups run.
+	rows.Close()
+
+	articles, err := s.toDtoArticles(ctx, articlesFromScan)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, article := range articles {
+		results[i].Article = article
+	}
+
+	return results, nil
+}
+
+// CountSearchArticles returns the number of articles of the domain matching
+// the query, ignoring the limit and offset.
+func (s *PostgressArticleStore) CountSearchArticles(ctx context.Context, filters *storage.SearchArticlesFilters) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	countStmt := pgQb().
+		Select("COUNT(*)").
+		From("public.article").
+		Where("search_vector @@ websearch_to_tsquery(public.search_config(?), ?)", filters.Language, filters.Query)
+
+	stmt, args, err := filterSearchArticles(countStmt, filters).ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	var count int
+
+	err = s.DB.QueryRow(ctx, stmt, args...).Scan(&count)
+	return count, err
+}
+
+// filterSearchArticles narrows stmt down to the articles of the domain matching
+// the category and status of the filter. Deleted articles never match.
+func filterSearchArticles(stmt squirrel.SelectBuilder, filter *storage.SearchArticlesFilters) squirrel.SelectBuilder {
+	stmt = stmt.Where(squirrel.Eq{"domain_id": filter.DomainId, "deleted_at": nil})
+
+	if filter.CategoryId != 0 {
+		stmt = stmt.Where(squirrel.Eq{"category_id": filter.CategoryId})
+	}
+
+	if filter.Status != "" {
+		stmt = stmt.Where(squirrel.Eq{"status": filter.Status})
+	}
+
+	return stmt
+}
+
+// toDtoArticles fills in the thumbnails, categories and authors of the
+// articles. Each of them is looked up in a single query for all the articles,
+// so a listing takes four queries whatever its size.
+func (s *PostgressArticleStore) toDtoArticles(ctx context.Context, articlesFromScan []*models.Article) ([]*dto.Article, error) {
+	var articles []*dto.Article
+
+	if len(articlesFromScan) == 0 {
+		return articles, nil
+	}
+
+	var thumbnailIds, categoryIds, authorIds []int
+
+	for _, article := range articlesFromScan {
+		if article.Thumbnail != nil {
+			thumbnailIds = append(thumbnailIds, *article.Thumbnail)
 		}
+		categoryIds = append(categoryIds, article.CategoryId)
+		authorIds = append(authorIds, article.AuthorId)
+	}
 
+	thumbnails := make(map[int]*models.Image)
+	if len(thumbnailIds) > 0 {
+		images, err := s.imageStorageStore.GetImagesByIds(ctx, uniqueIds(thumbnailIds))
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		for _, image := range images {
+			thumbnails[image.ID] = image
+		}
+	}
+
+	categoriesFromScan, err := s.categoryStore.GetCategoriesByIds(ctx, uniqueIds(categoryIds))
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	categories := make(map[int]*models.Category)
+	for _, category := range categoriesFromScan {
+		categories[category.ID] = category
+	}
+
+	authorsFromScan, err := s.authorStore.GetAuthorsByIds(ctx, uniqueIds(authorIds))
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	authors := make(map[int]*models.Author)
+	for _, author := range authorsFromScan {
+		authors[author.ID] = author
+	}
+
+	for _, articleFromScan := range articlesFromScan {
 		dtoArticle := dto.Article{
 			ID:              articleFromScan.ID,
 			Title:           articleFromScan.Title,
@@ -179,59 +718,66 @@ func (s *PostgressArticleStore) GetArticles(filters ...*storage.GetArticlesFilte
 			IsSponsored:     articleFromScan.IsSponsored,
 			CreatedAt:       articleFromScan.CreatedAt,
 			UpdatedAt:       articleFromScan.UpdatedAt,
+			MetaTitle:       articleFromScan.MetaTitle,
+			MetaDescription: articleFromScan.MetaDescription,
+			FocusKeyword:    articleFromScan.FocusKeyword,
+			Excerpt:         articleFromScan.Excerpt,
+			SourceOverlap:   articleFromScan.SourceOverlap,
+			QuestionId:      articleFromScan.QuestionId,
+			Status:          articleFromScan.Status,
 		}
 
 		if articleFromScan.Thumbnail != nil {
-			thumbnail, err := s.imageStorageStore.GetImage(*articleFromScan.Thumbnail)
-			if err != nil {
-				logger.Err(err).Send()
-				return nil, err
-			}
-			dtoArticle.Thumbnail = thumbnail
-		}
-
-		category, err := s.categoryStore.GetCategory(articleFromScan.CategoryId)
-		if err != nil {
-			logger.Err(err).Send()
-			return nil, err
+			dtoArticle.Thumbnail = thumbnails[*articleFromScan.Thumbnail]
 		}
 
-		if category == nil {
+		category, ok := categories[articleFromScan.CategoryId]
+		if !ok {
 			logger.Error().Msgf("No categories for article %s", articleFromScan.Title)
-			return nil, err
+			return nil, fmt.Errorf("category with id %d of article %d not found", articleFromScan.CategoryId, articleFromScan.ID)
 		}
 
 		dtoArticle.Category = *category
 
-		author, err := s.authorStore.GetAuthor(articleFromScan.AuthorId)
-		if err != nil {
-			logger.Err(err).Send()
-			return nil, err
+		author, ok := authors[articleFromScan.AuthorId]
+		if !ok {
+			logger.Error().Msgf("No author for article %s", articleFromScan.Title)
+			return nil, fmt.Errorf("author with id %d of article %d not found", articleFromScan.AuthorId, articleFromScan.ID)
 		}
 
 		dtoArticle.Author = *author
 
-		if err != nil {
-			logger.Err(err).Send()
-			return nil, err
-		}
-
 		articles = append(articles, &dtoArticle)
 	}
 
-	return articles, err
+	return articles, nil
+}
 
+// uniqueIds returns ids without repetitions, in the order they first appear.
+func uniqueIds(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+
+	return unique
 }
 
-func (s *PostgressArticleStore) GetArticle(id int) (*models.Article, error) {
+func (s *PostgressArticleStore) GetArticle(ctx context.Context, id int) (*models.Article, error) {
 
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
-		Select("*").
+		Select(articleColumns).
 		From("public.article").
-		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Eq{"id": id, "deleted_at": nil}).
 		ToSql()
 
 	if err != nil {
@@ -263,8 +809,83 @@ func (s *PostgressArticleStore) GetArticle(id int) (*models.Article, error) {
 	return article, err
 }
 
-func (s *PostgressArticleStore) UpdateArticle(id int, article *models.Article) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+// GetStaleArticles returns the published articles of the domain generated from
+// a question that overlap their sources more than overlapAbove or, when updatedBefore is
+// given, were last updated before it. Overlapping articles come first, then the
+// oldest ones. The body is not selected.
+func (s *PostgressArticleStore) GetStaleArticles(ctx context.Context, domainId int, updatedBefore *time.Time, overlapAbove float64, limit int) ([]*models.Article, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stale := squirrel.Or{squirrel.Gt{"source_overlap": overlapAbove}}
+	if updatedBefore != nil {
+		stale = append(stale, squirrel.Lt{"updated_at": *updatedBefore})
+	}
+
+	stmt, args, err := pgQb().
+		Select("id, title, slug, thumbnail, publication_date, is_published, author_id, category_id, domain_id, featured, reading_time, is_sponsored,created_at, updated_at, meta_title, meta_description, focus_keyword, excerpt, source_overlap, question_id, status").
+		From("public.article").
+		Where(squirrel.Eq{"domain_id": domainId, "deleted_at": nil}).
+		Where(squirrel.NotEq{"question_id": nil}).
+		Where(squirrel.Eq{"status": models.ArticleStatusPublished}).
+		Where(stale).
+		OrderByClause("COALESCE(source_overlap > ?, false) DESC", overlapAbove).
+		OrderBy("updated_at").
+		Limit(uint64(limit)).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	articles := make([]*models.Article, 0)
+
+	for rows.Next() {
+		articleFromScan, err := scanToArticleWithoutBody(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		articles = append(articles, articleFromScan)
+	}
+
+	return articles, err
+}
+
+// RequestArticleRefresh records that the refresh of the article was enqueued,
+// so it counts towards the daily quota of the domain before it completes.
+func (s *PostgressArticleStore) RequestArticleRefresh(ctx context.Context, id int) error {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Update("public.article").
+		Set("refresh_requested_at", time.Now().UTC()).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	_, err = s.DB.Exec(ctx, stmt, args...)
+	return err
+}
+
+func (s *PostgressArticleStore) UpdateArticle(ctx context.Context, id int, article *models.Article) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	articleMap := convertArticleToArticleMap(article)
@@ -273,7 +894,7 @@ func (s *PostgressArticleStore) UpdateArticle(id int, article *models.Article) (
 	stmt, args, err := pgQb().
 		Update("public.article").
 		SetMap(articleMap).
-		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.Eq{"id": id, "deleted_at": nil}).
 		Suffix("RETURNING \"id\"").ToSql()
 
 	if err != nil {
@@ -287,6 +908,27 @@ func (s *PostgressArticleStore) UpdateArticle(id int, article *models.Article) (
 	return updatedArticleId, err
 }
 
+// UpdateArticleSourceOverlap saves the share of the article body copied from
+// its sources.
+func (s *PostgressArticleStore) UpdateArticleSourceOverlap(ctx context.Context, id int, overlap float64) error {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Update("public.article").
+		Set("source_overlap", overlap).
+		Where(squirrel.Eq{"id": id, "deleted_at": nil}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	_, err = s.DB.Exec(ctx, stmt, args...)
+	return err
+}
+
 func scanToArticle(rows pgx.Rows) (*models.Article, error) {
 	var article models.Article
 	err := rows.Scan(
@@ -305,6 +947,13 @@ func scanToArticle(rows pgx.Rows) (*models.Article, error) {
 		&article.IsSponsored,
 		&article.CreatedAt,
 		&article.UpdatedAt,
+		&article.MetaTitle,
+		&article.MetaDescription,
+		&article.FocusKeyword,
+		&article.Excerpt,
+		&article.SourceOverlap,
+		&article.QuestionId,
+		&article.Status,
 	)
 
 	return &article, err
@@ -327,11 +976,22 @@ func scanToArticleWithoutBody(rows pgx.Rows) (*models.Article, error) {
 		&article.IsSponsored,
 		&article.CreatedAt,
 		&article.UpdatedAt,
+		&article.MetaTitle,
+		&article.MetaDescription,
+		&article.FocusKeyword,
+		&article.Excerpt,
+		&article.SourceOverlap,
+		&article.QuestionId,
+		&article.Status,
 	)
 
 	return &article, err
 }
 
+// convertArticleToArticleMap leaves out question_id, the question an article
+// answers is set when the article is created, status and is_published, which
+// change with UpdateArticleStatus only, and source_overlap, which changes with
+// UpdateArticleSourceOverlap only.
 func convertArticleToArticleMap(article *models.Article) map[string]interface{} {
 	return map[string]interface{}{
 		"title":            article.Title,
@@ -339,7 +999,6 @@ func convertArticleToArticleMap(article *models.Article) map[string]interface{}
 		"slug":             article.Slug,
 		"thumbnail":        article.Thumbnail,
 		"publication_date": article.PublicationDate,
-		"is_published":     article.IsPublished,
 		"author_id":        article.AuthorId,
 		"category_id":      article.CategoryId,
 		"domain_id":        article.DomainId,
@@ -348,5 +1007,9 @@ func convertArticleToArticleMap(article *models.Article) map[string]interface{}
 		"is_sponsored":     article.IsSponsored,
 		"created_at":       article.CreatedAt,
 		"updated_at":       article.UpdatedAt,
+		"meta_title":       article.MetaTitle,
+		"meta_description": article.MetaDescription,
+		"focus_keyword":    article.FocusKeyword,
+		"excerpt":          article.Excerpt,
 	}
 }
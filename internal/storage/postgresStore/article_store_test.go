@@ -0,0 +1,146 @@
+package postgresstore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rustoma/octo-pulse/internal/models"
+	"github.com/rustoma/octo-pulse/internal/storage"
+)
+
+// queryCounter counts the queries the lookup stores run, one per call.
+type queryCounter struct {
+	queries int
+}
+
+type countingCategoryStore struct {
+	storage.CategoryStore
+	counter *queryCounter
+}
+
+func (s countingCategoryStore) GetCategory(ctx context.Context, id int) (*models.Category, error) {
+	s.counter.queries++
+	return &models.Category{ID: id}, nil
+}
+
+func (s countingCategoryStore) GetCategoriesByIds(ctx context.Context, ids []int) ([]*models.Category, error) {
+	s.counter.queries++
+	categories := make([]*models.Category, 0, len(ids))
+	for _, id := range ids {
+		categories = append(categories, &models.Category{ID: id})
+	}
+	return categories, nil
+}
+
+type countingImageStore struct {
+	storage.ImageStorageStore
+	counter *queryCounter
+}
+
+func (s countingImageStore) GetImage(ctx context.Context, id int) (*models.Image, error) {
+	s.counter.queries++
+	return &models.Image{ID: id}, nil
+}
+
+func (s countingImageStore) GetImagesByIds(ctx context.Context, ids []int) ([]*models.Image, error) {
+	s.counter.queries++
+	images := make([]*models.Image, 0, len(ids))
+	for _, id := range ids {
+		images = append(images, &models.Image{ID: id})
+	}
+	return images, nil
+}
+
+type countingAuthorStore struct {
+	storage.AuthorStore
+	counter *queryCounter
+}
+
+func (s countingAuthorStore) GetAuthor(ctx context.Context, id int) (*models.Author, error) {
+	s.counter.queries++
+	return &models.Author{ID: id}, nil
+}
+
+func (s countingAuthorStore) GetAuthorsByIds(ctx context.Context, ids []int) ([]*models.Author, error) {
+	s.counter.queries++
+	authors := make([]*models.Author, 0, len(ids))
+	for _, id := range ids {
+		authors = append(authors, &models.Author{ID: id})
+	}
+	return authors, nil
+}
+
+func newCountingArticleStore() (*PostgressArticleStore, *queryCounter) {
+	counter := &queryCounter{}
+	store := NewArticleStore(nil, countingCategoryStore{counter: counter}, countingImageStore{counter: counter}, countingAuthorStore{counter: counter})
+	return store, counter
+}
+
+// listedArticles returns n articles spread over a few categories and authors,
+// each with a thumbnail of its own.
+func listedArticles(n int) []*models.Article {
+	articles := make([]*models.Article, 0, n)
+	for i := 1; i <= n; i++ {
+		thumbnail := 100 + i
+		articles = append(articles, &models.Article{ID: i, Thumbnail: &thumbnail, CategoryId: i%3 + 1, AuthorId: i%2 + 1})
+	}
+	return articles
+}
+
+// listingQueries is the listing query itself plus the thumbnail, category and
+// author lookups.
+const listingQueries = 4
+
+func TestToDtoArticlesQueryCount(t *testing.T) {
+	for _, size := range []int{1, 10, 50} {
+		t.Run(fmt.Sprintf("%d articles", size), func(t *testing.T) {
+			store, counter := newCountingArticleStore()
+
+			articles, err := store.toDtoArticles(context.Background(), listedArticles(size))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(articles) != size {
+				t.Fatalf("got %d articles, want %d", len(articles), size)
+			}
+
+			if queries := 1 + counter.queries; queries != listingQueries {
+				t.Errorf("listing %d articles took %d queries, want %d", size, queries, listingQueries)
+			}
+
+			for i, article := range articles {
+				if article.Thumbnail == nil || article.Thumbnail.ID != 101+i || article.Category.ID != (i+1)%3+1 || article.Author.ID != (i+1)%2+1 {
+					t.Errorf("article %d was filled in with the wrong thumbnail, category or author", article.ID)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkToDtoArticles(b *testing.B) {
+	for _, size := range []int{1, 10, 50} {
+		b.Run(fmt.Sprintf("%d articles", size), func(b *testing.B) {
+			store, counter := newCountingArticleStore()
+			articles := listedArticles(size)
+
+			for i := 0; i < b.N; i++ {
+				if _, err := store.toDtoArticles(context.Background(), articles); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			b.ReportMetric(1+float64(counter.queries)/float64(b.N), "queries/op")
+		})
+	}
+}
+
+func TestUniqueIds(t *testing.T) {
+	got := uniqueIds([]int{3, 1, 3, 2, 1})
+	want := []int{3, 1, 2}
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("uniqueIds = %v, want %v", got, want)
+	}
+}
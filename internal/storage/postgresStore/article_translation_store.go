@@ -0,0 +1,112 @@
+package postgresstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rustoma/octo-pulse/internal/models"
+)
+
+type PostgresArticleTranslationStore struct {
+	DB        *pgxpool.Pool
+	dbTimeout time.Duration
+}
+
+func NewArticleTranslationStore(DB *pgxpool.Pool) *PostgresArticleTranslationStore {
+	return &PostgresArticleTranslationStore{
+		DB:        DB,
+		dbTimeout: time.Second * 20,
+	}
+}
+
+// sourceArticleIdExpr resolves an article to the original article of its
+// translation group, the article itself when it is not a translation.
+const sourceArticleIdExpr = "COALESCE((SELECT source_article_id FROM public.article_translation WHERE article_id = ?), ?)"
+
+// InsertArticleTranslation links the article to the article it was translated
+// from, or to the original of that article when it is a translation itself.
+func (s *PostgresArticleTranslationStore) InsertArticleTranslation(ctx context.Context, articleId int, sourceArticleId int) error {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Insert("public.article_translation").
+		Columns("article_id, source_article_id, created_at").
+		Values(articleId, squirrel.Expr(sourceArticleIdExpr, sourceArticleId, sourceArticleId), time.Now().UTC()).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	_, err = s.DB.Exec(ctx, stmt, args...)
+	return err
+}
+
+// GetTranslatedArticles returns every article of the translation group of the
+// article, including the article itself, leaving out deleted ones. The body is
+// not selected.
+func (s *PostgresArticleTranslationStore) GetTranslatedArticles(ctx context.Context, articleId int) ([]*models.Article, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	var sourceArticleId int
+
+	stmt, args, err := pgQb().
+		Select().
+		Column(squirrel.Expr(sourceArticleIdExpr, articleId, articleId)).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	err = s.DB.QueryRow(ctx, stmt, args...).Scan(&sourceArticleId)
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	stmt, args, err = pgQb().
+		Select("id, title, slug, thumbnail, publication_date, is_published, author_id, category_id, domain_id, featured, reading_time, is_sponsored,created_at, updated_at, meta_title, meta_description, focus_keyword, excerpt, source_overlap, question_id, status").
+		From("public.article").
+		Where(squirrel.Eq{"deleted_at": nil}).
+		Where(squirrel.Or{
+			squirrel.Eq{"id": sourceArticleId},
+			squirrel.Expr("id IN (SELECT article_id FROM public.article_translation WHERE source_article_id = ?)", sourceArticleId),
+		}).
+		OrderBy("id").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	articles := make([]*models.Article, 0)
+
+	for rows.Next() {
+		articleFromScan, err := scanToArticleWithoutBody(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		articles = append(articles, articleFromScan)
+	}
+
+	return articles, err
+}
@@ -22,8 +22,8 @@ func NewAuthorStore(DB *pgxpool.Pool) *PostgresAuthorStore {
 	}
 }
 
-func (s *PostgresAuthorStore) InsertAuthor(author *models.Author) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresAuthorStore) InsertAuthor(ctx context.Context, author *models.Author) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -44,8 +44,8 @@ func (s *PostgresAuthorStore) InsertAuthor(author *models.Author) (int, error) {
 	return authorId, err
 }
 
-func (s *PostgresAuthorStore) GetAuthors() ([]*models.Author, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresAuthorStore) GetAuthors(ctx context.Context) ([]*models.Author, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -83,9 +83,9 @@ func (s *PostgresAuthorStore) GetAuthors() ([]*models.Author, error) {
 	return authors, err
 }
 
-func (s *PostgresAuthorStore) GetAuthor(id int) (*models.Author, error) {
+func (s *PostgresAuthorStore) GetAuthor(ctx context.Context, id int) (*models.Author, error) {
 
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -123,8 +123,49 @@ func (s *PostgresAuthorStore) GetAuthor(id int) (*models.Author, error) {
 	return author, err
 }
 
-func (s *PostgresAuthorStore) UpdateAuthor(id int, author *models.Author) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+// GetAuthorsByIds returns the authors with the given ids in one query, in no
+// particular order. Ids without a row are skipped.
+func (s *PostgresAuthorStore) GetAuthorsByIds(ctx context.Context, ids []int) ([]*models.Author, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("*").
+		From("public.author").
+		Where(squirrel.Expr("id = ANY(?)", ids)).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	authors := make([]*models.Author, 0, len(ids))
+
+	for rows.Next() {
+		authorFromScan, err := scanToAuthor(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		authors = append(authors, authorFromScan)
+	}
+
+	return authors, err
+}
+
+func (s *PostgresAuthorStore) UpdateAuthor(ctx context.Context, id int, author *models.Author) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	authorMap := convertAuthorToAuthorMap(author)
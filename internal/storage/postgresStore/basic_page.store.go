@@ -23,8 +23,8 @@ func NewBasicPageStore(DB *pgxpool.Pool) *PostgresBasicPageStore {
 	}
 }
 
-func (s *PostgresBasicPageStore) InsertBasicPage(page *models.BasicPage) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresBasicPageStore) InsertBasicPage(ctx context.Context, page *models.BasicPage) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -45,20 +45,27 @@ func (s *PostgresBasicPageStore) InsertBasicPage(page *models.BasicPage) (int, e
 	return pageId, err
 }
 
-func (s *PostgresBasicPageStore) GetBasicPages(filters ...*storage.GetBasicPagesFilters) ([]*models.BasicPage, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresBasicPageStore) GetBasicPages(ctx context.Context, filters ...*storage.GetBasicPagesFilters) ([]*models.BasicPage, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	basicPagesStmt := pgQb().
 		Select("*").
-		OrderBy("created_at DESC").
+		OrderBy("created_at DESC", "id DESC").
 		From("public.basic_page")
 
-	if len(filters) > 0 && filters[0].DomainId != 0 {
-		basicPagesStmt = basicPagesStmt.Where(
-			squirrel.And{
-				squirrel.Eq{"domain": filters[0].DomainId},
-			})
+	if len(filters) > 0 {
+		basicPagesStmt = filterBasicPages(basicPagesStmt, filters[0])
+	}
+
+	if len(filters) > 0 && filters[0].Limit != 0 {
+		basicPagesStmt = basicPagesStmt.Limit(uint64(filters[0].Limit))
+	}
+
+	if len(filters) > 0 && filters[0].Cursor != nil {
+		basicPagesStmt = basicPagesStmt.Where("(created_at, id) < (?, ?)", filters[0].Cursor.Date, filters[0].Cursor.Id)
+	} else if len(filters) > 0 && filters[0].Offset != 0 {
+		basicPagesStmt = basicPagesStmt.Offset(uint64(filters[0].Offset))
 	}
 
 	stmt, args, err := basicPagesStmt.ToSql()
@@ -92,9 +99,9 @@ func (s *PostgresBasicPageStore) GetBasicPages(filters ...*storage.GetBasicPages
 	return basicPages, err
 }
 
-func (s *PostgresBasicPageStore) GetBasicPage(id int) (*models.BasicPage, error) {
+func (s *PostgresBasicPageStore) GetBasicPage(ctx context.Context, id int) (*models.BasicPage, error) {
 
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -132,9 +139,9 @@ func (s *PostgresBasicPageStore) GetBasicPage(id int) (*models.BasicPage, error)
 	return page, err
 }
 
-func (s *PostgresBasicPageStore) GetBasicPageBySlug(slug string, filters ...*storage.GetBasicPageBySlugFilters) (*models.BasicPage, error) {
+func (s *PostgresBasicPageStore) GetBasicPageBySlug(ctx context.Context, slug string, filters ...*storage.GetBasicPageBySlugFilters) (*models.BasicPage, error) {
 
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	getBasicPageBySlugstmt := pgQb().
@@ -180,8 +187,8 @@ func (s *PostgresBasicPageStore) GetBasicPageBySlug(slug string, filters ...*sto
 	return page, err
 }
 
-func (s *PostgresBasicPageStore) UpdateBasicPage(id int, basicPage *models.BasicPage) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresBasicPageStore) UpdateBasicPage(ctx context.Context, id int, basicPage *models.BasicPage) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	basicPageMap := convertBasicPageToBasicPageMap(basicPage)
@@ -204,6 +211,45 @@ func (s *PostgresBasicPageStore) UpdateBasicPage(id int, basicPage *models.Basic
 	return updatedBasicPageId, err
 }
 
+// CountBasicPages returns the number of basic pages matching the filters,
+// ignoring their limit, offset and cursor.
+func (s *PostgresBasicPageStore) CountBasicPages(ctx context.Context, filters ...*storage.GetBasicPagesFilters) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	countStmt := pgQb().
+		Select("COUNT(*)").
+		From("public.basic_page")
+
+	if len(filters) > 0 {
+		countStmt = filterBasicPages(countStmt, filters[0])
+	}
+
+	stmt, args, err := countStmt.ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	var count int
+
+	err = s.DB.QueryRow(ctx, stmt, args...).Scan(&count)
+	return count, err
+}
+
+// filterBasicPages narrows stmt down to the basic pages matching the filter.
+func filterBasicPages(stmt squirrel.SelectBuilder, filter *storage.GetBasicPagesFilters) squirrel.SelectBuilder {
+	if filter.DomainId != 0 {
+		stmt = stmt.Where(
+			squirrel.And{
+				squirrel.Eq{"domain": filter.DomainId},
+			})
+	}
+
+	return stmt
+}
+
 func scanToBasicPage(rows pgx.Rows) (*models.BasicPage, error) {
 	var page models.BasicPage
 	err := rows.Scan(
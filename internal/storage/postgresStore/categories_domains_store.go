@@ -21,8 +21,8 @@ func NewCategoriesDomainsStore(DB *pgxpool.Pool) *PostgresCategoriesDomainsStore
 	}
 }
 
-func (s *PostgresCategoriesDomainsStore) AssignCategoryToDomain(categoryId int, domainId int) error {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresCategoriesDomainsStore) AssignCategoryToDomain(ctx context.Context, categoryId int, domainId int) error {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -44,8 +44,8 @@ func (s *PostgresCategoriesDomainsStore) AssignCategoryToDomain(categoryId int,
 	return err
 }
 
-func (s *PostgresCategoriesDomainsStore) GetDomainCategories(domainId int) ([]int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresCategoriesDomainsStore) GetDomainCategories(ctx context.Context, domainId int) ([]int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -0,0 +1,135 @@
+package postgresstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rustoma/octo-pulse/internal/models"
+	"github.com/rustoma/octo-pulse/internal/storage"
+)
+
+type PostgresCategoryAssignmentStore struct {
+	DB        *pgxpool.Pool
+	dbTimeout time.Duration
+}
+
+func NewCategoryAssignmentStore(DB *pgxpool.Pool) *PostgresCategoryAssignmentStore {
+	return &PostgresCategoryAssignmentStore{
+		DB:        DB,
+		dbTimeout: time.Second * 20,
+	}
+}
+
+func (s *PostgresCategoryAssignmentStore) InsertCategoryAssignment(ctx context.Context, assignment *models.CategoryAssignment) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Insert("public.category_assignment").
+		Columns("question_id, domain_id, category_id, score, runner_up_category_id, runner_up_score, method, model, created_at").
+		Values(
+			assignment.QuestionId,
+			assignment.DomainId,
+			assignment.CategoryId,
+			assignment.Score,
+			assignment.RunnerUpCategoryId,
+			assignment.RunnerUpScore,
+			assignment.Method,
+			assignment.Model,
+			time.Now().UTC(),
+		).
+		Suffix("RETURNING \"id\"").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	var assignmentId int
+
+	err = s.DB.QueryRow(ctx, stmt, args...).Scan(&assignmentId)
+	return assignmentId, err
+}
+
+// GetCategoryAssignments returns the newest assignments first.
+func (s *PostgresCategoryAssignmentStore) GetCategoryAssignments(ctx context.Context, filters ...*storage.GetCategoryAssignmentsFilters) ([]*models.CategoryAssignment, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	assignmentsStmt := pgQb().
+		Select("*").
+		From("public.category_assignment").
+		OrderBy("created_at DESC")
+
+	if len(filters) > 0 && filters[0].DomainId != 0 {
+		assignmentsStmt = assignmentsStmt.Where(squirrel.Eq{"domain_id": filters[0].DomainId})
+	}
+
+	if len(filters) > 0 && filters[0].CategoryId != 0 {
+		assignmentsStmt = assignmentsStmt.Where(squirrel.Eq{"category_id": filters[0].CategoryId})
+	}
+
+	if len(filters) > 0 && filters[0].Method != "" {
+		assignmentsStmt = assignmentsStmt.Where(squirrel.Eq{"method": filters[0].Method})
+	}
+
+	if len(filters) > 0 && filters[0].Limit != 0 {
+		assignmentsStmt = assignmentsStmt.Limit(uint64(filters[0].Limit))
+	}
+
+	if len(filters) > 0 && filters[0].Offset != 0 {
+		assignmentsStmt = assignmentsStmt.Offset(uint64(filters[0].Offset))
+	}
+
+	stmt, args, err := assignmentsStmt.ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	assignments := make([]*models.CategoryAssignment, 0)
+
+	for rows.Next() {
+		assignmentFromScan, err := scanToCategoryAssignment(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		assignments = append(assignments, assignmentFromScan)
+	}
+
+	return assignments, err
+}
+
+func scanToCategoryAssignment(rows pgx.Rows) (*models.CategoryAssignment, error) {
+	var assignment models.CategoryAssignment
+	err := rows.Scan(
+		&assignment.ID,
+		&assignment.QuestionId,
+		&assignment.DomainId,
+		&assignment.CategoryId,
+		&assignment.Score,
+		&assignment.RunnerUpCategoryId,
+		&assignment.RunnerUpScore,
+		&assignment.Method,
+		&assignment.Model,
+		&assignment.CreatedAt,
+	)
+
+	return &assignment, err
+}
@@ -0,0 +1,103 @@
+package postgresstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rustoma/octo-pulse/internal/models"
+)
+
+type PostgresCategoryEmbeddingStore struct {
+	DB        *pgxpool.Pool
+	dbTimeout time.Duration
+}
+
+func NewCategoryEmbeddingStore(DB *pgxpool.Pool) *PostgresCategoryEmbeddingStore {
+	return &PostgresCategoryEmbeddingStore{
+		DB:        DB,
+		dbTimeout: time.Second * 20,
+	}
+}
+
+// GetCategoryEmbeddings returns the embeddings computed with model for the
+// given categories. Categories without one are left out.
+func (s *PostgresCategoryEmbeddingStore) GetCategoryEmbeddings(ctx context.Context, model string, categoryIds []int) ([]*models.CategoryEmbedding, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("*").
+		From("public.category_embedding").
+		Where(squirrel.Eq{"model": model, "category_id": categoryIds}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	embeddings := make([]*models.CategoryEmbedding, 0)
+
+	for rows.Next() {
+		embeddingFromScan, err := scanToCategoryEmbedding(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		embeddings = append(embeddings, embeddingFromScan)
+	}
+
+	return embeddings, err
+}
+
+func (s *PostgresCategoryEmbeddingStore) UpsertCategoryEmbedding(ctx context.Context, embedding *models.CategoryEmbedding) error {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Insert("public.category_embedding").
+		Columns("category_id, model, text_hash, embedding, created_at").
+		Values(
+			embedding.CategoryId,
+			embedding.Model,
+			embedding.TextHash,
+			embedding.Embedding,
+			time.Now().UTC(),
+		).
+		Suffix("ON CONFLICT (category_id, model) DO UPDATE SET text_hash = EXCLUDED.text_hash, embedding = EXCLUDED.embedding, created_at = EXCLUDED.created_at").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	_, err = s.DB.Exec(ctx, stmt, args...)
+	return err
+}
+
+func scanToCategoryEmbedding(rows pgx.Rows) (*models.CategoryEmbedding, error) {
+	var embedding models.CategoryEmbedding
+	err := rows.Scan(
+		&embedding.CategoryId,
+		&embedding.Model,
+		&embedding.TextHash,
+		&embedding.Embedding,
+		&embedding.CreatedAt,
+	)
+
+	return &embedding, err
+}
@@ -23,8 +23,8 @@ func NewCategoryStore(DB *pgxpool.Pool) *PostgresCategoryStore {
 	}
 }
 
-func (c *PostgresCategoryStore) InsertCategory(category *models.Category) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), c.dbTimeout)
+func (c *PostgresCategoryStore) InsertCategory(ctx context.Context, category *models.Category) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -45,8 +45,8 @@ func (c *PostgresCategoryStore) InsertCategory(category *models.Category) (int,
 	return categoryId, err
 }
 
-func (s *PostgresCategoryStore) GetCategories(filters ...*storage.GetCategoriesFilters) ([]*models.Category, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresCategoryStore) GetCategories(ctx context.Context, filters ...*storage.GetCategoriesFilters) ([]*models.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	categoriesStmt := pgQb().
@@ -93,8 +93,8 @@ func (s *PostgresCategoryStore) GetCategories(filters ...*storage.GetCategoriesF
 	return categories, err
 }
 
-func (s *PostgresCategoryStore) GetCategory(id int) (*models.Category, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresCategoryStore) GetCategory(ctx context.Context, id int) (*models.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -132,8 +132,49 @@ func (s *PostgresCategoryStore) GetCategory(id int) (*models.Category, error) {
 	return category, err
 }
 
-func (s *PostgresCategoryStore) UpdateCategory(id int, category *models.Category) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+// GetCategoriesByIds returns the categories with the given ids in one query,
+// in no particular order. Ids without a row are skipped.
+func (s *PostgresCategoryStore) GetCategoriesByIds(ctx context.Context, ids []int) ([]*models.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("*").
+		From("public.category").
+		Where(squirrel.Expr("id = ANY(?)", ids)).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	categories := make([]*models.Category, 0, len(ids))
+
+	for rows.Next() {
+		categoryFromScan, err := scanToCategory(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		categories = append(categories, categoryFromScan)
+	}
+
+	return categories, err
+}
+
+func (s *PostgresCategoryStore) UpdateCategory(ctx context.Context, id int, category *models.Category) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	categoryMap := convertCategoryToCategoryMap(category)
@@ -23,14 +23,14 @@ func NewDomainStore(DB *pgxpool.Pool) *PostgresDomainStore {
 	}
 }
 
-func (s *PostgresDomainStore) InsertDomain(domain *models.Domain) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresDomainStore) InsertDomain(ctx context.Context, domain *models.Domain) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
 		Insert("public.domain").
-		Columns("name, email, created_at, updated_at").
-		Values(domain.Name, domain.Email, time.Now().UTC(), time.Now().UTC()).
+		Columns("name, email, created_at, updated_at, language, locale, refresh_after_days, refresh_daily_quota").
+		Values(domain.Name, domain.Email, time.Now().UTC(), time.Now().UTC(), domain.Language, domain.Locale, domain.RefreshAfterDays, domain.RefreshDailyQuota).
 		Suffix("RETURNING \"id\"").
 		ToSql()
 
@@ -45,8 +45,8 @@ func (s *PostgresDomainStore) InsertDomain(domain *models.Domain) (int, error) {
 	return domainId, err
 }
 
-func (s *PostgresDomainStore) GetDomains() ([]*models.Domain, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresDomainStore) GetDomains(ctx context.Context) ([]*models.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -83,8 +83,8 @@ func (s *PostgresDomainStore) GetDomains() ([]*models.Domain, error) {
 	return domains, err
 }
 
-func (s *PostgresDomainStore) GetDomain(id int) (*models.Domain, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresDomainStore) GetDomain(ctx context.Context, id int) (*models.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -122,8 +122,8 @@ func (s *PostgresDomainStore) GetDomain(id int) (*models.Domain, error) {
 	return domain, err
 }
 
-func (s *PostgresDomainStore) GetDomainPublicData(id int) (*dto.DomainPublicData, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresDomainStore) GetDomainPublicData(ctx context.Context, id int) (*dto.DomainPublicData, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -165,8 +165,8 @@ func (s *PostgresDomainStore) GetDomainPublicData(id int) (*dto.DomainPublicData
 	return domainPublicData, err
 }
 
-func (s *PostgresDomainStore) UpdateDomain(id int, domain *models.Domain) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresDomainStore) UpdateDomain(ctx context.Context, id int, domain *models.Domain) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	domainMap := convertDomainToDomainMap(domain)
@@ -197,6 +197,10 @@ func scanToDomain(rows pgx.Rows) (*models.Domain, error) {
 		&domain.Email,
 		&domain.CreatedAt,
 		&domain.UpdatedAt,
+		&domain.Language,
+		&domain.Locale,
+		&domain.RefreshAfterDays,
+		&domain.RefreshDailyQuota,
 	)
 
 	return &domain, err
@@ -204,9 +208,13 @@ func scanToDomain(rows pgx.Rows) (*models.Domain, error) {
 
 func convertDomainToDomainMap(domain *models.Domain) map[string]interface{} {
 	return map[string]interface{}{
-		"name":       domain.Name,
-		"email":      domain.Email,
-		"created_at": domain.CreatedAt,
-		"updated_at": domain.UpdatedAt,
+		"name":                domain.Name,
+		"email":               domain.Email,
+		"created_at":          domain.CreatedAt,
+		"updated_at":          domain.UpdatedAt,
+		"language":            domain.Language,
+		"locale":              domain.Locale,
+		"refresh_after_days":  domain.RefreshAfterDays,
+		"refresh_daily_quota": domain.RefreshDailyQuota,
 	}
 }
@@ -22,8 +22,8 @@ func NewImageCategoryStore(DB *pgxpool.Pool) *PostgresImageCategoryStore {
 	}
 }
 
-func (s *PostgresImageCategoryStore) InsertCategory(category *models.ImageCategory) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresImageCategoryStore) InsertCategory(ctx context.Context, category *models.ImageCategory) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -44,8 +44,8 @@ func (s *PostgresImageCategoryStore) InsertCategory(category *models.ImageCatego
 	return categoryId, err
 }
 
-func (s *PostgresImageCategoryStore) GetCategory(id int) (*models.ImageCategory, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresImageCategoryStore) GetCategory(ctx context.Context, id int) (*models.ImageCategory, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -82,8 +82,8 @@ func (s *PostgresImageCategoryStore) GetCategory(id int) (*models.ImageCategory,
 	return category, err
 }
 
-func (s *PostgresImageCategoryStore) GetCategories() ([]*models.ImageCategory, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresImageCategoryStore) GetCategories(ctx context.Context) ([]*models.ImageCategory, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -121,8 +121,8 @@ func (s *PostgresImageCategoryStore) GetCategories() ([]*models.ImageCategory, e
 	return categories, err
 }
 
-func (s *PostgresImageCategoryStore) UpdateImageCategory(id int, category *models.ImageCategory) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresImageCategoryStore) UpdateImageCategory(ctx context.Context, id int, category *models.ImageCategory) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	categoryMap := convertImageCategoryToImageCategoryMap(category)
@@ -23,8 +23,8 @@ func NewImageStorageStore(DB *pgxpool.Pool) *PostgresImageStorageStore {
 	}
 }
 
-func (s *PostgresImageStorageStore) InsertImage(image *models.Image) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+func (s *PostgresImageStorageStore) InsertImage(ctx context.Context, image *models.Image) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -45,9 +45,9 @@ func (s *PostgresImageStorageStore) InsertImage(image *models.Image) (int, error
 	return imageId, err
 }
 
-func (s *PostgresImageStorageStore) GetImage(id int) (*models.Image, error) {
+func (s *PostgresImageStorageStore) GetImage(ctx context.Context, id int) (*models.Image, error) {
 
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -85,37 +85,70 @@ func (s *PostgresImageStorageStore) GetImage(id int) (*models.Image, error) {
 	return image, err
 }
 
-func (s *PostgresImageStorageStore) GetImages(filters ...*storage.GetImagesFilters) ([]*models.Image, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), s.dbTimeout)
+// GetImagesByIds returns the images with the given ids in one query, in no
+// particular order. Ids without a row are skipped.
+func (s *PostgresImageStorageStore) GetImagesByIds(ctx context.Context, ids []int) ([]*models.Image, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("*").
+		From("public.image_storage").
+		Where(squirrel.Expr("id = ANY(?)", ids)).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	images := make([]*models.Image, 0, len(ids))
+
+	for rows.Next() {
+		imageFromScan, err := scanToImage(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		images = append(images, imageFromScan)
+	}
+
+	return images, err
+}
+
+func (s *PostgresImageStorageStore) GetImages(ctx context.Context, filters ...*storage.GetImagesFilters) ([]*models.Image, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
 	defer cancel()
 
 	imagesStmt := pgQb().
 		Select("*").
-		OrderBy("created_at DESC").
+		OrderBy("created_at DESC", "id DESC").
 		From("public.image_storage")
 
+	if len(filters) > 0 {
+		imagesStmt = filterImages(imagesStmt, filters[0])
+	}
+
 	if len(filters) > 0 && filters[0].Limit != 0 {
 		imagesStmt = imagesStmt.Limit(uint64(filters[0].Limit))
 	}
 
-	if len(filters) > 0 && filters[0].Offset != 0 {
+	if len(filters) > 0 && filters[0].Cursor != nil {
+		imagesStmt = imagesStmt.Where("(created_at, id) < (?, ?)", filters[0].Cursor.Date, filters[0].Cursor.Id)
+	} else if len(filters) > 0 && filters[0].Offset != 0 {
 		imagesStmt = imagesStmt.Offset(uint64(filters[0].Offset))
 	}
 
-	if len(filters) > 0 && filters[0].CategoryId != 0 {
-		imagesStmt = imagesStmt.Where(
-			squirrel.And{
-				squirrel.Eq{"category_id": filters[0].CategoryId},
-			})
-	}
-
-	if len(filters) > 0 && filters[0].Path != "" {
-		imagesStmt = imagesStmt.Where(
-			squirrel.And{
-				squirrel.Eq{"path": filters[0].Path},
-			})
-	}
-
 	stmt, args, err := imagesStmt.ToSql()
 
 	if err != nil {
@@ -147,6 +180,52 @@ func (s *PostgresImageStorageStore) GetImages(filters ...*storage.GetImagesFilte
 	return images, err
 }
 
+// CountImages returns the number of images matching the filters, ignoring
+// their limit, offset and cursor.
+func (s *PostgresImageStorageStore) CountImages(ctx context.Context, filters ...*storage.GetImagesFilters) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	countStmt := pgQb().
+		Select("COUNT(*)").
+		From("public.image_storage")
+
+	if len(filters) > 0 {
+		countStmt = filterImages(countStmt, filters[0])
+	}
+
+	stmt, args, err := countStmt.ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	var count int
+
+	err = s.DB.QueryRow(ctx, stmt, args...).Scan(&count)
+	return count, err
+}
+
+// filterImages narrows stmt down to the images matching the filter.
+func filterImages(stmt squirrel.SelectBuilder, filter *storage.GetImagesFilters) squirrel.SelectBuilder {
+	if filter.CategoryId != 0 {
+		stmt = stmt.Where(
+			squirrel.And{
+				squirrel.Eq{"category_id": filter.CategoryId},
+			})
+	}
+
+	if filter.Path != "" {
+		stmt = stmt.Where(
+			squirrel.And{
+				squirrel.Eq{"path": filter.Path},
+			})
+	}
+
+	return stmt
+}
+
 func scanToImage(rows pgx.Rows) (*models.Image, error) {
 	var image models.Image
 	err := rows.Scan(
@@ -0,0 +1,152 @@
+package postgresstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rustoma/octo-pulse/internal/models"
+)
+
+type PostgresLlmCacheStore struct {
+	DB        *pgxpool.Pool
+	dbTimeout time.Duration
+}
+
+func NewLlmCacheStore(DB *pgxpool.Pool) *PostgresLlmCacheStore {
+	return &PostgresLlmCacheStore{
+		DB:        DB,
+		dbTimeout: time.Second * 20,
+	}
+}
+
+// GetLlmCacheEntry returns nil when there is no entry for key or it has expired.
+func (s *PostgresLlmCacheStore) GetLlmCacheEntry(ctx context.Context, key string) (*models.LlmCacheEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("*").
+		From("public.llm_cache").
+		Where(squirrel.Eq{"key": key}).
+		Where(squirrel.Gt{"expires_at": time.Now().UTC()}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	var entry *models.LlmCacheEntry
+
+	for rows.Next() {
+		entryFromScan, err := scanToLlmCacheEntry(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		entry = entryFromScan
+	}
+
+	return entry, err
+}
+
+func (s *PostgresLlmCacheStore) UpsertLlmCacheEntry(ctx context.Context, entry *models.LlmCacheEntry) error {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Insert("public.llm_cache").
+		Columns("key, model, content, prompt_tokens, completion_tokens, total_tokens, expires_at, created_at, updated_at").
+		Values(
+			entry.Key,
+			entry.Model,
+			entry.Content,
+			entry.PromptTokens,
+			entry.CompletionTokens,
+			entry.TotalTokens,
+			entry.ExpiresAt,
+			time.Now().UTC(),
+			time.Now().UTC(),
+		).
+		Suffix("ON CONFLICT (key) DO UPDATE SET model = EXCLUDED.model, content = EXCLUDED.content, prompt_tokens = EXCLUDED.prompt_tokens, completion_tokens = EXCLUDED.completion_tokens, total_tokens = EXCLUDED.total_tokens, expires_at = EXCLUDED.expires_at, updated_at = EXCLUDED.updated_at").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	_, err = s.DB.Exec(ctx, stmt, args...)
+	return err
+}
+
+func (s *PostgresLlmCacheStore) DeleteLlmCacheEntry(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Delete("public.llm_cache").
+		Where(squirrel.Eq{"key": key}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	_, err = s.DB.Exec(ctx, stmt, args...)
+	return err
+}
+
+// DeleteExpiredLlmCacheEntries returns the number of deleted entries.
+func (s *PostgresLlmCacheStore) DeleteExpiredLlmCacheEntries(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Delete("public.llm_cache").
+		Where(squirrel.LtOrEq{"expires_at": time.Now().UTC()}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	result, err := s.DB.Exec(ctx, stmt, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func scanToLlmCacheEntry(rows pgx.Rows) (*models.LlmCacheEntry, error) {
+	var entry models.LlmCacheEntry
+	err := rows.Scan(
+		&entry.Key,
+		&entry.Model,
+		&entry.Content,
+		&entry.PromptTokens,
+		&entry.CompletionTokens,
+		&entry.TotalTokens,
+		&entry.ExpiresAt,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+
+	return &entry, err
+}
@@ -11,30 +11,54 @@ import (
 var logger *zerolog.Logger
 
 type PostgressStore struct {
-	User              storage.UserStore
-	Role              storage.RoleStore
-	Domain            storage.DomainStore
-	Category          storage.CategoryStore
-	Author            storage.AuthorStore
-	Article           storage.ArticleStore
-	CategoriesDomains storage.CategoriesDomainsStore
-	Image             storage.ImageStorageStore
-	ImageCategory     storage.ImageCategoryStore
-	BasicPage         storage.BasicPageStore
+	User               storage.UserStore
+	Role               storage.RoleStore
+	Domain             storage.DomainStore
+	Category           storage.CategoryStore
+	Author             storage.AuthorStore
+	Article            storage.ArticleStore
+	CategoriesDomains  storage.CategoriesDomainsStore
+	Image              storage.ImageStorageStore
+	ImageCategory      storage.ImageCategoryStore
+	BasicPage          storage.BasicPageStore
+	PromptTemplate     storage.PromptTemplateStore
+	Usage              storage.UsageStore
+	LlmCache           storage.LlmCacheStore
+	ArticleGeneration  storage.ArticleGenerationStore
+	ArticleLink        storage.ArticleLinkStore
+	ArticleFaq         storage.ArticleFaqStore
+	ArticleStatus      storage.ArticleStatusStore
+	ArticleTranslation storage.ArticleTranslationStore
+	ArticleRevision    storage.ArticleRevisionStore
+	CategoryEmbedding  storage.CategoryEmbeddingStore
+	CategoryAssignment storage.CategoryAssignmentStore
+	QuestionDuplicate  storage.QuestionDuplicateStore
 }
 
 func NewPostgresStorage(DB *pgxpool.Pool) *PostgressStore {
 	return &PostgressStore{
-		User:              NewUserStore(DB),
-		Role:              NewRoleStore(DB),
-		Domain:            NewDomainStore(DB),
-		Category:          NewCategoryStore(DB),
-		Author:            NewAuthorStore(DB),
-		Article:           NewArticleStore(DB, NewCategoryStore(DB), NewImageStorageStore(DB), NewAuthorStore(DB)),
-		CategoriesDomains: NewCategoriesDomainsStore(DB),
-		Image:             NewImageStorageStore(DB),
-		ImageCategory:     NewImageCategoryStore(DB),
-		BasicPage:         NewBasicPageStore(DB),
+		User:               NewUserStore(DB),
+		Role:               NewRoleStore(DB),
+		Domain:             NewDomainStore(DB),
+		Category:           NewCategoryStore(DB),
+		Author:             NewAuthorStore(DB),
+		Article:            NewArticleStore(DB, NewCategoryStore(DB), NewImageStorageStore(DB), NewAuthorStore(DB)),
+		CategoriesDomains:  NewCategoriesDomainsStore(DB),
+		Image:              NewImageStorageStore(DB),
+		ImageCategory:      NewImageCategoryStore(DB),
+		BasicPage:          NewBasicPageStore(DB),
+		PromptTemplate:     NewPromptTemplateStore(DB),
+		Usage:              NewUsageStore(DB),
+		LlmCache:           NewLlmCacheStore(DB),
+		ArticleGeneration:  NewArticleGenerationStore(DB),
+		ArticleLink:        NewArticleLinkStore(DB),
+		ArticleFaq:         NewArticleFaqStore(DB),
+		ArticleStatus:      NewArticleStatusStore(DB),
+		ArticleTranslation: NewArticleTranslationStore(DB),
+		ArticleRevision:    NewArticleRevisionStore(DB),
+		CategoryEmbedding:  NewCategoryEmbeddingStore(DB),
+		CategoryAssignment: NewCategoryAssignmentStore(DB),
+		QuestionDuplicate:  NewQuestionDuplicateStore(DB),
 	}
 }
 
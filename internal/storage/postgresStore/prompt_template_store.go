@@ -0,0 +1,373 @@
+package postgresstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rustoma/octo-pulse/internal/models"
+	"github.com/rustoma/octo-pulse/internal/storage"
+)
+
+type PostgresPromptTemplateStore struct {
+	DB        *pgxpool.Pool
+	dbTimeout time.Duration
+}
+
+func NewPromptTemplateStore(DB *pgxpool.Pool) *PostgresPromptTemplateStore {
+	return &PostgresPromptTemplateStore{
+		DB:        DB,
+		dbTimeout: time.Second * 20,
+	}
+}
+
+func (s *PostgresPromptTemplateStore) InsertPromptTemplate(ctx context.Context, template *models.PromptTemplate) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Insert("public.prompt_template").
+		Columns("name, version, domain_id, body, is_active, created_at, updated_at, language").
+		Values(template.Name, template.Version, template.DomainId, template.Body, template.IsActive, time.Now().UTC(), time.Now().UTC(), template.Language).
+		Suffix("RETURNING \"id\"").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	var templateId int
+
+	err = s.DB.QueryRow(ctx, stmt, args...).Scan(&templateId)
+	return templateId, err
+}
+
+func (s *PostgresPromptTemplateStore) GetPromptTemplate(ctx context.Context, id int) (*models.PromptTemplate, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("*").
+		From("public.prompt_template").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	var template *models.PromptTemplate
+
+	for rows.Next() {
+		templateFromScan, err := scanToPromptTemplate(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		template = templateFromScan
+	}
+
+	return template, err
+}
+
+func (s *PostgresPromptTemplateStore) GetPromptTemplates(ctx context.Context, filters ...*storage.GetPromptTemplatesFilters) ([]*models.PromptTemplate, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	templatesStmt := pgQb().
+		Select("*").
+		OrderBy("name ASC", "domain_id NULLS FIRST", "language ASC", "version DESC").
+		From("public.prompt_template")
+
+	if len(filters) > 0 && filters[0].Name != "" {
+		templatesStmt = templatesStmt.Where(
+			squirrel.And{
+				squirrel.Eq{"name": filters[0].Name},
+			})
+	}
+
+	if len(filters) > 0 && filters[0].DomainId != 0 {
+		templatesStmt = templatesStmt.Where(
+			squirrel.And{
+				squirrel.Eq{"domain_id": filters[0].DomainId},
+			})
+	}
+
+	if len(filters) > 0 && filters[0].Language != "" {
+		templatesStmt = templatesStmt.Where(
+			squirrel.And{
+				squirrel.Eq{"language": filters[0].Language},
+			})
+	}
+
+	stmt, args, err := templatesStmt.ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	templates := make([]*models.PromptTemplate, 0)
+
+	for rows.Next() {
+		templateFromScan, err := scanToPromptTemplate(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		templates = append(templates, templateFromScan)
+	}
+
+	return templates, err
+}
+
+// GetActivePromptTemplate returns the active domain override for the template in
+// the given language, falling back to the active global one. It returns nil when
+// neither exists.
+func (s *PostgresPromptTemplateStore) GetActivePromptTemplate(ctx context.Context, name string, domainId int, language string) (*models.PromptTemplate, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("*").
+		From("public.prompt_template").
+		Where(squirrel.And{
+			squirrel.Eq{"name": name},
+			squirrel.Eq{"is_active": true},
+			squirrel.Eq{"language": language},
+			squirrel.Or{
+				squirrel.Eq{"domain_id": domainId},
+				squirrel.Eq{"domain_id": nil},
+			},
+		}).
+		OrderBy("domain_id NULLS LAST", "version DESC").
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	var template *models.PromptTemplate
+
+	for rows.Next() {
+		templateFromScan, err := scanToPromptTemplate(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		template = templateFromScan
+	}
+
+	return template, err
+}
+
+func (s *PostgresPromptTemplateStore) GetLatestPromptTemplateVersion(ctx context.Context, name string, domainId *int, language string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("COALESCE(MAX(version), 0)").
+		From("public.prompt_template").
+		Where(squirrel.Eq{"name": name, "domain_id": domainId, "language": language}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	var version int
+
+	err = s.DB.QueryRow(ctx, stmt, args...).Scan(&version)
+	return version, err
+}
+
+// ActivatePromptTemplate makes the template the only active version for its name, domain and language.
+func (s *PostgresPromptTemplateStore) ActivatePromptTemplate(ctx context.Context, id int) error {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		name     string
+		domainId *int
+		language string
+	)
+
+	err = tx.QueryRow(ctx, "SELECT name, domain_id, language FROM public.prompt_template WHERE id = $1", id).Scan(&name, &domainId, &language)
+	if err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	deactivateStmt, args, err := pgQb().
+		Update("public.prompt_template").
+		Set("is_active", false).
+		Set("updated_at", time.Now().UTC()).
+		Where(squirrel.Eq{"name": name, "domain_id": domainId, "language": language}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, deactivateStmt, args...); err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	activateStmt, args, err := pgQb().
+		Update("public.prompt_template").
+		Set("is_active", true).
+		Set("updated_at", time.Now().UTC()).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, activateStmt, args...); err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (s *PostgresPromptTemplateStore) InsertArticlePromptVersions(ctx context.Context, articleId int, versions []*models.ArticlePromptVersion) error {
+	if len(versions) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	insertStmt := pgQb().
+		Insert("public.article_prompt_version").
+		Columns("article_id, name, version, source, language, created_at").
+		Suffix("ON CONFLICT (article_id, name) DO UPDATE SET version = EXCLUDED.version, source = EXCLUDED.source, language = EXCLUDED.language, created_at = EXCLUDED.created_at")
+
+	for _, version := range versions {
+		insertStmt = insertStmt.Values(articleId, version.Name, version.Version, version.Source, version.Language, time.Now().UTC())
+	}
+
+	stmt, args, err := insertStmt.ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	_, err = s.DB.Exec(ctx, stmt, args...)
+	return err
+}
+
+func (s *PostgresPromptTemplateStore) GetArticlePromptVersions(ctx context.Context, articleId int) ([]*models.ArticlePromptVersion, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("article_id, name, version, source, language, created_at").
+		From("public.article_prompt_version").
+		Where(squirrel.Eq{"article_id": articleId}).
+		OrderBy("name ASC").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	versions := make([]*models.ArticlePromptVersion, 0)
+
+	for rows.Next() {
+		var version models.ArticlePromptVersion
+
+		err := rows.Scan(
+			&version.ArticleId,
+			&version.Name,
+			&version.Version,
+			&version.Source,
+			&version.Language,
+			&version.CreatedAt,
+		)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		versions = append(versions, &version)
+	}
+
+	return versions, err
+}
+
+func scanToPromptTemplate(rows pgx.Rows) (*models.PromptTemplate, error) {
+	var template models.PromptTemplate
+	err := rows.Scan(
+		&template.ID,
+		&template.Name,
+		&template.Version,
+		&template.DomainId,
+		&template.Body,
+		&template.IsActive,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+		&template.Language,
+	)
+
+	return &template, err
+}
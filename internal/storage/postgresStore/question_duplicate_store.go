@@ -0,0 +1,52 @@
+package postgresstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rustoma/octo-pulse/internal/models"
+)
+
+type PostgresQuestionDuplicateStore struct {
+	DB        *pgxpool.Pool
+	dbTimeout time.Duration
+}
+
+func NewQuestionDuplicateStore(DB *pgxpool.Pool) *PostgresQuestionDuplicateStore {
+	return &PostgresQuestionDuplicateStore{
+		DB:        DB,
+		dbTimeout: time.Second * 20,
+	}
+}
+
+func (s *PostgresQuestionDuplicateStore) InsertQuestionDuplicate(ctx context.Context, duplicate *models.QuestionDuplicate) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Insert("public.question_duplicate").
+		Columns("question_id, domain_id, duplicate_of_article_id, duplicate_of_question_id, signal, score, batch_id, created_at").
+		Values(
+			duplicate.QuestionId,
+			duplicate.DomainId,
+			duplicate.DuplicateOfArticleId,
+			duplicate.DuplicateOfQuestionId,
+			duplicate.Signal,
+			duplicate.Score,
+			duplicate.BatchId,
+			time.Now().UTC(),
+		).
+		Suffix("RETURNING \"id\"").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return 0, err
+	}
+
+	var duplicateId int
+
+	err = s.DB.QueryRow(ctx, stmt, args...).Scan(&duplicateId)
+	return duplicateId, err
+}
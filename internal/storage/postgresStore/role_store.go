@@ -20,8 +20,8 @@ func NewRoleStore(DB *pgxpool.Pool) *PostgressRoleStore {
 	}
 }
 
-func (r *PostgressRoleStore) InsertRole(role *models.Role) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), r.dbTimeout)
+func (r *PostgressRoleStore) InsertRole(ctx context.Context, role *models.Role) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
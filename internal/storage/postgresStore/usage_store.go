@@ -0,0 +1,407 @@
+package postgresstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rustoma/octo-pulse/internal/dto"
+	"github.com/rustoma/octo-pulse/internal/models"
+	"github.com/rustoma/octo-pulse/internal/storage"
+)
+
+const usageTotalsColumns = "COALESCE(SUM(prompt_tokens), 0), COALESCE(SUM(completion_tokens), 0), COALESCE(SUM(total_tokens), 0), COALESCE(SUM(cost), 0)"
+
+type PostgresUsageStore struct {
+	DB        *pgxpool.Pool
+	dbTimeout time.Duration
+}
+
+func NewUsageStore(DB *pgxpool.Pool) *PostgresUsageStore {
+	return &PostgresUsageStore{
+		DB:        DB,
+		dbTimeout: time.Second * 20,
+	}
+}
+
+func (s *PostgresUsageStore) InsertUsage(ctx context.Context, usages []*models.LlmUsage) error {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	insertStmt := pgQb().
+		Insert("public.llm_usage").
+		Columns("article_id, domain_id, task, batch_id, model, prompt_tokens, completion_tokens, total_tokens, cost, created_at, updated_at")
+
+	for _, usage := range usages {
+		insertStmt = insertStmt.Values(
+			usage.ArticleId,
+			usage.DomainId,
+			usage.Task,
+			usage.BatchId,
+			usage.Model,
+			usage.PromptTokens,
+			usage.CompletionTokens,
+			usage.TotalTokens,
+			usage.Cost,
+			time.Now().UTC(),
+			time.Now().UTC(),
+		)
+	}
+
+	stmt, args, err := insertStmt.ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	_, err = s.DB.Exec(ctx, stmt, args...)
+	return err
+}
+
+func (s *PostgresUsageStore) GetArticleUsage(ctx context.Context, articleId int) ([]*models.LlmUsage, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("*").
+		From("public.llm_usage").
+		Where(squirrel.Eq{"article_id": articleId}).
+		OrderBy("created_at ASC", "model ASC").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	usages := make([]*models.LlmUsage, 0)
+
+	for rows.Next() {
+		usageFromScan, err := scanToLlmUsage(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		usages = append(usages, usageFromScan)
+	}
+
+	return usages, err
+}
+
+func (s *PostgresUsageStore) GetUsagePerDomain(ctx context.Context, filters ...*storage.GetUsageFilters) ([]*dto.DomainUsage, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	usageStmt := applyUsageFilters(pgQb().
+		Select("domain_id", usageTotalsColumns).
+		From("public.llm_usage").
+		GroupBy("domain_id").
+		OrderBy("domain_id ASC"), filters...)
+
+	stmt, args, err := usageStmt.ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	usages := make([]*dto.DomainUsage, 0)
+
+	for rows.Next() {
+		var usage dto.DomainUsage
+
+		err := rows.Scan(
+			&usage.DomainId,
+			&usage.PromptTokens,
+			&usage.CompletionTokens,
+			&usage.TotalTokens,
+			&usage.Cost,
+		)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		usages = append(usages, &usage)
+	}
+
+	return usages, err
+}
+
+func (s *PostgresUsageStore) GetUsagePerDay(ctx context.Context, filters ...*storage.GetUsageFilters) ([]*dto.DailyUsage, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	usageStmt := applyUsageFilters(pgQb().
+		Select("date_trunc('day', created_at) AS day", usageTotalsColumns).
+		From("public.llm_usage").
+		GroupBy("day").
+		OrderBy("day ASC"), filters...)
+
+	stmt, args, err := usageStmt.ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	usages := make([]*dto.DailyUsage, 0)
+
+	for rows.Next() {
+		var usage dto.DailyUsage
+
+		err := rows.Scan(
+			&usage.Day,
+			&usage.PromptTokens,
+			&usage.CompletionTokens,
+			&usage.TotalTokens,
+			&usage.Cost,
+		)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		usages = append(usages, &usage)
+	}
+
+	return usages, err
+}
+
+func (s *PostgresUsageStore) GetUsagePerBatch(ctx context.Context, filters ...*storage.GetUsageFilters) ([]*dto.BatchUsage, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	usageStmt := applyUsageFilters(pgQb().
+		Select("batch_id", "MIN(domain_id)", "COUNT(DISTINCT article_id)", "MIN(created_at)", "MAX(created_at)", usageTotalsColumns).
+		From("public.llm_usage").
+		Where(squirrel.NotEq{"batch_id": nil}).
+		GroupBy("batch_id").
+		OrderBy("MIN(created_at) DESC"), filters...)
+
+	stmt, args, err := usageStmt.ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	usages := make([]*dto.BatchUsage, 0)
+
+	for rows.Next() {
+		var usage dto.BatchUsage
+
+		err := rows.Scan(
+			&usage.BatchId,
+			&usage.DomainId,
+			&usage.Articles,
+			&usage.StartedAt,
+			&usage.EndedAt,
+			&usage.PromptTokens,
+			&usage.CompletionTokens,
+			&usage.TotalTokens,
+			&usage.Cost,
+		)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		usages = append(usages, &usage)
+	}
+
+	return usages, err
+}
+
+func (s *PostgresUsageStore) GetModelPrice(ctx context.Context, model string) (*models.LlmModelPrice, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("*").
+		From("public.llm_model_price").
+		Where(squirrel.Eq{"model": model}).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	var price *models.LlmModelPrice
+
+	for rows.Next() {
+		priceFromScan, err := scanToLlmModelPrice(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		price = priceFromScan
+	}
+
+	return price, err
+}
+
+func (s *PostgresUsageStore) GetModelPrices(ctx context.Context) ([]*models.LlmModelPrice, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Select("*").
+		From("public.llm_model_price").
+		OrderBy("model ASC").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.Query(ctx, stmt, args...)
+	defer rows.Close()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	prices := make([]*models.LlmModelPrice, 0)
+
+	for rows.Next() {
+		priceFromScan, err := scanToLlmModelPrice(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		prices = append(prices, priceFromScan)
+	}
+
+	return prices, err
+}
+
+// UpsertModelPrice changes the price of a model. Usage recorded before the
+// change keeps the cost computed at the time it was recorded.
+func (s *PostgresUsageStore) UpsertModelPrice(ctx context.Context, price *models.LlmModelPrice) error {
+	ctx, cancel := context.WithTimeout(ctx, s.dbTimeout)
+	defer cancel()
+
+	stmt, args, err := pgQb().
+		Insert("public.llm_model_price").
+		Columns("model, prompt_price, completion_price, created_at, updated_at").
+		Values(price.Model, price.PromptPrice, price.CompletionPrice, time.Now().UTC(), time.Now().UTC()).
+		Suffix("ON CONFLICT (model) DO UPDATE SET prompt_price = EXCLUDED.prompt_price, completion_price = EXCLUDED.completion_price, updated_at = EXCLUDED.updated_at").
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return err
+	}
+
+	_, err = s.DB.Exec(ctx, stmt, args...)
+	return err
+}
+
+func applyUsageFilters(usageStmt squirrel.SelectBuilder, filters ...*storage.GetUsageFilters) squirrel.SelectBuilder {
+	if len(filters) > 0 && filters[0].DomainId != 0 {
+		usageStmt = usageStmt.Where(squirrel.Eq{"domain_id": filters[0].DomainId})
+	}
+
+	if len(filters) > 0 && !filters[0].From.IsZero() {
+		usageStmt = usageStmt.Where(squirrel.GtOrEq{"created_at": filters[0].From})
+	}
+
+	if len(filters) > 0 && !filters[0].To.IsZero() {
+		usageStmt = usageStmt.Where(squirrel.Lt{"created_at": filters[0].To})
+	}
+
+	return usageStmt
+}
+
+func scanToLlmUsage(rows pgx.Rows) (*models.LlmUsage, error) {
+	var usage models.LlmUsage
+	err := rows.Scan(
+		&usage.ID,
+		&usage.ArticleId,
+		&usage.DomainId,
+		&usage.Task,
+		&usage.BatchId,
+		&usage.Model,
+		&usage.PromptTokens,
+		&usage.CompletionTokens,
+		&usage.TotalTokens,
+		&usage.Cost,
+		&usage.CreatedAt,
+		&usage.UpdatedAt,
+	)
+
+	return &usage, err
+}
+
+func scanToLlmModelPrice(rows pgx.Rows) (*models.LlmModelPrice, error) {
+	var price models.LlmModelPrice
+	err := rows.Scan(
+		&price.Model,
+		&price.PromptPrice,
+		&price.CompletionPrice,
+		&price.CreatedAt,
+		&price.UpdatedAt,
+	)
+
+	return &price, err
+}
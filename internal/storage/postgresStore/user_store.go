@@ -23,8 +23,8 @@ func NewUserStore(DB *pgxpool.Pool) *PostgressUserStore {
 	}
 }
 
-func (u *PostgressUserStore) InsertUser(user *models.User) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), u.dbTimeout)
+func (u *PostgressUserStore) InsertUser(ctx context.Context, user *models.User) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -46,8 +46,8 @@ func (u *PostgressUserStore) InsertUser(user *models.User) (int, error) {
 
 }
 
-func (u *PostgressUserStore) GetUserByEmail(email string) (*models.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), u.dbTimeout)
+func (u *PostgressUserStore) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -89,8 +89,8 @@ func (u *PostgressUserStore) GetUserByEmail(email string) (*models.User, error)
 	return user, err
 }
 
-func (u *PostgressUserStore) UpdateRefreshToken(userId int, refreshToken string) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), u.dbTimeout)
+func (u *PostgressUserStore) UpdateRefreshToken(ctx context.Context, userId int, refreshToken string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
@@ -112,8 +112,8 @@ func (u *PostgressUserStore) UpdateRefreshToken(userId int, refreshToken string)
 	return userId, err
 }
 
-func (u *PostgressUserStore) SelectUserByRefreshToken(refreshToken string) (*models.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), u.dbTimeout)
+func (u *PostgressUserStore) SelectUserByRefreshToken(ctx context.Context, refreshToken string) (*models.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.dbTimeout)
 	defer cancel()
 
 	stmt, args, err := pgQb().
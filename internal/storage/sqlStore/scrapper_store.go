@@ -1,6 +1,7 @@
 package sqlstore
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
@@ -21,7 +22,7 @@ func NewScrapperStore(DB *sql.DB) *SqlScrapperStore {
 	}
 }
 
-func (s *SqlScrapperStore) GetQuestionSources(id int) ([]*models.QuestionSource, error) {
+func (s *SqlScrapperStore) GetQuestionSources(ctx context.Context, id int) ([]*models.QuestionSource, error) {
 
 	stmt, args, err := sqlQb().
 		Select("id_question_source, id_question, href").
@@ -34,7 +35,7 @@ func (s *SqlScrapperStore) GetQuestionSources(id int) ([]*models.QuestionSource,
 		return nil, err
 	}
 
-	rows, err := s.DB.Query(stmt, args...)
+	rows, err := s.DB.QueryContext(ctx, stmt, args...)
 	defer rows.Close()
 	if err != nil {
 		logger.Err(err).Send()
@@ -56,7 +57,7 @@ func (s *SqlScrapperStore) GetQuestionSources(id int) ([]*models.QuestionSource,
 	return questionSources, nil
 }
 
-func (s *SqlScrapperStore) GetQuestionPageContents(id int) ([]*models.QuestionPageContent, error) {
+func (s *SqlScrapperStore) GetQuestionPageContents(ctx context.Context, id int) ([]*models.QuestionPageContent, error) {
 
 	stmt, args, err := sqlQb().
 		Select("octopulse_question_sources.id_question_source, octopulse_question_sources.id_question, octopulse_question_sources.href, COALESCE(page_content, '') AS page_content, COALESCE(page_content_processed, '') AS page_content_processed").
@@ -70,7 +71,7 @@ func (s *SqlScrapperStore) GetQuestionPageContents(id int) ([]*models.QuestionPa
 		return nil, err
 	}
 
-	rows, err := s.DB.Query(stmt, args...)
+	rows, err := s.DB.QueryContext(ctx, stmt, args...)
 	defer rows.Close()
 	if err != nil {
 		logger.Err(err).Send()
@@ -92,9 +93,9 @@ func (s *SqlScrapperStore) GetQuestionPageContents(id int) ([]*models.QuestionPa
 	return questionPageContents, nil
 }
 
-func (s *SqlScrapperStore) GetQuestion(id int) (*models.Question, error) {
+func (s *SqlScrapperStore) GetQuestion(ctx context.Context, id int) (*models.Question, error) {
 	stmt, args, err := sqlQb().
-		Select("id_question, question,COALESCE(answer, '') AS answer, href, octopulse_questions.fetched, id_category").
+		Select("id_question, question,COALESCE(answer, '') AS answer, href, octopulse_questions.fetched, id_category, id_phrase").
 		From("octopulse_questions").
 		Join("octopulse_phrases USING (id_phrase)").
 		Where(squirrel.Eq{"id_question": id}).
@@ -105,7 +106,7 @@ func (s *SqlScrapperStore) GetQuestion(id int) (*models.Question, error) {
 		return nil, err
 	}
 
-	rows, err := s.DB.Query(stmt, args...)
+	rows, err := s.DB.QueryContext(ctx, stmt, args...)
 
 	if err != nil {
 		logger.Err(err).Send()
@@ -130,17 +131,17 @@ func (s *SqlScrapperStore) GetQuestion(id int) (*models.Question, error) {
 		return nil, nil
 	}
 
-	questionPageContents, err := s.GetQuestionPageContents(id)
+	questionPageContents, err := s.GetQuestionPageContents(ctx, id)
 
 	question.PageContents = questionPageContents
 
 	return question, nil
 }
 
-func (s *SqlScrapperStore) GetQuestions(filters ...*storage.GetQuestionsFilters) ([]*models.Question, error) {
+func (s *SqlScrapperStore) GetQuestions(ctx context.Context, filters ...*storage.GetQuestionsFilters) ([]*models.Question, error) {
 
 	questionsStatement := sqlQb().
-		Select("id_question, question,COALESCE(answer, '') AS answer, href, octopulse_questions.fetched, id_category").
+		Select("id_question, question,COALESCE(answer, '') AS answer, href, octopulse_questions.fetched, id_category, id_phrase").
 		From("octopulse_questions").
 		Join("octopulse_phrases USING (id_phrase)").
 		OrderBy("RAND()").
@@ -163,7 +164,7 @@ func (s *SqlScrapperStore) GetQuestions(filters ...*storage.GetQuestionsFilters)
 		return nil, err
 	}
 
-	rows, err := s.DB.Query(stmt, args...)
+	rows, err := s.DB.QueryContext(ctx, stmt, args...)
 
 	if err != nil {
 		logger.Err(err).Send()
@@ -187,7 +188,53 @@ func (s *SqlScrapperStore) GetQuestions(filters ...*storage.GetQuestionsFilters)
 	return questions, nil
 }
 
-func (s *SqlScrapperStore) UpdateQuestion(id int, question *models.Question) error {
+// GetSiblingQuestions returns up to limit other questions scraped for the same
+// phrase as question, topped up with questions of the same category.
+func (s *SqlScrapperStore) GetSiblingQuestions(ctx context.Context, question *models.Question, limit int) ([]*models.Question, error) {
+	stmt, args, err := sqlQb().
+		Select("id_question, question,COALESCE(answer, '') AS answer, href, octopulse_questions.fetched, id_category, id_phrase").
+		From("octopulse_questions").
+		Join("octopulse_phrases USING (id_phrase)").
+		Where(squirrel.NotEq{"id_question": question.Id}).
+		Where(squirrel.Or{
+			squirrel.Eq{"id_phrase": question.PhraseId},
+			squirrel.Eq{"id_category": question.CategoryId},
+		}).
+		OrderByClause("id_phrase = ? DESC", question.PhraseId).
+		OrderBy("RAND()").
+		Limit(uint64(limit)).
+		ToSql()
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	rows, err := s.DB.QueryContext(ctx, stmt, args...)
+
+	if err != nil {
+		logger.Err(err).Send()
+		return nil, err
+	}
+
+	defer rows.Close()
+	questions := make([]*models.Question, 0)
+
+	for rows.Next() {
+		questionFromScan, err := scanToQuestion(rows)
+
+		if err != nil {
+			logger.Err(err).Send()
+			return nil, err
+		}
+
+		questions = append(questions, questionFromScan)
+	}
+
+	return questions, nil
+}
+
+func (s *SqlScrapperStore) UpdateQuestion(ctx context.Context, id int, question *models.Question) error {
 
 	questionMap := convertQuestionToQuestionMap(question)
 
@@ -201,12 +248,12 @@ func (s *SqlScrapperStore) UpdateQuestion(id int, question *models.Question) err
 		return err
 	}
 
-	_, err = s.DB.Exec(stmt, args...)
+	_, err = s.DB.ExecContext(ctx, stmt, args...)
 
 	return err
 }
 
-func (s *SqlScrapperStore) GetQuestionCategories() ([]*models.QuestionCategory, error) {
+func (s *SqlScrapperStore) GetQuestionCategories(ctx context.Context) ([]*models.QuestionCategory, error) {
 	stmt, args, err := sqlQb().
 		Select("*").
 		From("octopulse_categories").
@@ -217,7 +264,7 @@ func (s *SqlScrapperStore) GetQuestionCategories() ([]*models.QuestionCategory,
 		return nil, err
 	}
 
-	rows, err := s.DB.Query(stmt, args...)
+	rows, err := s.DB.QueryContext(ctx, stmt, args...)
 
 	if err != nil {
 		logger.Err(err).Send()
@@ -251,6 +298,7 @@ func scanToQuestion(rows *sql.Rows) (*models.Question, error) {
 		&question.Href,
 		&question.Fetched,
 		&question.CategoryId,
+		&question.PhraseId,
 	)
 
 	return &question, err
@@ -1,41 +1,56 @@
 package storage
 
 import (
+	"context"
+	"time"
+
 	"github.com/rustoma/octo-pulse/internal/dto"
 	"github.com/rustoma/octo-pulse/internal/models"
 )
 
 type Store struct {
-	User              UserStore
-	Role              RoleStore
-	Domain            DomainStore
-	Category          CategoryStore
-	Author            AuthorStore
-	Article           ArticleStore
-	CategoriesDomains CategoriesDomainsStore
-	Scrapper          ScrapperStore
-	Image             ImageStorageStore
-	ImageCategory     ImageCategoryStore
-	BasicPage         BasicPageStore
+	User               UserStore
+	Role               RoleStore
+	Domain             DomainStore
+	Category           CategoryStore
+	Author             AuthorStore
+	Article            ArticleStore
+	CategoriesDomains  CategoriesDomainsStore
+	Scrapper           ScrapperStore
+	Image              ImageStorageStore
+	ImageCategory      ImageCategoryStore
+	BasicPage          BasicPageStore
+	PromptTemplate     PromptTemplateStore
+	Usage              UsageStore
+	LlmCache           LlmCacheStore
+	ArticleGeneration  ArticleGenerationStore
+	ArticleLink        ArticleLinkStore
+	ArticleFaq         ArticleFaqStore
+	ArticleStatus      ArticleStatusStore
+	ArticleTranslation ArticleTranslationStore
+	ArticleRevision    ArticleRevisionStore
+	CategoryEmbedding  CategoryEmbeddingStore
+	CategoryAssignment CategoryAssignmentStore
+	QuestionDuplicate  QuestionDuplicateStore
 }
 
 type UserStore interface {
-	GetUserByEmail(email string) (*models.User, error)
-	UpdateRefreshToken(userId int, refreshToken string) (int, error)
-	SelectUserByRefreshToken(refreshToken string) (*models.User, error)
-	InsertUser(user *models.User) (int, error)
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	UpdateRefreshToken(ctx context.Context, userId int, refreshToken string) (int, error)
+	SelectUserByRefreshToken(ctx context.Context, refreshToken string) (*models.User, error)
+	InsertUser(ctx context.Context, user *models.User) (int, error)
 }
 
 type RoleStore interface {
-	InsertRole(role *models.Role) (int, error)
+	InsertRole(ctx context.Context, role *models.Role) (int, error)
 }
 
 type DomainStore interface {
-	InsertDomain(domain *models.Domain) (int, error)
-	GetDomains() ([]*models.Domain, error)
-	GetDomain(id int) (*models.Domain, error)
-	GetDomainPublicData(id int) (*dto.DomainPublicData, error)
-	UpdateDomain(id int, domain *models.Domain) (int, error)
+	InsertDomain(ctx context.Context, domain *models.Domain) (int, error)
+	GetDomains(ctx context.Context) ([]*models.Domain, error)
+	GetDomain(ctx context.Context, id int) (*models.Domain, error)
+	GetDomainPublicData(ctx context.Context, id int) (*dto.DomainPublicData, error)
+	UpdateDomain(ctx context.Context, id int, domain *models.Domain) (int, error)
 }
 
 type GetCategoriesFilters struct {
@@ -43,22 +58,24 @@ type GetCategoriesFilters struct {
 }
 
 type CategoryStore interface {
-	InsertCategory(category *models.Category) (int, error)
-	GetCategories(filters ...*GetCategoriesFilters) ([]*models.Category, error)
-	GetCategory(id int) (*models.Category, error)
-	UpdateCategory(id int, category *models.Category) (int, error)
+	InsertCategory(ctx context.Context, category *models.Category) (int, error)
+	GetCategories(ctx context.Context, filters ...*GetCategoriesFilters) ([]*models.Category, error)
+	GetCategory(ctx context.Context, id int) (*models.Category, error)
+	GetCategoriesByIds(ctx context.Context, ids []int) ([]*models.Category, error)
+	UpdateCategory(ctx context.Context, id int, category *models.Category) (int, error)
 }
 
 type CategoriesDomainsStore interface {
-	AssignCategoryToDomain(categoryId int, domainId int) error
-	GetDomainCategories(domainId int) ([]int, error)
+	AssignCategoryToDomain(ctx context.Context, categoryId int, domainId int) error
+	GetDomainCategories(ctx context.Context, domainId int) ([]int, error)
 }
 
 type AuthorStore interface {
-	InsertAuthor(author *models.Author) (int, error)
-	GetAuthors() ([]*models.Author, error)
-	GetAuthor(id int) (*models.Author, error)
-	UpdateAuthor(id int, author *models.Author) (int, error)
+	InsertAuthor(ctx context.Context, author *models.Author) (int, error)
+	GetAuthors(ctx context.Context) ([]*models.Author, error)
+	GetAuthor(ctx context.Context, id int) (*models.Author, error)
+	GetAuthorsByIds(ctx context.Context, ids []int) ([]*models.Author, error)
+	UpdateAuthor(ctx context.Context, id int, author *models.Author) (int, error)
 }
 
 type GetArticlesFilters struct {
@@ -69,14 +86,49 @@ type GetArticlesFilters struct {
 	Featured    string
 	Slug        string
 	ExcludeBody string
+	Status      string
+	// Cursor starts the page after the article it points at, by publication
+	// date and id. Offset is ignored when it is set.
+	Cursor *Cursor
+}
+
+type SearchArticlesFilters struct {
+	Query    string
+	DomainId int
+	// Language is the language of the domain, it picks the text search
+	// configuration the query is parsed with.
+	Language   string
+	CategoryId int
+	Status     string
+	Limit      int
+	Offset     int
+}
+
+type GetDeletedArticlesFilters struct {
+	DomainId int
+	Limit    int
+	Offset   int
 }
 
 type ArticleStore interface {
-	InsertArticle(article *models.Article) (int, error)
-	GetArticle(id int) (*models.Article, error)
-	GetArticles(filters ...*GetArticlesFilters) ([]*dto.Article, error)
-	UpdateArticle(id int, article *models.Article) (int, error)
-	DeleteArticle(id int) (int, error)
+	InsertArticle(ctx context.Context, article *models.Article) (int, error)
+	GetArticle(ctx context.Context, id int) (*models.Article, error)
+	GetArticles(ctx context.Context, filters ...*GetArticlesFilters) ([]*dto.Article, error)
+	CountArticles(ctx context.Context, filters ...*GetArticlesFilters) (int, error)
+	SearchArticles(ctx context.Context, filters *SearchArticlesFilters) ([]*dto.ArticleSearchResult, error)
+	CountSearchArticles(ctx context.Context, filters *SearchArticlesFilters) (int, error)
+	GetStaleArticles(ctx context.Context, domainId int, updatedBefore *time.Time, overlapAbove float64, limit int) ([]*models.Article, error)
+	RequestArticleRefresh(ctx context.Context, id int) error
+	UpdateArticle(ctx context.Context, id int, article *models.Article) (int, error)
+	UpdateArticleSourceOverlap(ctx context.Context, id int, overlap float64) error
+	DeleteArticle(ctx context.Context, id int) (int, error)
+	RestoreArticle(ctx context.Context, id int) (int, error)
+	PurgeArticle(ctx context.Context, id int) (int, error)
+	DiscardArticle(ctx context.Context, id int) (int, error)
+	PurgeDeletedArticles(ctx context.Context, deletedBefore time.Time) (int, error)
+	GetDeletedArticle(ctx context.Context, id int) (*models.Article, error)
+	GetDeletedArticles(ctx context.Context, filters *GetDeletedArticlesFilters) ([]*dto.DeletedArticle, error)
+	CountDeletedArticles(ctx context.Context, filters *GetDeletedArticlesFilters) (int, error)
 }
 
 type GetQuestionsFilters struct {
@@ -84,10 +136,11 @@ type GetQuestionsFilters struct {
 }
 
 type ScrapperStore interface {
-	GetQuestion(id int) (*models.Question, error)
-	GetQuestions(filters ...*GetQuestionsFilters) ([]*models.Question, error)
-	UpdateQuestion(id int, question *models.Question) error
-	GetQuestionCategories() ([]*models.QuestionCategory, error)
+	GetQuestion(ctx context.Context, id int) (*models.Question, error)
+	GetQuestions(ctx context.Context, filters ...*GetQuestionsFilters) ([]*models.Question, error)
+	GetSiblingQuestions(ctx context.Context, question *models.Question, limit int) ([]*models.Question, error)
+	UpdateQuestion(ctx context.Context, id int, question *models.Question) error
+	GetQuestionCategories(ctx context.Context) ([]*models.QuestionCategory, error)
 }
 
 type GetImagesFilters struct {
@@ -95,23 +148,33 @@ type GetImagesFilters struct {
 	Path       string
 	Limit      int
 	Offset     int
+	// Cursor starts the page after the image it points at, by creation date
+	// and id. Offset is ignored when it is set.
+	Cursor *Cursor
 }
 
 type ImageStorageStore interface {
-	InsertImage(image *models.Image) (int, error)
-	GetImage(id int) (*models.Image, error)
-	GetImages(filters ...*GetImagesFilters) ([]*models.Image, error)
+	InsertImage(ctx context.Context, image *models.Image) (int, error)
+	GetImage(ctx context.Context, id int) (*models.Image, error)
+	GetImagesByIds(ctx context.Context, ids []int) ([]*models.Image, error)
+	GetImages(ctx context.Context, filters ...*GetImagesFilters) ([]*models.Image, error)
+	CountImages(ctx context.Context, filters ...*GetImagesFilters) (int, error)
 }
 
 type ImageCategoryStore interface {
-	InsertCategory(category *models.ImageCategory) (int, error)
-	GetCategory(id int) (*models.ImageCategory, error)
-	GetCategories() ([]*models.ImageCategory, error)
-	UpdateImageCategory(id int, category *models.ImageCategory) (int, error)
+	InsertCategory(ctx context.Context, category *models.ImageCategory) (int, error)
+	GetCategory(ctx context.Context, id int) (*models.ImageCategory, error)
+	GetCategories(ctx context.Context) ([]*models.ImageCategory, error)
+	UpdateImageCategory(ctx context.Context, id int, category *models.ImageCategory) (int, error)
 }
 
 type GetBasicPagesFilters struct {
 	DomainId int
+	Limit    int
+	Offset   int
+	// Cursor starts the page after the page it points at, by creation date and
+	// id. Offset is ignored when it is set.
+	Cursor *Cursor
 }
 
 type GetBasicPageBySlugFilters struct {
@@ -119,9 +182,112 @@ type GetBasicPageBySlugFilters struct {
 }
 
 type BasicPageStore interface {
-	InsertBasicPage(page *models.BasicPage) (int, error)
-	GetBasicPages(filters ...*GetBasicPagesFilters) ([]*models.BasicPage, error)
-	GetBasicPage(id int) (*models.BasicPage, error)
-	GetBasicPageBySlug(slug string, filters ...*GetBasicPageBySlugFilters) (*models.BasicPage, error)
-	UpdateBasicPage(id int, basicPage *models.BasicPage) (int, error)
+	InsertBasicPage(ctx context.Context, page *models.BasicPage) (int, error)
+	GetBasicPages(ctx context.Context, filters ...*GetBasicPagesFilters) ([]*models.BasicPage, error)
+	CountBasicPages(ctx context.Context, filters ...*GetBasicPagesFilters) (int, error)
+	GetBasicPage(ctx context.Context, id int) (*models.BasicPage, error)
+	GetBasicPageBySlug(ctx context.Context, slug string, filters ...*GetBasicPageBySlugFilters) (*models.BasicPage, error)
+	UpdateBasicPage(ctx context.Context, id int, basicPage *models.BasicPage) (int, error)
+}
+
+type GetPromptTemplatesFilters struct {
+	Name     string
+	DomainId int
+	Language string
+}
+
+type PromptTemplateStore interface {
+	InsertPromptTemplate(ctx context.Context, template *models.PromptTemplate) (int, error)
+	GetPromptTemplate(ctx context.Context, id int) (*models.PromptTemplate, error)
+	GetPromptTemplates(ctx context.Context, filters ...*GetPromptTemplatesFilters) ([]*models.PromptTemplate, error)
+	GetActivePromptTemplate(ctx context.Context, name string, domainId int, language string) (*models.PromptTemplate, error)
+	GetLatestPromptTemplateVersion(ctx context.Context, name string, domainId *int, language string) (int, error)
+	ActivatePromptTemplate(ctx context.Context, id int) error
+	InsertArticlePromptVersions(ctx context.Context, articleId int, versions []*models.ArticlePromptVersion) error
+	GetArticlePromptVersions(ctx context.Context, articleId int) ([]*models.ArticlePromptVersion, error)
+}
+
+type GetUsageFilters struct {
+	DomainId int
+	From     time.Time
+	To       time.Time
+}
+
+type UsageStore interface {
+	InsertUsage(ctx context.Context, usages []*models.LlmUsage) error
+	GetArticleUsage(ctx context.Context, articleId int) ([]*models.LlmUsage, error)
+	GetUsagePerDomain(ctx context.Context, filters ...*GetUsageFilters) ([]*dto.DomainUsage, error)
+	GetUsagePerDay(ctx context.Context, filters ...*GetUsageFilters) ([]*dto.DailyUsage, error)
+	GetUsagePerBatch(ctx context.Context, filters ...*GetUsageFilters) ([]*dto.BatchUsage, error)
+	GetModelPrice(ctx context.Context, model string) (*models.LlmModelPrice, error)
+	GetModelPrices(ctx context.Context) ([]*models.LlmModelPrice, error)
+	UpsertModelPrice(ctx context.Context, price *models.LlmModelPrice) error
+}
+
+type LlmCacheStore interface {
+	GetLlmCacheEntry(ctx context.Context, key string) (*models.LlmCacheEntry, error)
+	UpsertLlmCacheEntry(ctx context.Context, entry *models.LlmCacheEntry) error
+	DeleteLlmCacheEntry(ctx context.Context, key string) error
+	DeleteExpiredLlmCacheEntries(ctx context.Context) (int64, error)
+}
+
+type ArticleGenerationStore interface {
+	GetArticleGenerationSteps(ctx context.Context, articleId int) ([]*models.ArticleGenerationStep, error)
+	UpsertArticleGenerationStep(ctx context.Context, step *models.ArticleGenerationStep) error
+	DeleteArticleGenerationSteps(ctx context.Context, articleId int) error
+}
+
+type ArticleLinkStore interface {
+	ReplaceArticleLinks(ctx context.Context, sourceArticleId int, links []*models.ArticleLink) error
+	GetInboundArticleLinks(ctx context.Context, targetArticleId int) ([]*models.ArticleLink, error)
+	RemoveInboundArticleLinks(ctx context.Context, targetArticleId int, sources []*models.Article) error
+}
+
+type CategoryEmbeddingStore interface {
+	GetCategoryEmbeddings(ctx context.Context, model string, categoryIds []int) ([]*models.CategoryEmbedding, error)
+	UpsertCategoryEmbedding(ctx context.Context, embedding *models.CategoryEmbedding) error
+}
+
+type GetCategoryAssignmentsFilters struct {
+	DomainId   int
+	CategoryId int
+	Method     string
+	Limit      int
+	Offset     int
+}
+
+type CategoryAssignmentStore interface {
+	InsertCategoryAssignment(ctx context.Context, assignment *models.CategoryAssignment) (int, error)
+	GetCategoryAssignments(ctx context.Context, filters ...*GetCategoryAssignmentsFilters) ([]*models.CategoryAssignment, error)
+}
+
+type QuestionDuplicateStore interface {
+	InsertQuestionDuplicate(ctx context.Context, duplicate *models.QuestionDuplicate) (int, error)
+}
+
+type ArticleStatusStore interface {
+	UpdateArticleStatus(ctx context.Context, transition *models.ArticleStatusTransition, publicationDate *time.Time) (int, error)
+	GetArticleStatusTransitions(ctx context.Context, articleId int) ([]*models.ArticleStatusTransition, error)
+	GetDueScheduledArticles(ctx context.Context, before time.Time) ([]*models.Article, error)
+}
+
+type ArticleTranslationStore interface {
+	InsertArticleTranslation(ctx context.Context, articleId int, sourceArticleId int) error
+	GetTranslatedArticles(ctx context.Context, articleId int) ([]*models.Article, error)
+}
+
+type ArticleFaqStore interface {
+	GetArticleFaq(ctx context.Context, articleId int) ([]*models.ArticleFaqItem, error)
+	ReplaceArticleFaq(ctx context.Context, articleId int, items []*models.ArticleFaqItem) error
+}
+
+type ArticleRevisionStore interface {
+	InsertArticleRevision(ctx context.Context, revision *models.ArticleRevision) (int, error)
+	GetArticleRevisions(ctx context.Context, articleId int) ([]*models.ArticleRevision, error)
+	GetArticleRevision(ctx context.Context, id int) (*models.ArticleRevision, error)
+	GetLatestArticleRevision(ctx context.Context, articleId int) (*models.ArticleRevision, error)
+	// CountDomainArticleRefreshes counts the refreshes of the articles of the
+	// domain completed since the given time, and the ones requested since then
+	// that are still pending.
+	CountDomainArticleRefreshes(ctx context.Context, domainId int, since time.Time) (int, error)
 }
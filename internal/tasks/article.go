@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/gosimple/slug"
+	"github.com/rustoma/octo-pulse/internal/language"
+	"github.com/rustoma/octo-pulse/internal/originality"
 	"github.com/rustoma/octo-pulse/internal/utils"
 	"math/rand"
 	"os"
@@ -12,6 +14,8 @@ import (
 
 	"github.com/hibiken/asynq"
 	"github.com/rustoma/octo-pulse/internal/ai"
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+	"github.com/rustoma/octo-pulse/internal/dto"
 	"github.com/rustoma/octo-pulse/internal/models"
 	"github.com/rustoma/octo-pulse/internal/services"
 	"github.com/rustoma/octo-pulse/internal/storage"
@@ -20,14 +24,25 @@ import (
 const (
 	TypeArticleGenerateDescription = "article:generateDescription"
 	TypeArticleGenerateArticles    = "article:generateArticles"
+	TypeArticleGenerateThumbnail   = "article:generateThumbnail"
+	TypeArticleGenerateSeo         = "article:generateSeo"
+	TypeArticleRefreshStale        = "article:refreshStale"
+	TypeArticlePublishScheduled    = "article:publishScheduled"
+	TypeArticleTranslate           = "article:translate"
+	TypeArticlePurgeTrash          = "article:purgeTrash"
 )
 
+// faqQuestionsLimit is the number of sibling questions answered in the FAQ of
+// a generated article.
+const faqQuestionsLimit = 5
+
 type articleTasks struct {
 	articleService  services.ArticleService
 	domainService   services.DomainService
 	scrapperService services.ScrapperService
 	categoryService services.CategoryService
 	imageService    services.ImageService
+	usageService    services.UsageService
 	ai              *ai.AI
 	inspector       *asynq.Inspector
 	scrapperTasks   scrapperTasks
@@ -39,6 +54,7 @@ func NewArticleTasks(
 	scrapperService services.ScrapperService,
 	categoryService services.CategoryService,
 	imageService services.ImageService,
+	usageService services.UsageService,
 	ai *ai.AI,
 	scrapperTasks scrapperTasks,
 ) articleTasks {
@@ -48,6 +64,7 @@ func NewArticleTasks(
 		scrapperService: scrapperService,
 		categoryService: categoryService,
 		imageService:    imageService,
+		usageService:    usageService,
 		ai:              ai,
 		scrapperTasks:   scrapperTasks,
 	}
@@ -56,6 +73,29 @@ func NewArticleTasks(
 type DescriptionTaskPayload struct {
 	ArticleId  int
 	QuestionId int
+	// BatchId is the id of the generate articles task that created the article.
+	BatchId string
+	// Fresh skips cached completions and saved generation steps on the first run
+	// of the task. Retries continue from the steps the first run completed.
+	Fresh bool
+	// ThumbnailCategory is the image category a generated thumbnail is saved in.
+	// No thumbnail is generated when it is 0.
+	ThumbnailCategory int
+	// Refresh rewrites a published article. Its current body stays in the
+	// previous revision and its publication date does not change.
+	Refresh bool
+}
+
+type SeoTaskPayload struct {
+	ArticleId int
+}
+
+type ThumbnailTaskPayload struct {
+	ArticleId int
+	// Headings are the headings of the article agenda the thumbnail illustrates.
+	Headings      []string
+	ImageCategory int
+	BatchId       string
 }
 
 type GenerateArticlesTaskPayload struct {
@@ -63,9 +103,10 @@ type GenerateArticlesTaskPayload struct {
 	NumberOfArticlesToCreate int
 	QuestionCategoryId       int
 	ImagesCategory           int
+	GenerateThumbnails       bool
 }
 
-func (t articleTasks) NewGenerateArticlesTask(domainId int, numberOfArticlesToCreate int, questionCategoryId int, imagesCategory int) error {
+func (t articleTasks) NewGenerateArticlesTask(ctx context.Context, domainId int, numberOfArticlesToCreate int, questionCategoryId int, imagesCategory int, generateThumbnails bool) error {
 	client := asynq.NewClient(asynq.RedisClientOpt{Addr: os.Getenv("REDIS_ADDR"), Password: os.Getenv("REDIS_PASSWORD")})
 	defer client.Close()
 
@@ -74,6 +115,7 @@ func (t articleTasks) NewGenerateArticlesTask(domainId int, numberOfArticlesToCr
 		NumberOfArticlesToCreate: numberOfArticlesToCreate,
 		QuestionCategoryId:       questionCategoryId,
 		ImagesCategory:           imagesCategory,
+		GenerateThumbnails:       generateThumbnails,
 	})
 
 	if err != nil {
@@ -81,7 +123,7 @@ func (t articleTasks) NewGenerateArticlesTask(domainId int, numberOfArticlesToCr
 	}
 
 	task := asynq.NewTask(TypeArticleGenerateArticles, payload)
-	info, err := client.Enqueue(task, asynq.MaxRetry(2), asynq.Timeout(2*time.Hour))
+	info, err := client.EnqueueContext(ctx, task, asynq.MaxRetry(2), asynq.Timeout(2*time.Hour))
 
 	logger.Info().Msgf("enqueued task: id=%s queue=%s", info.ID, info.Queue)
 
@@ -92,24 +134,28 @@ func (t articleTasks) NewGenerateArticlesTask(domainId int, numberOfArticlesToCr
 	return nil
 }
 
-func (t articleTasks) NewGenerateDescriptionTask(articleId int, questionId int) error {
+func (t articleTasks) NewGenerateDescriptionTask(ctx context.Context, articleId int, questionId int, fresh bool) error {
+	return t.newGenerateDescriptionTask(ctx, DescriptionTaskPayload{ArticleId: articleId, QuestionId: questionId, Fresh: fresh})
+}
+
+func (t articleTasks) newGenerateDescriptionTask(ctx context.Context, descriptionPayload DescriptionTaskPayload, opts ...asynq.Option) error {
 	client := asynq.NewClient(asynq.RedisClientOpt{Addr: os.Getenv("REDIS_ADDR"), Password: os.Getenv("REDIS_PASSWORD")})
 	defer client.Close()
 
-	payload, err := json.Marshal(DescriptionTaskPayload{ArticleId: articleId, QuestionId: questionId})
+	payload, err := json.Marshal(descriptionPayload)
 	if err != nil {
 		return err
 	}
 
 	task := asynq.NewTask(TypeArticleGenerateDescription, payload)
-	info, err := client.Enqueue(task, asynq.MaxRetry(2), asynq.Timeout(2*time.Hour))
-
-	logger.Info().Msgf("enqueued task: id=%s queue=%s", info.ID, info.Queue)
+	info, err := client.EnqueueContext(ctx, task, append([]asynq.Option{asynq.MaxRetry(2), asynq.Timeout(2 * time.Hour)}, opts...)...)
 
 	if err != nil {
 		return err
 	}
 
+	logger.Info().Msgf("enqueued task: id=%s queue=%s", info.ID, info.Queue)
+
 	return nil
 }
 
@@ -119,8 +165,8 @@ func (t articleTasks) HandleGenerateDescription(ctx context.Context, task *asynq
 		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
 	}
 
-	article, err := t.articleService.GetArticle(payload.ArticleId)
-	question, err := t.scrapperService.GetQuestion(payload.QuestionId)
+	article, err := t.articleService.GetArticle(ctx, payload.ArticleId)
+	question, err := t.scrapperService.GetQuestion(ctx, payload.QuestionId)
 
 	if err != nil {
 		return err
@@ -134,29 +180,292 @@ func (t articleTasks) HandleGenerateDescription(ctx context.Context, task *asynq
 		return fmt.Errorf("question with %d not found", payload.QuestionId)
 	}
 
-	description, err := t.articleService.GenerateDescription(question)
-
+	domain, err := t.domainService.GetDomain(ctx, article.DomainId)
 	if err != nil {
 		return err
 	}
 
-	article.Body = description
+	if domain == nil {
+		return fmt.Errorf("domain with %d not found: %w", article.DomainId, asynq.SkipRetry)
+	}
+
+	meter := llm.NewMeter()
+	defer t.recordUsage(services.UsageSource{
+		ArticleId: &payload.ArticleId,
+		DomainId:  article.DomainId,
+		Task:      TypeArticleGenerateDescription,
+		BatchId:   payload.BatchId,
+	}, meter)
+
+	question.PageContents = filterSources(question, domain)
+
+	retried, _ := asynq.GetRetryCount(ctx)
+	fresh := payload.Fresh && retried == 0
+
+	description, err := t.articleService.GenerateDescription(ctx, payload.ArticleId, question, domain, meter, fresh)
+
+	if err != nil {
+		return aiError(err)
+	}
+
+	article.Body = description.Body
+
+	// Failing here retries the task, which continues from the saved steps, so
+	// only the metadata is generated again.
+	err = t.articleService.GenerateSeoMetadata(ctx, article, domain, meter)
+	if err != nil {
+		return aiError(err)
+	}
+
+	var faq []*models.ArticleFaqItem
+
+	siblings, err := t.scrapperService.GetSiblingQuestions(ctx, question, faqQuestionsLimit)
+	if err != nil {
+		logger.Err(err).Msgf("Cannot get sibling questions of question id: %d", payload.QuestionId)
+	}
+
+	if len(siblings) > 0 {
+		faq, err = t.articleService.GenerateFaq(ctx, article, siblings, domain, meter)
+		if err != nil {
+			return aiError(err)
+		}
+	}
 
-	readingTime := utils.CalculateReadTime(description)
+	links, err := t.articleService.AddInternalLinks(ctx, article)
+	if err != nil {
+		logger.Err(err).Msgf("Cannot add internal links to article id: %d", payload.ArticleId)
+	}
+
+	readingTime := utils.CalculateReadTime(description.Body)
 	article.ReadingTime = &readingTime
-	article.IsPublished = true
-	article.PublicationDate = time.Now().UTC()
+	if !payload.Refresh {
+		article.PublicationDate = time.Now().UTC()
+	}
+
+	sources := make([]string, 0, len(question.PageContents))
+	for _, pageContent := range question.PageContents {
+		sources = append(sources, pageContent.PageContentProcessed)
+	}
+
+	report := originality.Check(description.Body, sources)
+	article.SourceOverlap = &report.Overlap
+
+	err = t.articleService.UpdateArticleSourceOverlap(ctx, payload.ArticleId, report.Overlap)
+	if err != nil {
+		return err
+	}
+
+	threshold := services.OriginalityThreshold()
+	original := report.Overlap <= threshold
+
+	if !original {
+		source := ""
+		if report.MaxContainmentSource >= 0 {
+			source = question.PageContents[report.MaxContainmentSource].Href
+		}
+		logger.Warn().Msgf("Article id: %d overlaps its sources in %.2f (threshold %.2f), most with %s. It needs a review before it is published", payload.ArticleId, report.Overlap, threshold, source)
+	}
+
+	// A refreshed article stays published unless it copies its sources. Then it
+	// is taken down for a review before its body is replaced, so the copy is
+	// never shown.
+	if article.Status == models.ArticleStatusPublished && !original {
+		_, err = t.articleService.ChangeArticleStatus(ctx, payload.ArticleId, &dto.ArticleStatusRequest{Status: models.ArticleStatusNeedsReview}, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = t.articleService.UpdateArticle(ctx, payload.ArticleId, article, &models.ArticleRevisionAuthor{Source: TypeArticleGenerateDescription, Refresh: payload.Refresh})
+	if err != nil {
+		return err
+	}
+
+	// A generated article waits for a review. Approving one that overlaps its
+	// sources above the threshold is left to admins, see ChangeArticleStatus.
+	if article.Status == models.ArticleStatusGenerating {
+		_, err = t.articleService.ChangeArticleStatus(ctx, payload.ArticleId, &dto.ArticleStatusRequest{Status: models.ArticleStatusNeedsReview}, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = t.articleService.ClearArticleGenerationSteps(ctx, payload.ArticleId)
+	if err != nil {
+		logger.Err(err).Msgf("Cannot clear generation steps for article id: %d", payload.ArticleId)
+	}
+
+	err = t.articleService.SaveArticleFaq(ctx, payload.ArticleId, faq)
+	if err != nil {
+		logger.Err(err).Msgf("Cannot save FAQ of article id: %d", payload.ArticleId)
+	}
+
+	err = t.articleService.SaveArticleLinks(ctx, payload.ArticleId, links)
+	if err != nil {
+		logger.Err(err).Msgf("Cannot save internal links of article id: %d", payload.ArticleId)
+	}
+
+	err = t.articleService.SaveArticlePromptVersions(ctx, payload.ArticleId, description.PromptVersions)
+	if err != nil {
+		logger.Err(err).Msgf("Cannot save prompt versions for article id: %d", payload.ArticleId)
+	}
+
+	err = t.articleService.RemoveDuplicateHeadingsFromArticle(ctx, payload.ArticleId)
+	if err != nil {
+		logger.Err(err).Msgf("Cannot remove duplicates for article id: %d", payload.ArticleId)
+	}
+
+	if payload.ThumbnailCategory != 0 {
+		headings := make([]string, 0, len(description.Agenda.Subtitles))
+		for _, subtitle := range description.Agenda.Subtitles {
+			headings = append(headings, subtitle.Title)
+		}
+
+		err = t.newGenerateThumbnailTask(ctx, payload.ArticleId, headings, payload.ThumbnailCategory, payload.BatchId)
+		if err != nil {
+			logger.Err(err).Msgf("Cannot enqueue thumbnail generation for article id: %d", payload.ArticleId)
+		}
+	}
+
+	return nil
+}
+
+func (t articleTasks) NewGenerateSeoTask(ctx context.Context, articleId int) error {
+	client := asynq.NewClient(asynq.RedisClientOpt{Addr: os.Getenv("REDIS_ADDR"), Password: os.Getenv("REDIS_PASSWORD")})
+	defer client.Close()
+
+	payload, err := json.Marshal(SeoTaskPayload{ArticleId: articleId})
+	if err != nil {
+		return err
+	}
+
+	task := asynq.NewTask(TypeArticleGenerateSeo, payload)
+	info, err := client.EnqueueContext(ctx, task, asynq.MaxRetry(2), asynq.Timeout(10*time.Minute))
+
+	if err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("enqueued task: id=%s queue=%s", info.ID, info.Queue)
+
+	return nil
+}
+
+// HandleGenerateSeo regenerates the SEO metadata of an existing article.
+func (t articleTasks) HandleGenerateSeo(ctx context.Context, task *asynq.Task) error {
+	var payload SeoTaskPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	article, err := t.articleService.GetArticle(ctx, payload.ArticleId)
+	if err != nil {
+		return err
+	}
+
+	if article == nil {
+		return fmt.Errorf("article with %d not found: %w", payload.ArticleId, asynq.SkipRetry)
+	}
+
+	domain, err := t.domainService.GetDomain(ctx, article.DomainId)
+	if err != nil {
+		return err
+	}
+
+	if domain == nil {
+		return fmt.Errorf("domain with %d not found: %w", article.DomainId, asynq.SkipRetry)
+	}
+
+	meter := llm.NewMeter()
+	defer t.recordUsage(services.UsageSource{
+		ArticleId: &payload.ArticleId,
+		DomainId:  article.DomainId,
+		Task:      TypeArticleGenerateSeo,
+	}, meter)
+
+	err = t.articleService.GenerateSeoMetadata(ctx, article, domain, meter)
+	if err != nil {
+		return aiError(err)
+	}
+
+	_, err = t.articleService.UpdateArticle(ctx, payload.ArticleId, article, &models.ArticleRevisionAuthor{Source: TypeArticleGenerateSeo})
+	return err
+}
+
+func (t articleTasks) newGenerateThumbnailTask(ctx context.Context, articleId int, headings []string, imageCategory int, batchId string) error {
+	client := asynq.NewClient(asynq.RedisClientOpt{Addr: os.Getenv("REDIS_ADDR"), Password: os.Getenv("REDIS_PASSWORD")})
+	defer client.Close()
+
+	payload, err := json.Marshal(ThumbnailTaskPayload{ArticleId: articleId, Headings: headings, ImageCategory: imageCategory, BatchId: batchId})
+	if err != nil {
+		return err
+	}
+
+	task := asynq.NewTask(TypeArticleGenerateThumbnail, payload)
+	info, err := client.EnqueueContext(ctx, task, asynq.MaxRetry(2), asynq.Timeout(10*time.Minute))
+
+	if err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("enqueued task: id=%s queue=%s", info.ID, info.Queue)
+
+	return nil
+}
+
+// HandleGenerateThumbnail replaces the thumbnail of the article with a generated
+// one. When generation fails the article keeps the thumbnail it was created with.
+func (t articleTasks) HandleGenerateThumbnail(ctx context.Context, task *asynq.Task) error {
+	var payload ThumbnailTaskPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	article, err := t.articleService.GetArticle(ctx, payload.ArticleId)
+	if err != nil {
+		return err
+	}
+
+	if article == nil {
+		return fmt.Errorf("article with %d not found: %w", payload.ArticleId, asynq.SkipRetry)
+	}
+
+	domain, err := t.domainService.GetDomain(ctx, article.DomainId)
+	if err != nil {
+		return err
+	}
+
+	if domain == nil {
+		return fmt.Errorf("domain with %d not found: %w", article.DomainId, asynq.SkipRetry)
+	}
+
+	meter := llm.NewMeter()
+	defer t.recordUsage(services.UsageSource{
+		ArticleId: &payload.ArticleId,
+		DomainId:  article.DomainId,
+		Task:      TypeArticleGenerateThumbnail,
+		BatchId:   payload.BatchId,
+	}, meter)
+
+	thumbnail, err := t.ai.ChatGPT.WithMeter(meter).GenerateThumbnail(ctx, article.Title, payload.Headings, domain)
+	if err != nil {
+		return aiError(err)
+	}
 
-	_, err = t.articleService.UpdateArticle(payload.ArticleId, article)
+	thumbnailId, err := t.imageService.SaveGeneratedImage(ctx, thumbnail.Image, article.Title, thumbnail.Alt, payload.ImageCategory)
 	if err != nil {
 		return err
 	}
 
-	err = t.articleService.RemoveDuplicateHeadingsFromArticle(payload.ArticleId)
+	article.Thumbnail = &thumbnailId
+
+	_, err = t.articleService.UpdateArticle(ctx, payload.ArticleId, article, &models.ArticleRevisionAuthor{Source: TypeArticleGenerateThumbnail})
 	if err != nil {
-		logger.Err(err).Msgf("Cannot remove duplicates for article id: %s", payload.ArticleId)
+		return err
 	}
 
+	logger.Info().Msgf("Generated thumbnail %d for article id: %d", thumbnailId, payload.ArticleId)
+
 	return nil
 }
 
@@ -168,17 +477,40 @@ func (t articleTasks) HandleGenerateArticles(ctx context.Context, task *asynq.Ta
 
 	logger.Info().Interface("payload", payload).Send()
 
-	questions, err := t.scrapperService.GetQuestions(&storage.GetQuestionsFilters{CategoryId: payload.QuestionCategoryId})
+	domain, err := t.domainService.GetDomain(ctx, payload.DomainId)
+	if err != nil {
+		return err
+	}
+
+	if domain == nil {
+		return fmt.Errorf("domain with %d not found: %w", payload.DomainId, asynq.SkipRetry)
+	}
+
+	t.warnOnLanguageMismatch(ctx, domain, payload.QuestionCategoryId)
+	lang := language.Get(domain.Language)
+
+	// Every article created by this task and its descriptions are accounted to
+	// the same batch. The task id does not change between retries.
+	batchId, _ := asynq.GetTaskID(ctx)
+
+	questions, err := t.scrapperService.GetQuestions(ctx, &storage.GetQuestionsFilters{CategoryId: payload.QuestionCategoryId})
 
 	if err != nil {
 		return err
 	}
 
-	domainCategories, err := t.categoryService.GetDomainCategories(payload.DomainId)
+	domainCategories, err := t.categoryService.GetDomainCategories(ctx, payload.DomainId)
 	if err != nil {
 		return err
 	}
 
+	dedupMeter := llm.NewMeter()
+	deduplicator, err := t.articleService.NewQuestionDeduplicator(ctx, domain, questions, dedupMeter)
+	t.recordUsage(services.UsageSource{DomainId: payload.DomainId, Task: TypeArticleGenerateArticles, BatchId: batchId}, dedupMeter)
+	if err != nil {
+		return aiError(err)
+	}
+
 	//---------------------
 
 	createdArticles := 0
@@ -188,17 +520,34 @@ func (t articleTasks) HandleGenerateArticles(ctx context.Context, task *asynq.Ta
 			break
 		}
 
+		if match := deduplicator.Check(question); match != nil {
+			logger.Info().Msgf("Skipping question id: %d, it duplicates %s id: %d (%s, score %.3f)", question.Id, match.Entry.Kind, match.Entry.Id, match.Signal, match.Score)
+
+			err = t.articleService.SaveQuestionDuplicate(ctx, question, payload.DomainId, match, batchId)
+			if err != nil {
+				logger.Err(err).Msgf("Cannot save duplicate of question id: %d", question.Id)
+			}
+
+			// Mark the question as used, so the next batches do not pick it again.
+			err = t.scrapperTasks.NewUpdateQuestionTask(ctx, question.Id, question)
+			if err != nil {
+				logger.Err(err).Msgf("Cannot mark duplicate question id: %d as used", question.Id)
+			}
+
+			continue
+		}
+
 		//ensures equal distribution of articles for categories
 		categoriesMap := make(map[string]int, len(domainCategories))
 
 		for _, category := range domainCategories {
-			articlesFromCategory, err := t.articleService.GetArticles(&storage.GetArticlesFilters{CategoryId: category.ID, DomainId: payload.DomainId})
+			articlesFromCategory, err := t.articleService.CountArticles(ctx, &storage.GetArticlesFilters{CategoryId: category.ID, DomainId: payload.DomainId})
 			logger.Info().Interface("category: ", category.ID).Send()
 			if err != nil {
 				logger.Err(err).Send()
 			}
-			logger.Info().Interface("category articles: ", len(articlesFromCategory)).Send()
-			categoriesMap[category.Slug] = len(articlesFromCategory)
+			logger.Info().Interface("category articles: ", articlesFromCategory).Send()
+			categoriesMap[category.Slug] = articlesFromCategory
 		}
 
 		filteredCategories, err := filterCategoriesByEqualDistribution(domainCategories, categoriesMap)
@@ -208,17 +557,23 @@ func (t articleTasks) HandleGenerateArticles(ctx context.Context, task *asynq.Ta
 
 		logger.Info().Interface("Filtered categories to which an article can be assigned: ", filteredCategories).Send()
 
-		catgoryId, err := t.ai.ChatGPT.AssignToCategory(filteredCategories, question)
+		usageSource := services.UsageSource{DomainId: payload.DomainId, Task: TypeArticleGenerateArticles, BatchId: batchId}
+		meter := llm.NewMeter()
+
+		assignment, err := t.categoryService.AssignQuestionToCategory(ctx, filteredCategories, question, domain, meter)
 		if err != nil {
-			return err
+			t.recordUsage(usageSource, meter)
+			return aiError(err)
 		}
 
-		if catgoryId == 0 {
-			logger.Info().Msg("There is no category that fits")
+		if assignment.CategoryId == nil {
+			logger.Info().Msgf("There is no category that fits question id: %d, best score %.3f (assignment id: %d)", question.Id, assignment.Score, assignment.ID)
+			t.recordUsage(usageSource, meter)
 			continue
 		}
 
-		logger.Info().Interface("Assigned to category", catgoryId).Send()
+		catgoryId := *assignment.CategoryId
+		logger.Info().Msgf("Assigned question id: %d to category id: %d by %s with score %.3f", question.Id, catgoryId, assignment.Method, assignment.Score)
 
 		//Get random thumbnail
 		var thumbnailId *int
@@ -226,7 +581,7 @@ func (t articleTasks) HandleGenerateArticles(ctx context.Context, task *asynq.Ta
 			imagesFilter := &storage.GetImagesFilters{
 				CategoryId: payload.ImagesCategory,
 			}
-			thumbnails, err := t.imageService.GetImages(imagesFilter)
+			thumbnails, err := t.imageService.GetImages(ctx, imagesFilter)
 			if err != nil {
 				logger.Err(err).Send()
 			}
@@ -241,10 +596,12 @@ func (t articleTasks) HandleGenerateArticles(ctx context.Context, task *asynq.Ta
 
 		article := &models.Article{
 			Title:       question.Question,
-			Slug:        slug.Make(question.Question),
-			Body:        "Treść w przygotowaniu",
+			Slug:        slug.MakeLang(question.Question, lang.Code),
+			Body:        lang.PendingContent,
 			Thumbnail:   thumbnailId,
 			CategoryId:  catgoryId,
+			QuestionId:  &question.Id,
+			Status:      models.ArticleStatusGenerating,
 			AuthorId:    1,
 			DomainId:    payload.DomainId,
 			Featured:    false,
@@ -253,29 +610,79 @@ func (t articleTasks) HandleGenerateArticles(ctx context.Context, task *asynq.Ta
 			UpdatedAt:   time.Now().UTC(),
 		}
 
-		articleId, err := t.articleService.CreateArticle(article)
+		articleId, err := t.articleService.CreateArticle(ctx, article)
 		if err != nil {
+			t.recordUsage(usageSource, meter)
 			return err
 		}
 
+		usageSource.ArticleId = &articleId
+		t.recordUsage(usageSource, meter)
+
 		logger.Info().Interface("CreatedArticle ID", articleId).Send()
 
+		deduplicator.Accept(question)
+
 		//Increase number of created articles
 		createdArticles++
 
-		err = t.scrapperTasks.NewUpdateQuestionTask(question.Id, question)
+		err = t.scrapperTasks.NewUpdateQuestionTask(ctx, question.Id, question)
 		if err != nil {
-			_, _ = t.articleService.DeleteArticle(articleId)
+			_ = t.articleService.DiscardArticle(ctx, articleId)
 			return err
 		}
 
+		var thumbnailCategory int
+		if payload.GenerateThumbnails {
+			thumbnailCategory = payload.ImagesCategory
+		}
+
 		//Generate Description For article
-		_ = t.NewGenerateDescriptionTask(articleId, question.Id)
+		_ = t.newGenerateDescriptionTask(ctx, DescriptionTaskPayload{
+			ArticleId:         articleId,
+			QuestionId:        question.Id,
+			BatchId:           batchId,
+			ThumbnailCategory: thumbnailCategory,
+		})
 	}
 
 	return nil
 }
 
+// recordUsage stores the tokens spent by a task run. Failing to record usage
+// must not fail the task, so errors are only logged. The tokens are spent even
+// when the task is cancelled, so usage is recorded with a context of its own.
+func (t articleTasks) recordUsage(source services.UsageSource, meter *llm.Meter) {
+	err := t.usageService.RecordUsage(context.Background(), source, meter.Usage())
+	if err != nil {
+		logger.Err(err).Msgf("Cannot record usage of task %s", source.Task)
+	}
+}
+
+// warnOnLanguageMismatch logs when the questions are scraped in a different
+// language than the domain is written in. Articles are always generated in the
+// domain language, so the sources get translated along the way.
+func (t articleTasks) warnOnLanguageMismatch(ctx context.Context, domain *models.Domain, questionCategoryId int) {
+	questionCategories, err := t.scrapperService.GetQuestionCategories(ctx)
+	if err != nil {
+		logger.Err(err).Msg("Cannot get question categories")
+		return
+	}
+
+	for _, questionCategory := range questionCategories {
+		if questionCategory.IdCategory != questionCategoryId {
+			continue
+		}
+
+		questionLanguage := language.Normalize(questionCategory.Language)
+		if questionLanguage != "" && questionLanguage != domain.Language {
+			logger.Warn().Msgf("Question category %d is in %q but domain %d is in %q. Articles will be written in %q", questionCategoryId, questionLanguage, domain.ID, domain.Language, domain.Language)
+		}
+
+		return
+	}
+}
+
 func filterCategoriesByEqualDistribution(categories []*models.Category, categoriesMap map[string]int) ([]*models.Category, error) {
 
 	filteredCategoriesMap := findMaxMin(categoriesMap)
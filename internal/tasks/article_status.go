@@ -0,0 +1,34 @@
+package tasks
+
+import (
+	"context"
+
+	"github.com/hibiken/asynq"
+	"github.com/rustoma/octo-pulse/internal/dto"
+	"github.com/rustoma/octo-pulse/internal/models"
+)
+
+// PublishScheduledSchedule checks for scheduled articles to publish every
+// minute.
+const PublishScheduledSchedule = "* * * * *"
+
+// HandlePublishScheduledArticles publishes the scheduled articles whose
+// publication date has come.
+func (t articleTasks) HandlePublishScheduledArticles(ctx context.Context, task *asynq.Task) error {
+	articles, err := t.articleService.GetDueScheduledArticles(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, article := range articles {
+		_, err = t.articleService.ChangeArticleStatus(ctx, article.ID, &dto.ArticleStatusRequest{Status: models.ArticleStatusPublished}, nil)
+		if err != nil {
+			logger.Err(err).Msgf("Cannot publish scheduled article id: %d", article.ID)
+			continue
+		}
+
+		logger.Info().Msgf("Published scheduled article id: %d", article.ID)
+	}
+
+	return nil
+}
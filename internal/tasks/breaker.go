@@ -0,0 +1,110 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+)
+
+// QueuePauser pauses a queue while the LLM circuit breaker is open, so pending
+// tasks wait for the provider to recover instead of failing one after another.
+type QueuePauser struct {
+	queue        string
+	newInspector func() queueInspector
+	mu           sync.Mutex
+	resume       *time.Timer
+}
+
+// queueInspector is the part of asynq.Inspector the pauser uses.
+type queueInspector interface {
+	PauseQueue(queue string) error
+	UnpauseQueue(queue string) error
+	GetQueueInfo(queue string) (*asynq.QueueInfo, error)
+	Close() error
+}
+
+func NewQueuePauser(queue string) *QueuePauser {
+	return &QueuePauser{queue: queue, newInspector: newQueueInspector}
+}
+
+func newQueueInspector() queueInspector {
+	return asynq.NewInspector(asynq.RedisClientOpt{Addr: os.Getenv("REDIS_ADDR"), Password: os.Getenv("REDIS_PASSWORD")})
+}
+
+// OnBreakerStateChange is meant to be registered with llm.Breaker.OnStateChange.
+// The queue is resumed when the cooldown is over so the next task can probe the
+// provider. If the probe fails the breaker opens and the queue is paused again.
+func (p *QueuePauser) OnBreakerStateChange(state llm.BreakerState, retryIn time.Duration) {
+	if state != llm.BreakerOpen {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	inspector := p.newInspector()
+	defer inspector.Close()
+
+	if err := inspector.PauseQueue(p.queue); err != nil {
+		logger.Err(err).Msgf("Cannot pause queue %s", p.queue)
+		return
+	}
+	logger.Warn().Msgf("Queue %s paused for %s, the AI provider is unavailable", p.queue, retryIn)
+
+	if p.resume != nil {
+		p.resume.Stop()
+	}
+	p.resume = time.AfterFunc(retryIn, p.Resume)
+}
+
+// Resume unpauses the queue. Call it on startup as well, in case the worker
+// stopped while the queue was paused.
+func (p *QueuePauser) Resume() {
+	inspector := p.newInspector()
+	defer inspector.Close()
+
+	// The queue does not exist until a task is enqueued to it.
+	info, err := inspector.GetQueueInfo(p.queue)
+	if err != nil || !info.Paused {
+		return
+	}
+
+	if err := inspector.UnpauseQueue(p.queue); err != nil {
+		logger.Err(err).Msgf("Cannot resume queue %s", p.queue)
+		return
+	}
+	logger.Info().Msgf("Queue %s resumed", p.queue)
+}
+
+// IsFailure is meant for asynq.Config. A task stopped by the open circuit
+// breaker did not fail on its own, so it does not use up one of its retries.
+func IsFailure(err error) bool {
+	return !errors.Is(err, llm.ErrCircuitOpen)
+}
+
+// RetryDelay is meant for asynq.Config. Tasks stopped by the open circuit
+// breaker are retried once it lets calls through again.
+func RetryDelay(n int, err error, task *asynq.Task) time.Duration {
+	var circuitErr *llm.CircuitOpenError
+	if errors.As(err, &circuitErr) {
+		return circuitErr.RetryIn
+	}
+
+	return asynq.DefaultRetryDelayFunc(n, err, task)
+}
+
+// aiError marks errors no retry can fix, like a prompt that does not fit the
+// model, so asynq does not run the task again.
+func aiError(err error) error {
+	switch llm.Classify(err) {
+	case llm.ErrorContextLength, llm.ErrorInvalidRequest:
+		return fmt.Errorf("%v: %w", err, asynq.SkipRetry)
+	}
+
+	return err
+}
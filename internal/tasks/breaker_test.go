@@ -0,0 +1,178 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+)
+
+// fakeInspector keeps the paused flag of the queues in memory.
+type fakeInspector struct {
+	mu     sync.Mutex
+	paused map[string]bool
+	pauses int
+}
+
+func newFakeInspector() *fakeInspector {
+	return &fakeInspector{paused: make(map[string]bool)}
+}
+
+func (i *fakeInspector) PauseQueue(queue string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.paused[queue] = true
+	i.pauses++
+	return nil
+}
+
+func (i *fakeInspector) UnpauseQueue(queue string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.paused[queue] = false
+	return nil
+}
+
+func (i *fakeInspector) GetQueueInfo(queue string) (*asynq.QueueInfo, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	paused, ok := i.paused[queue]
+	if !ok {
+		return nil, fmt.Errorf("queue %s not found", queue)
+	}
+	return &asynq.QueueInfo{Queue: queue, Paused: paused}, nil
+}
+
+func (i *fakeInspector) Close() error {
+	return nil
+}
+
+func (i *fakeInspector) isPaused(queue string) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.paused[queue]
+}
+
+func newTestPauser(inspector *fakeInspector) *QueuePauser {
+	pauser := NewQueuePauser("default")
+	pauser.newInspector = func() queueInspector { return inspector }
+	return pauser
+}
+
+func TestQueuePauserPausesUntilRetry(t *testing.T) {
+	inspector := newFakeInspector()
+	pauser := newTestPauser(inspector)
+
+	pauser.OnBreakerStateChange(llm.BreakerOpen, 30*time.Millisecond)
+	if !inspector.isPaused("default") {
+		t.Fatal("queue is not paused while the breaker is open")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if inspector.isPaused("default") {
+		t.Fatal("queue is still paused after the cooldown")
+	}
+}
+
+func TestQueuePauserIgnoresOtherStates(t *testing.T) {
+	for _, state := range []llm.BreakerState{llm.BreakerClosed, llm.BreakerHalfOpen} {
+		inspector := newFakeInspector()
+		pauser := newTestPauser(inspector)
+
+		pauser.OnBreakerStateChange(state, 0)
+		if inspector.pauses != 0 {
+			t.Errorf("queue paused when the breaker became %s", state)
+		}
+	}
+}
+
+func TestQueuePauserReopenPostponesResume(t *testing.T) {
+	inspector := newFakeInspector()
+	pauser := newTestPauser(inspector)
+
+	pauser.OnBreakerStateChange(llm.BreakerOpen, 30*time.Millisecond)
+	// The probe failed, the breaker opened again for longer.
+	pauser.OnBreakerStateChange(llm.BreakerOpen, 200*time.Millisecond)
+
+	time.Sleep(60 * time.Millisecond)
+	if !inspector.isPaused("default") {
+		t.Fatal("the first cooldown resumed the queue after the breaker opened again")
+	}
+}
+
+func TestQueuePauserResume(t *testing.T) {
+	inspector := newFakeInspector()
+	pauser := newTestPauser(inspector)
+
+	// The queue does not exist yet.
+	pauser.Resume()
+
+	inspector.paused["default"] = true
+	pauser.Resume()
+	if inspector.isPaused("default") {
+		t.Fatal("Resume did not unpause the queue left paused")
+	}
+}
+
+func TestIsFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"open circuit", &llm.CircuitOpenError{RetryIn: time.Minute}, false},
+		{"wrapped open circuit", fmt.Errorf("generate description: %w", &llm.CircuitOpenError{}), false},
+		{"provider error", &llm.APIError{StatusCode: 503}, true},
+		{"other error", errors.New("boom"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsFailure(tt.err); got != tt.want {
+				t.Errorf("IsFailure = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	task := asynq.NewTask(TypeArticleGenerateDescription, nil)
+
+	err := fmt.Errorf("generate description: %w", &llm.CircuitOpenError{RetryIn: 42 * time.Second})
+	if delay := RetryDelay(3, err, task); delay != 42*time.Second {
+		t.Errorf("RetryDelay = %s, want the remaining cooldown of 42s", delay)
+	}
+
+	if delay := RetryDelay(0, errors.New("boom"), task); delay <= 0 {
+		t.Errorf("RetryDelay = %s, want the default asynq delay", delay)
+	}
+}
+
+func TestAiError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		skipRetry bool
+	}{
+		{"prompt too long", &llm.APIError{StatusCode: 400, Code: "context_length_exceeded"}, true},
+		{"invalid request", &llm.APIError{StatusCode: 400}, true},
+		{"rate limit", &llm.APIError{StatusCode: 429}, false},
+		{"unavailable", &llm.APIError{StatusCode: 503}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(aiError(tt.err), asynq.SkipRetry); got != tt.skipRetry {
+				t.Errorf("skip retry = %t, want %t", got, tt.skipRetry)
+			}
+		})
+	}
+}
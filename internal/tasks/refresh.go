@@ -0,0 +1,95 @@
+package tasks
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rustoma/octo-pulse/internal/services"
+)
+
+// DefaultRefreshSchedule runs the refresh of stale articles every night.
+const DefaultRefreshSchedule = "0 3 * * *"
+
+// RefreshSchedule is the cron spec of the refresh of stale articles, read from
+// ARTICLE_REFRESH_SCHEDULE.
+func RefreshSchedule() string {
+	if schedule := os.Getenv("ARTICLE_REFRESH_SCHEDULE"); schedule != "" {
+		return schedule
+	}
+
+	return DefaultRefreshSchedule
+}
+
+// HandleRefreshStaleArticles enqueues the rewrite of the stale articles of
+// every domain, up to what is left of the daily quota of the domain. The quota
+// counts the refreshes still queued too, so running the job again before they
+// complete does not exceed it. Articles whose refresh is still queued are not
+// enqueued again.
+func (t articleTasks) HandleRefreshStaleArticles(ctx context.Context, task *asynq.Task) error {
+	domains, err := t.domainService.GetDomains(ctx)
+	if err != nil {
+		return err
+	}
+
+	batchId, _ := asynq.GetTaskID(ctx)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	threshold := services.OriginalityThreshold()
+
+	for _, domain := range domains {
+		if domain.RefreshDailyQuota == 0 {
+			continue
+		}
+
+		refreshed, err := t.articleService.CountArticleRefreshes(ctx, domain.ID, today)
+		if err != nil {
+			logger.Err(err).Msgf("Cannot count refreshed articles of domain id: %d", domain.ID)
+			continue
+		}
+
+		remaining := domain.RefreshDailyQuota - refreshed
+		if remaining <= 0 {
+			logger.Info().Msgf("Daily refresh quota of domain id: %d is used up", domain.ID)
+			continue
+		}
+
+		articles, err := t.articleService.GetStaleArticles(ctx, domain, threshold, remaining)
+		if err != nil {
+			logger.Err(err).Msgf("Cannot get stale articles of domain id: %d", domain.ID)
+			continue
+		}
+
+		enqueued := 0
+		for _, article := range articles {
+			err = t.newGenerateDescriptionTask(ctx, DescriptionTaskPayload{
+				ArticleId:  article.ID,
+				QuestionId: *article.QuestionId,
+				BatchId:    batchId,
+				Fresh:      true,
+				Refresh:    true,
+			}, asynq.Unique(24*time.Hour))
+
+			if errors.Is(err, asynq.ErrDuplicateTask) {
+				continue
+			}
+
+			if err != nil {
+				logger.Err(err).Msgf("Cannot enqueue refresh of article id: %d", article.ID)
+				continue
+			}
+
+			err = t.articleService.RequestArticleRefresh(ctx, article.ID)
+			if err != nil {
+				logger.Err(err).Msgf("Cannot reserve the refresh quota for article id: %d", article.ID)
+			}
+
+			enqueued++
+		}
+
+		logger.Info().Msgf("Enqueued refresh of %d articles of domain id: %d", enqueued, domain.ID)
+	}
+
+	return nil
+}
@@ -30,7 +30,7 @@ type UpdateQuestionTaskPayload struct {
 	Question *models.Question
 }
 
-func (t scrapperTasks) NewUpdateQuestionTask(id int, question *models.Question) error {
+func (t scrapperTasks) NewUpdateQuestionTask(ctx context.Context, id int, question *models.Question) error {
 	client := asynq.NewClient(asynq.RedisClientOpt{Addr: os.Getenv("REDIS_ADDR"), Password: os.Getenv("REDIS_PASSWORD")})
 	defer client.Close()
 
@@ -44,7 +44,7 @@ func (t scrapperTasks) NewUpdateQuestionTask(id int, question *models.Question)
 	}
 
 	task := asynq.NewTask(TypeScrapperUpdateQuestion, payload)
-	info, err := client.Enqueue(task, asynq.MaxRetry(20))
+	info, err := client.EnqueueContext(ctx, task, asynq.MaxRetry(20))
 	logger.Info().Msgf("enqueued task: id=%s queue=%s", info.ID, info.Queue)
 
 	if err != nil {
@@ -61,7 +61,7 @@ func (t scrapperTasks) HandleUpdateQuestionTask(ctx context.Context, task *asynq
 	}
 
 	payload.Question.Fetched = 1
-	err := t.scrapperService.UpdateQuestion(payload.Id, payload.Question)
+	err := t.scrapperService.UpdateQuestion(ctx, payload.Id, payload.Question)
 	if err != nil {
 		return err
 	}
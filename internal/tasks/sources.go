@@ -0,0 +1,30 @@
+package tasks
+
+import (
+	"github.com/rustoma/octo-pulse/internal/language"
+	"github.com/rustoma/octo-pulse/internal/models"
+	"github.com/rustoma/octo-pulse/internal/sourcecheck"
+)
+
+// filterSources returns the page contents of the question usable as sources
+// for an article of the domain, logging why the others are rejected.
+func filterSources(question *models.Question, domain *models.Domain) []*models.QuestionPageContent {
+	lang := language.Get(domain.Language).Code
+	sources := make([]*models.QuestionPageContent, 0, len(question.PageContents))
+
+	for _, pageContent := range question.PageContents {
+		rejection := sourcecheck.Check(pageContent.PageContentProcessed, lang)
+		if rejection != nil {
+			logger.Info().Msgf("Source %s of question id: %d is rejected: %s", pageContent.Href, question.Id, rejection)
+			continue
+		}
+
+		sources = append(sources, pageContent)
+	}
+
+	if len(sources) < len(question.PageContents) {
+		logger.Info().Msgf("Question id: %d keeps %d of %d sources", question.Id, len(sources), len(question.PageContents))
+	}
+
+	return sources
+}
@@ -25,24 +25,33 @@ func NewTasks(
 	scrapperService services.ScrapperService,
 	categoryService services.CategoryService,
 	imageService services.ImageService,
+	usageService services.UsageService,
 	ai *ai.AI) *Tasks {
 	scrapperTasks := NewScrapperTasks(scrapperService)
 
 	return &Tasks{
-		Article:  NewArticleTasks(articleService, domainService, scrapperService, categoryService, imageService, ai, scrapperTasks),
+		Article:  NewArticleTasks(articleService, domainService, scrapperService, categoryService, imageService, usageService, ai, scrapperTasks),
 		Scrapper: scrapperTasks,
 	}
 }
 
 type ArticleTasker interface {
-	NewGenerateDescriptionTask(pageId int, questionId int) error
+	NewGenerateDescriptionTask(ctx context.Context, pageId int, questionId int, fresh bool) error
 	HandleGenerateDescription(ctx context.Context, task *asynq.Task) error
-	NewGenerateArticlesTask(domainId int, numberOfArticlesToCreate int, questionCategoryId int, imagesCategory int) error
+	NewGenerateArticlesTask(ctx context.Context, domainId int, numberOfArticlesToCreate int, questionCategoryId int, imagesCategory int, generateThumbnails bool) error
 	HandleGenerateArticles(ctx context.Context, task *asynq.Task) error
+	HandleGenerateThumbnail(ctx context.Context, task *asynq.Task) error
+	NewGenerateSeoTask(ctx context.Context, articleId int) error
+	HandleGenerateSeo(ctx context.Context, task *asynq.Task) error
+	HandleRefreshStaleArticles(ctx context.Context, task *asynq.Task) error
+	HandlePublishScheduledArticles(ctx context.Context, task *asynq.Task) error
+	NewTranslateArticleTask(ctx context.Context, articleId int, domainId int) error
+	HandleTranslateArticle(ctx context.Context, task *asynq.Task) error
+	HandlePurgeTrash(ctx context.Context, task *asynq.Task) error
 }
 
 type ScrapperTasker interface {
-	NewUpdateQuestionTask(id int, question *models.Question) error
+	NewUpdateQuestionTask(ctx context.Context, id int, question *models.Question) error
 	HandleUpdateQuestionTask(ctx context.Context, task *asynq.Task) error
 }
 
@@ -0,0 +1,121 @@
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/rustoma/octo-pulse/internal/ai/llm"
+	e "github.com/rustoma/octo-pulse/internal/errors"
+	"github.com/rustoma/octo-pulse/internal/services"
+)
+
+type TranslateTaskPayload struct {
+	ArticleId int
+	// DomainId is the domain the translation is created in.
+	DomainId int
+}
+
+func (t articleTasks) NewTranslateArticleTask(ctx context.Context, articleId int, domainId int) error {
+	client := asynq.NewClient(asynq.RedisClientOpt{Addr: os.Getenv("REDIS_ADDR"), Password: os.Getenv("REDIS_PASSWORD")})
+	defer client.Close()
+
+	payload, err := json.Marshal(TranslateTaskPayload{ArticleId: articleId, DomainId: domainId})
+	if err != nil {
+		return err
+	}
+
+	task := asynq.NewTask(TypeArticleTranslate, payload)
+	info, err := client.EnqueueContext(ctx, task, asynq.MaxRetry(2), asynq.Timeout(20*time.Minute))
+
+	if err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("enqueued task: id=%s queue=%s", info.ID, info.Queue)
+
+	return nil
+}
+
+// HandleTranslateArticle creates a draft translation of the article in the
+// target domain, filed under the category of the domain matching the category
+// of the article. Nothing is done when the article already has a translation
+// in the domain.
+func (t articleTasks) HandleTranslateArticle(ctx context.Context, task *asynq.Task) error {
+	var payload TranslateTaskPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("json.Unmarshal failed: %v: %w", err, asynq.SkipRetry)
+	}
+
+	article, err := t.articleService.GetArticle(ctx, payload.ArticleId)
+	if err != nil {
+		return err
+	}
+
+	if article == nil {
+		return fmt.Errorf("article with %d not found: %w", payload.ArticleId, asynq.SkipRetry)
+	}
+
+	if article.DomainId == payload.DomainId {
+		return fmt.Errorf("article with %d already belongs to domain %d: %w", payload.ArticleId, payload.DomainId, asynq.SkipRetry)
+	}
+
+	from, err := t.domainService.GetDomain(ctx, article.DomainId)
+	if err != nil {
+		return err
+	}
+
+	if from == nil {
+		return fmt.Errorf("domain with %d not found: %w", article.DomainId, asynq.SkipRetry)
+	}
+
+	to, err := t.domainService.GetDomain(ctx, payload.DomainId)
+	if err != nil {
+		return err
+	}
+
+	if to == nil {
+		return fmt.Errorf("domain with %d not found: %w", payload.DomainId, asynq.SkipRetry)
+	}
+
+	translations, err := t.articleService.GetArticleTranslations(ctx, article.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, translation := range translations {
+		if translation.DomainId == to.ID {
+			logger.Info().Msgf("Article id: %d is already translated into domain id: %d as article id: %d", article.ID, to.ID, translation.ID)
+			return nil
+		}
+	}
+
+	meter := llm.NewMeter()
+	defer t.recordUsage(services.UsageSource{
+		ArticleId: &payload.ArticleId,
+		DomainId:  to.ID,
+		Task:      TypeArticleTranslate,
+	}, meter)
+
+	category, err := t.categoryService.MapCategoryToDomain(ctx, article.CategoryId, article.Title, to, meter)
+	if errors.As(err, &e.BadRequest{}) {
+		return fmt.Errorf("%v: %w", err, asynq.SkipRetry)
+	}
+
+	if err != nil {
+		return aiError(err)
+	}
+
+	translationId, err := t.articleService.TranslateArticle(ctx, article, from, to, category.ID, meter)
+	if err != nil {
+		return aiError(err)
+	}
+
+	logger.Info().Msgf("Translated article id: %d into domain id: %d as article id: %d", article.ID, to.ID, translationId)
+
+	return nil
+}
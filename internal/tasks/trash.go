@@ -0,0 +1,24 @@
+package tasks
+
+import (
+	"context"
+
+	"github.com/hibiken/asynq"
+)
+
+// PurgeTrashSchedule purges the articles kept in the trash past the retention
+// period every night.
+const PurgeTrashSchedule = "30 3 * * *"
+
+// HandlePurgeTrash permanently deletes the articles kept in the trash for
+// longer than the retention period.
+func (t articleTasks) HandlePurgeTrash(ctx context.Context, task *asynq.Task) error {
+	purged, err := t.articleService.PurgeExpiredArticles(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info().Msgf("Purged %d articles from the trash", purged)
+
+	return nil
+}
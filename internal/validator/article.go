@@ -29,6 +29,10 @@ type ArticleValidation struct {
 	DomainId        int       `validate:"required"`
 	CreatedAt       time.Time `validate:"required"`
 	UpdatedAt       time.Time `validate:"required"`
+	MetaTitle       string    `validate:"max=60"`
+	MetaDescription string    `validate:"max=160"`
+	FocusKeyword    string    `validate:"max=60"`
+	Excerpt         string    `validate:"max=300"`
 }
 
 func (v *articleValidator) Validate(article *models.Article) error {
@@ -43,6 +47,10 @@ func (v *articleValidator) Validate(article *models.Article) error {
 		DomainId:        article.DomainId,
 		CreatedAt:       article.CreatedAt,
 		UpdatedAt:       article.UpdatedAt,
+		MetaTitle:       article.MetaTitle,
+		MetaDescription: article.MetaDescription,
+		FocusKeyword:    article.FocusKeyword,
+		Excerpt:         article.Excerpt,
 	}
 
 	err := v.validate.Struct(propertiesToValidate)
@@ -0,0 +1,26 @@
+package validator
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/rustoma/octo-pulse/internal/errors"
+	"github.com/rustoma/octo-pulse/internal/models"
+)
+
+type llmModelPriceValidator struct {
+	validate *validator.Validate
+}
+
+func newLlmModelPriceValidator(validate *validator.Validate) *llmModelPriceValidator {
+	return &llmModelPriceValidator{
+		validate: validate,
+	}
+}
+
+func (v *llmModelPriceValidator) Validate(price *models.LlmModelPrice) error {
+	err := v.validate.Struct(price)
+	if err != nil {
+		return errors.BadRequest{Err: err.Error()}
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package validator
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/rustoma/octo-pulse/internal/errors"
+	"github.com/rustoma/octo-pulse/internal/models"
+)
+
+type promptTemplateValidator struct {
+	validate *validator.Validate
+}
+
+func newPromptTemplateValidator(validate *validator.Validate) *promptTemplateValidator {
+	return &promptTemplateValidator{
+		validate: validate,
+	}
+}
+
+func (v *promptTemplateValidator) Validate(template *models.PromptTemplate) error {
+	err := v.validate.Struct(template)
+	if err != nil {
+		return errors.BadRequest{Err: err.Error()}
+	}
+
+	return nil
+}
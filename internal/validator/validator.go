@@ -2,33 +2,44 @@ package validator
 
 import (
 	"github.com/go-playground/validator/v10"
+	"github.com/rustoma/octo-pulse/internal/language"
 	"github.com/rustoma/octo-pulse/internal/models"
 )
 
 type Validator struct {
-	Article       ArticleValidatorer
-	Scrapper      ScrapperValidatorer
-	Domain        DomainValidatorer
-	ImageCategory ImageCategoryValidatorer
-	Author        AuthorValidatorer
-	Category      CategoryValidatorer
-	BasicPage     BasicPageValidatorer
+	Article        ArticleValidatorer
+	Scrapper       ScrapperValidatorer
+	Domain         DomainValidatorer
+	ImageCategory  ImageCategoryValidatorer
+	Author         AuthorValidatorer
+	Category       CategoryValidatorer
+	BasicPage      BasicPageValidatorer
+	PromptTemplate PromptTemplateValidatorer
+	LlmModelPrice  LlmModelPriceValidatorer
 }
 
 func NewValidator() *Validator {
 	validate := validator.New(validator.WithRequiredStructEnabled())
+	_ = validate.RegisterValidation("language", validateLanguage)
 
 	return &Validator{
-		Article:       newArticleValidator(validate),
-		Scrapper:      newScrapperValidator(validate),
-		Domain:        newDomainValidator(validate),
-		ImageCategory: newImageCategoryValidator(validate),
-		Author:        newAuthorValidator(validate),
-		Category:      newCategoryValidator(validate),
-		BasicPage:     newBasicPageValidator(validate),
+		Article:        newArticleValidator(validate),
+		Scrapper:       newScrapperValidator(validate),
+		Domain:         newDomainValidator(validate),
+		ImageCategory:  newImageCategoryValidator(validate),
+		Author:         newAuthorValidator(validate),
+		Category:       newCategoryValidator(validate),
+		BasicPage:      newBasicPageValidator(validate),
+		PromptTemplate: newPromptTemplateValidator(validate),
+		LlmModelPrice:  newLlmModelPriceValidator(validate),
 	}
 }
 
+// validateLanguage accepts the ISO 639-1 codes of the supported languages.
+func validateLanguage(fl validator.FieldLevel) bool {
+	return language.IsSupported(fl.Field().String())
+}
+
 type ArticleValidatorer interface {
 	Validate(article *models.Article) error
 }
@@ -56,3 +67,11 @@ type CategoryValidatorer interface {
 type BasicPageValidatorer interface {
 	Validate(category *models.BasicPage) error
 }
+
+type PromptTemplateValidatorer interface {
+	Validate(template *models.PromptTemplate) error
+}
+
+type LlmModelPriceValidatorer interface {
+	Validate(price *models.LlmModelPrice) error
+}